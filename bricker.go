@@ -14,6 +14,118 @@ The bricker is also a producer of events.
 To use this events their will be need consumer (subscriber).
 There is a fallback mechanism for events without a consumer (default fallback subscriber).
 
+# Concurrency
+
+A bricker is safe for concurrent use by multiple go routines.
+All routing tables (connectors, uids, subscriber, choosers and the default fallback subscriber)
+are guarded by one read write lock inside the bricker.
+Methods which change the tables (Attach, Release, Subscribe, Unsubscribe and the default fallback
+methods) take the write lock, the dispatching of incoming events takes the read lock only.
+The dispatcher collects the matching subscriber under the read lock and notifies them after
+the lock is released, so a subscriber could call Subscribe or Unsubscribe from inside Notify.
+A subscriber, which is not a callback, will be unsubscribed before it is notified.
+So it is notified at most once, even if more than one matching event comes in at the same time.
+
+# Event delivery
+
+Every incoming event is put into a queue of its device (connector and uid).
+A queue is worked by one go routine, so the events of a device (e.g. the callbacks of a IO-16 Bricklet)
+reach the subscriber in the order of their arrival, the events of different devices are delivered in parallel.
+The subscriber of an event are notified one after another, a slow subscriber delays the following
+events of the same device. Responses of requests do not wait in the queues.
+The depth of the queues and the policy for a full queue (block the reader of the connector,
+drop the oldest or the newest event) are options of New. DispatchStats gives the counters
+of the delivered and dropped events.
+
+# Interceptors
+
+Every outgoing event passes the interceptor chain before it is sent by the connector,
+every incoming event before the subscriber are notified.
+An interceptor could observe, modify, delay or drop the events (Intercept, RemoveInterceptor).
+The package interceptor has some interceptors (e.g. a packet logger and a rate limiter).
+
+# Flow control
+
+With a flow control (option Flow), the bricker limits the requests, which wait for a response (window),
+and the packets per second of every connector. Packets over the limits wait in a queue of the connector
+and are sent in order, so a burst of requests does not overflow the buffers of the brick daemon.
+FlowStats gives the length of the queues and the waiting times.
+
+# Metrics
+
+The bricker counts the sent and received packets and bytes, the broken packets and the error codes
+of the brick daemon per connector and measures the latency of the responses per function id.
+Metrics gives these counters together with the pending requests, the counters of the event delivery
+and the number of subscriber. The package metrics exposes them in the Prometheus text format.
+
+# Channels
+
+Channel subscribes a subscription and delivers the events into a channel with a buffer.
+The channel is closed, when the context is done or the connector goes away
+(released or disconnected without a reconnect). Every subscriber, which implements
+the Closer interface, is closed with its connector.
+The typed version for the devices is device.Channel.
+
+# Filters
+
+A subscription could have a filter (subscription.Filter) besides its hash.
+The filter matches the events by the device identifier (e.g. all Temperature Bricklets),
+the connector, the class (callbacks or responses) and by a predicate.
+The device identifier is taken from the registry, so a subscription for a device identifier
+follows also the devices, which are connected later (e.g. subscription.NewClass or device.Class).
+A subscriber with a filter without a connector is not closed with a connector.
+
+# Requests and responses
+
+A subscriber, which is not a callback and has a request, waits for exactly one response.
+The bricker gives every such request a sequence number (per connector) and
+tracks the request as outstanding by connector, uid, function id and sequence number.
+The brick daemon answers with the same values, so every response is delivered only to the
+subscriber, which has sent the request, even if more requests with the same uid and function id are on the way.
+Callbacks (sequence number 0) and all other events are routed over the hashes of the subscriptions.
+If the connector closes, all pending subscriber are notified with an error (ErrorConnectorClosed).
+With a retry policy (option Retry), a idempotent request (e.g. a getter) without a response is sent again
+and after the last attempt the subscriber is notified with an error (ErrorNoResponse).
+
+# Devices
+
+The bricker enumerates the devices of every attached connector (and after every reconnect) and
+keeps a registry of all known devices, which is updated by the enumerate callbacks
+(available, newly connected and disconnected devices).
+The registry routes the requests for a uid to the right connector.
+Devices, Device and Topology (a tree of stacks, bricks and bricklets) query the registry.
+
+# Connector states
+
+The bricker tracks the state of every attached connector (connected, disconnected with the cause,
+reconnected and released). Connectors lists all attached connectors with their address and state.
+A state subscriber (SubscribeState) is notified on every state change, in the order of the changes.
+
+# Reconnect
+
+A connector, which could reconnect (connector.StateNotifier), informs the bricker about a lost connection.
+Then all pending subscriber of the connector are notified with an error (ErrorConnectorClosed).
+After the reconnect the bricker restores the session: it sends an enumerate and all requests,
+which configure the devices (subscriptions with the Restore flag, e.g. callback periods and thresholds)
+and callback subscriptions with a request, again.
+
+# Connector groups
+
+Redundant connectors, which reach the same devices (e.g. the brick daemon on USB and a Ethernet
+Master Extension), could form a group (Group). The group name is a destination like a connector name.
+The requests are sent over the first available member (policy Failover) or over the available members
+in turns (policy Spread). When a member goes down, the requests go over the next member and its session
+is sent over that member too. A callback is delivered only once, from the first available member.
+If the first connector (the destination for unknown devices) is released,
+the next attached connector becomes the first one.
+
+# Shutdown
+
+Shutdown stops the bricker gracefully: it takes no new subscriber, could disable the callbacks,
+which the bricker has enabled (option DisableCallbacks), waits for the outstanding responses
+until the context is done, notifies the abandoned subscriber with an error and closes the connectors.
+The report lists, what was abandoned.
+
 For using this API you need a running brick daemon (brickd) or some hardware with a brick daemon,
 please use an actual version of the daemon.
 You get the daemon from http://www.tinkerforge.com/en/doc/Software/Brickd.html#brickd as
@@ -26,103 +138,256 @@ package bricker
 import (
 	"github.com/dirkjabl/bricker/connector"
 	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
 	"github.com/dirkjabl/bricker/util/hash"
+	"sync"
 )
 
 // The bricker type.
 // A bricker managed connectors and subscriber.
 type Bricker struct {
+	dispatcher        *dispatcher  // delivers the incoming events per device in order
+	meter             *meter       // counts the packets and measures the response latency
+	throttle          *throttle    // limits the outgoing packets per connector
+	retry             RetryPolicy  // retry of the idempotent requests
+	statelock         sync.Mutex   // orders the state changes and their notifies, taken before lock
+	lock              sync.RWMutex // guards all following fields
 	connection        map[string]connector.Connector
 	first             string
 	uids              map[uint32]string
 	subscriber        map[hash.Hash]map[string]Subscriber
 	choosers          []uint8
 	defaultsubscriber Subscriber
+	sequences         map[string]*connector.Sequence   // sequence numbers per connector
+	outstanding       map[request]Subscriber           // requests, which wait for a response
+	pending           map[pendingKey]request           // subscriber, which wait for a response
+	sessions          map[string][]sessionEntry        // configuration requests per connector for a restore
+	states            map[string]ConnectorInfo         // state per connector
+	registry          map[string]map[uint32]DeviceInfo // known devices per connector
+	statesubscriber   map[string]StateSubscriber       // subscriber for connector state changes
+	closers           map[pendingKey]string            // connector of the subscriber, which are closed with it
+	interceptors      []interceptor                    // interceptor chain, copy on write
+	disablers         map[string][]*packet.Packet      // requests per connector, which disable callbacks
+	closing           bool                             // shutdown is running, no new subscriber
+	drained           chan struct{}                    // closed, when no request is outstanding (nil without a waiter)
+	attached          []string                         // names of the connectors in the order of attaching
+	groups            map[string]*group                // connector groups by name
+	grouped           map[string]*group                // group of every member
 }
 
 // New create the bricker.
 // The new bricker start direct the service.
 // After start, the bricker has no connection and no subscriber.
-func New() *Bricker {
-	return &Bricker{
-		connection: make(map[string]connector.Connector),
-		first:      "",
-		uids:       make(map[uint32]string),
-		subscriber: make(map[hash.Hash]map[string]Subscriber),
-		choosers:   make([]uint8, 0)}
+// The options configure the delivery of the incoming events (see QueueDepth and OverflowPolicy),
+// the latency histograms (LatencyBuckets), the retry of requests (Retry) and the flow control (Flow).
+func New(opts ...Option) *Bricker {
+	b := &Bricker{
+		connection:      make(map[string]connector.Connector),
+		first:           "",
+		uids:            make(map[uint32]string),
+		subscriber:      make(map[hash.Hash]map[string]Subscriber),
+		choosers:        make([]uint8, 0),
+		sequences:       make(map[string]*connector.Sequence),
+		outstanding:     make(map[request]Subscriber),
+		pending:         make(map[pendingKey]request),
+		sessions:        make(map[string][]sessionEntry),
+		states:          make(map[string]ConnectorInfo),
+		registry:        make(map[string]map[uint32]DeviceInfo),
+		statesubscriber: make(map[string]StateSubscriber),
+		closers:         make(map[pendingKey]string),
+		disablers:       make(map[string][]*packet.Packet),
+		attached:        make([]string, 0),
+		groups:          make(map[string]*group),
+		grouped:         make(map[string]*group)}
+	b.dispatcher = newDispatcher(b.dispatch)
+	b.meter = newMeter()
+	b.throttle = newThrottle(b.transmit)
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // Done release all connections and subscriber and release all resources.
+// The connectors are not closed and no response is awaited, for a graceful stop see Shutdown.
 func (b *Bricker) Done() {
 	// Unsubscribe all subscriber.
-	for _, subs := range b.subscriber {
-		for _, s := range subs {
-			b.Unsubscribe(s)
-		}
+	for _, s := range b.subscribers() {
+		b.Unsubscribe(s)
 	}
 	// Release all connections.
-	for name, _ := range b.connection {
+	for _, name := range b.connectorNames() {
 		b.Release(name)
 	}
 }
 
-// Internal method: read wait for a new event and forward it to the dispatcher.
+// Internal method: read wait for a new event and forward it to the queue of the device.
 func (b *Bricker) read(c connector.Connector, n string) {
 	var ev *event.Event
 	for {
 		ev = c.Receive()
 		if ev == nil {
+			var cause error
+			if f, ok := c.(connector.Failer); ok {
+				cause = f.Err()
+			}
+			b.stateChanged(n, connector.StateDisconnected, cause)
 			return // done, no more packets
 		}
 		ev.ConnectorName = n
-		go b.dispatch(ev)
+		b.meter.received(ev)
+		b.dispatcher.enqueue(ev)
 	}
 }
 
-// Internal method: write takes a event and send it to the right bricker (dispatch).
+// Internal method: write takes a event and send it to the right connector.
+// The event passes the interceptor chain first, a dropped request is answered with an error.
 func (b *Bricker) write(e *event.Event) {
-	if e != nil {
-		if conn, ok := b.connection[e.ConnectorName]; ok {
-			conn.Send(e)
-		} else {
-			e.Err = NewError(ErrorConnectorNameNotExists)
-			go b.dispatch(e)
+	if e == nil {
+		return
+	}
+	if chain := b.chain(); len(chain) == 0 {
+		b.send(e)
+	} else if !intercept(chain, Outbound, e, b.send) {
+		if _, ok := requestOf(e); ok {
+			e.Err = NewError(ErrorEventDropped)
+			go b.deliver(e)
 		}
 	}
 }
 
-// Internal method: process dispatch the event to the right subscriber.
-func (b *Bricker) dispatch(e *event.Event) {
-	var h hash.Hash
-	if e.Packet == nil { // without a packet, no subscriber could be determined
-		go b.process(e, b.defaultsubscriber)
+// Internal method: send takes a event and send it to the right connector (over the flow control)
+// or dispatch an error.
+func (b *Bricker) send(e *event.Event) {
+	b.lock.RLock()
+	conn, ok := b.connection[e.ConnectorName]
+	b.lock.RUnlock()
+	if !ok {
+		e.Err = NewError(ErrorConnectorNameNotExists)
+		go b.deliver(e)
+	} else if b.throttle.enabled() {
+		b.throttle.enqueue(conn, e)
 	} else {
-		match := false
+		b.transmit(conn, e)
+	}
+}
+
+// Internal method: transmit counts the event and sends it with the connector.
+func (b *Bricker) transmit(conn connector.Connector, e *event.Event) {
+	b.meter.sent(e)
+	conn.Send(e)
+}
+
+// Internal method: dispatch passes the event through the interceptor chain and delivers it.
+// A dropped response is delivered as error (ErrorEventDropped) to the subscriber of the request.
+func (b *Bricker) dispatch(e *event.Event) {
+	if chain := b.chain(); len(chain) != 0 {
+		r, response := requestOf(e) // the interceptors could change the event
+		if !intercept(chain, Inbound, e, b.deliver) && response && b.isOutstanding(r) {
+			p := packet.NewSimpleHeaderOnly(r.uid, r.fid, false)
+			p.Head.SetSequence(r.seq)
+			ev := event.NewSimple(NewError(ErrorEventDropped), p)
+			ev.ConnectorName = r.connector
+			b.deliver(ev)
+		}
+		return
+	}
+	b.deliver(e) // no chain, no allocation
+}
+
+// Internal method: deliver the event to the right subscriber.
+// The subscriber are notified one after another, in the order of the match.
+func (b *Bricker) deliver(e *event.Event) {
+	di := b.observe(e)
+	if b.redundant(e) { // the callback is delivered from another member of the group
+		return
+	}
+	var buf [8]Subscriber // most events have only a few subscriber, no allocation for them
+	for _, s := range b.match(e, di, buf[:0]) {
+		b.process(e, s)
+	}
+}
+
+// Internal method: match appends all subscriber for the given event to subs.
+// The device identifier is set for an enumerate callback, otherwise it is taken from the registry.
+// The filters of the subscriptions are checked without the lock, so a predicate could use the bricker.
+// If no subscriber matches, the default fallback subscriber is the result (if one exists).
+func (b *Bricker) match(e *event.Event, di uint16, subs []Subscriber) []Subscriber {
+	subs, di, response := b.collect(e, di, subs)
+	if response { // the response is only for the subscriber of the request
+		b.meter.responded(e)
+		return subs
+	}
+	n := 0
+	for _, s := range subs {
+		if s.Subscription().Accept(e, di) {
+			subs[n] = s
+			n++
+		}
+	}
+	subs = subs[:n]
+	if len(subs) == 0 { // no subscriber hash matched against packet hash
+		b.lock.RLock()
+		if b.defaultsubscriber != nil {
+			subs = append(subs, b.defaultsubscriber)
+		}
+		b.lock.RUnlock()
+	}
+	return subs
+}
+
+// Internal method: collect appends all subscriber, which hashes match the event, to subs
+// and determines the device identifier of the event.
+// The result response is true, if the event is the response for an outstanding request.
+// The routing tables are only read under the read lock, the result is a snapshot.
+func (b *Bricker) collect(e *event.Event, di uint16, subs []Subscriber) ([]Subscriber, uint16, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if r, ok := requestOf(e); ok { // response for an outstanding request
+		if s, ok := b.outstanding[r]; ok {
+			return append(subs, s), di, true
+		}
+	}
+	if e.Packet != nil && e.Packet.Head != nil { // without a packet, no subscriber could be determined
+		if di == 0 {
+			di = b.registry[e.ConnectorName][e.Packet.Head.Uid].DeviceIdentifier
+		}
 		for _, chooser := range b.choosers {
-			h = hash.New(chooser, e.Packet.Head.Uid, e.Packet.Head.FunctionID)
-			if s, ok := b.subscriber[h]; ok {
-				match = true && (len(s) > 0)
-				go func(ev *event.Event, subs map[string]Subscriber) {
-					for _, s := range subs {
-						go b.process(e, s)
-					}
-				}(e, s)
+			h := hash.New(chooser, e.Packet.Head.Uid, e.Packet.Head.FunctionID)
+			for id, s := range b.subscriber[h] {
+				if _, ok := b.pending[pendingKey{h, id}]; !ok { // pending subscriber wait for their response
+					subs = append(subs, s)
+				}
 			}
 		}
-		if !match { // no subscriber hash matched against packet hash
-			go b.process(e, b.defaultsubscriber)
-		}
 	}
+	return subs, di, false
 }
 
 // Internal method: process notify given subscriber.
+// A subscriber, which is not a callback, is unsubscribed first and only notified,
+// if it was still subscribed. This garanties only one notify.
 func (b *Bricker) process(e *event.Event, sub Subscriber) {
 	if sub == nil {
 		return // no subscriber, no notify
-	} else {
-		sub.Notify(e)
-		if !sub.Subscription().Callback { // not a callback, call only once
-			b.Unsubscribe(sub)
+	}
+	if !sub.Subscription().Callback && !b.isDefaultFallback(sub) { // not a callback, call only once
+		if b.Unsubscribe(sub) != nil {
+			return // already notified by another event
+		}
+	}
+	sub.Notify(e)
+}
+
+// Internal method: subscribers returns a snapshot of all registered subscriber.
+func (b *Bricker) subscribers() []Subscriber {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	subs := make([]Subscriber, 0)
+	for _, m := range b.subscriber {
+		for _, s := range m {
+			subs = append(subs, s)
 		}
 	}
+	return subs
 }
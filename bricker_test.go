@@ -0,0 +1,385 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"fmt"
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/subscription"
+	"github.com/dirkjabl/bricker/util/hash"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testSubscriber is a simple subscriber, it counts the notifies.
+type testSubscriber struct {
+	id       string
+	sub      *subscription.Subscription
+	count    int32
+	notified chan *event.Event
+}
+
+func newTestSubscriber(id string, uid uint32, fid uint8, request bool, callback bool) *testSubscriber {
+	var p *packet.Packet
+	if request {
+		p = packet.NewSimpleHeaderOnly(uid, fid, true)
+	}
+	return &testSubscriber{
+		id:       id,
+		sub:      subscription.New(hash.ChoosenFunctionIDUid, uid, fid, p, callback),
+		notified: make(chan *event.Event, 1)}
+}
+
+func (s *testSubscriber) Id() string {
+	return s.id
+}
+
+func (s *testSubscriber) Subscription() *subscription.Subscription {
+	return s.sub
+}
+
+func (s *testSubscriber) Notify(e *event.Event) {
+	atomic.AddInt32(&s.count, 1)
+	select {
+	case s.notified <- e:
+	default:
+	}
+}
+
+// echoGenerator answers every request with a copy of the request packet.
+func echoGenerator(e *event.Event) *event.Event {
+	if e == nil || e.Packet == nil {
+		return nil
+	}
+	return event.NewPacket(packet.NewSimpleHeaderOnly(e.Packet.Head.Uid, e.Packet.Head.FunctionID, false))
+}
+
+// deviceGenerator answers only the requests for the device with the uid with the generator.
+func deviceGenerator(uid uint32, gen virtual.GeneratorFunc) virtual.GeneratorFunc {
+	return func(e *event.Event) *event.Event {
+		if e == nil || e.Packet == nil || e.Packet.Head.Uid != uid {
+			return nil
+		}
+		return gen(e)
+	}
+}
+
+// newTestBricker creates a bricker with the options and attaches the virtual connector "virtual",
+// which answers the requests with the generator (nil for no answers).
+func newTestBricker(tb testing.TB, gen virtual.GeneratorFunc, opts ...Option) (*Bricker, *virtual.Virtual) {
+	b := New(opts...)
+	return b, attachTestConnector(tb, b, "virtual", gen)
+}
+
+// attachTestConnector attaches a further virtual connector with the name,
+// which answers the requests with the generator (nil for no answers).
+func attachTestConnector(tb testing.TB, b *Bricker, name string, gen virtual.GeneratorFunc) *virtual.Virtual {
+	v := virtual.New()
+	if gen != nil {
+		v.AttachFallbackGenerator(gen)
+	}
+	if err := b.Attach(v, name); err != nil {
+		tb.Fatalf("Error %s: could not attach virtual connector %s (%s).", tb.Name(), name, err.Error())
+	}
+	return v
+}
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	b := New()
+	s := newTestSubscriber("test", 1, 2, false, true)
+	if err := b.Subscribe(s, nil); err != nil {
+		t.Fatalf("Error TestSubscribeUnsubscribe: subscribe failed (%s).", err.Error())
+	}
+	if err := b.Subscribe(s, nil); err == nil {
+		t.Fatalf("Error TestSubscribeUnsubscribe: subscribe twice should fail.")
+	}
+	if err := b.Unsubscribe(s); err != nil {
+		t.Fatalf("Error TestSubscribeUnsubscribe: unsubscribe failed (%s).", err.Error())
+	}
+	if err := b.Unsubscribe(s); err == nil {
+		t.Fatalf("Error TestSubscribeUnsubscribe: unsubscribe twice should fail.")
+	}
+}
+
+func TestConcurrentSubscribeDispatch(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	defer b.Done()
+	var wg sync.WaitGroup
+	for g := 0; g < 20; g++ {
+		wg.Add(2)
+		go func(g int) { // subscribe and unsubscribe callbacks
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s := newTestSubscriber(fmt.Sprintf("cb-%d-%d", g, i), uint32(g), uint8(i%4), false, true)
+				b.Subscribe(s, nil)
+				b.Unsubscribe(s)
+			}
+		}(g)
+		go func(g int) { // dispatch events
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				b.dispatch(event.NewPacket(packet.NewSimpleHeaderOnly(uint32(g), uint8(i%4), false)))
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	defer b.Done()
+	var wg sync.WaitGroup
+	subs := make([]*testSubscriber, 0)
+	var lock sync.Mutex
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			s := newTestSubscriber(fmt.Sprintf("request-%d", g), uint32(g%5), 1, true, false)
+			lock.Lock()
+			subs = append(subs, s)
+			lock.Unlock()
+			if err := b.Subscribe(s, "virtual"); err != nil {
+				t.Errorf("Error TestConcurrentRequests: subscribe failed (%s).", err.Error())
+				return
+			}
+			select {
+			case <-s.notified:
+			case <-time.After(5 * time.Second):
+				t.Errorf("Error TestConcurrentRequests: subscriber %s was not notified.", s.Id())
+			}
+		}(g)
+	}
+	wg.Wait()
+	time.Sleep(10 * time.Millisecond) // give late events the chance to arrive
+	for _, s := range subs {
+		if c := atomic.LoadInt32(&s.count); c != 1 {
+			t.Fatalf("Error TestConcurrentRequests: subscriber %s notified %d times, expected once.", s.Id(), c)
+		}
+	}
+}
+
+func TestResponseCorrelation(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	defer b.Done()
+	var counter uint32
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event { // every response has a new value
+		c := atomic.AddUint32(&counter, 1)
+		return event.NewPacket(packet.NewSimpleHeaderPayload(e.Packet.Head.Uid, e.Packet.Head.FunctionID, false, c))
+	})
+	subs := make([]*testSubscriber, 10)
+	for i := range subs {
+		subs[i] = newTestSubscriber(fmt.Sprintf("correlation-%d", i), 7, 1, true, false)
+		if err := b.Subscribe(subs[i], "virtual"); err != nil {
+			t.Fatalf("Error TestResponseCorrelation: subscribe failed (%s).", err.Error())
+		}
+	}
+	values := make(map[uint32]bool)
+	for _, s := range subs {
+		select {
+		case e := <-s.notified:
+			var value uint32
+			if err := e.Packet.Payload.Decode(&value); err != nil {
+				t.Fatalf("Error TestResponseCorrelation: could not decode response (%s).", err.Error())
+			}
+			if values[value] {
+				t.Fatalf("Error TestResponseCorrelation: response %d delivered twice.", value)
+			}
+			values[value] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Error TestResponseCorrelation: subscriber %s was not notified.", s.Id())
+		}
+	}
+}
+
+func TestConcurrentAttachRelease(t *testing.T) {
+	b := New()
+	defer b.Done()
+	var wg sync.WaitGroup
+	for g := 0; g < 20; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				v := virtual.New()
+				n := fmt.Sprintf("virtual-%d-%d", g, i)
+				b.Attach(v, n)
+				b.Release(n)
+				v.Done()
+			}
+		}(g)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s := newTestSubscriber(fmt.Sprintf("attach-%d-%d", g, i), uint32(g), 1, true, true)
+				b.Subscribe(s, uint32(g))
+				b.Unsubscribe(s)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestDefaultFallback(t *testing.T) {
+	b := New()
+	s := newTestSubscriber("fallback", 0, 0, false, false)
+	b.SubscribeDefaultFallback(s)
+	b.dispatch(event.NewPacket(packet.NewSimpleHeaderOnly(1, 1, false)))
+	b.dispatch(event.NewPacket(packet.NewSimpleHeaderOnly(1, 1, false)))
+	time.Sleep(10 * time.Millisecond)
+	if c := atomic.LoadInt32(&s.count); c != 2 {
+		t.Fatalf("Error TestDefaultFallback: fallback subscriber notified %d times, expected 2.", c)
+	}
+	b.UnsubscribeDefaultFallback()
+	b.dispatch(event.NewPacket(packet.NewSimpleHeaderOnly(1, 1, false)))
+	time.Sleep(10 * time.Millisecond)
+	if c := atomic.LoadInt32(&s.count); c != 2 {
+		t.Fatalf("Error TestDefaultFallback: released fallback subscriber notified (%d).", c)
+	}
+}
+
+func TestRestoreSession(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	defer b.Done()
+	sent := make(chan uint8, 10)
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event {
+		sent <- e.Packet.Head.FunctionID
+		return nil
+	})
+	restore := newTestSubscriber("restore", 3, 10, true, false)
+	restore.sub.Restore = true
+	once := newTestSubscriber("once", 3, 11, true, false)
+	for _, s := range []*testSubscriber{restore, once} {
+		if err := b.Subscribe(s, "virtual"); err != nil {
+			t.Fatalf("Error TestRestoreSession: subscribe failed (%s).", err.Error())
+		}
+		<-sent
+	}
+	b.stateChanged("virtual", connector.StateReconnected, nil)
+	for _, expected := range []uint8{function_enumerate, 10} {
+		select {
+		case fid := <-sent:
+			if fid != expected {
+				t.Fatalf("Error TestRestoreSession: function id %d sent, expected %d.", fid, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestRestoreSession: function id %d not sent.", expected)
+		}
+	}
+	select {
+	case fid := <-sent:
+		t.Fatalf("Error TestRestoreSession: unexpected function id %d sent.", fid)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestRestoreUnsubscribed(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	defer b.Done()
+	sent := make(chan uint8, 10)
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event {
+		sent <- e.Packet.Head.FunctionID
+		return nil
+	})
+	callback := newTestSubscriber("callback", 3, 12, true, true)
+	if err := b.Subscribe(callback, "virtual"); err != nil {
+		t.Fatalf("Error TestRestoreUnsubscribed: subscribe failed (%s).", err.Error())
+	}
+	<-sent
+	b.Unsubscribe(callback)
+	b.stateChanged("virtual", connector.StateReconnected, nil)
+	if fid := <-sent; fid != function_enumerate {
+		t.Fatalf("Error TestRestoreUnsubscribed: function id %d sent, expected %d.", fid, function_enumerate)
+	}
+	select {
+	case fid := <-sent:
+		t.Fatalf("Error TestRestoreUnsubscribed: request %d of a unsubscribed subscriber sent.", fid)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+// testStateSubscriber collects the state changes.
+type testStateSubscriber struct {
+	states chan ConnectorInfo
+}
+
+func (s *testStateSubscriber) Id() string {
+	return "states"
+}
+
+func (s *testStateSubscriber) NotifyState(ci ConnectorInfo) {
+	s.states <- ci
+}
+
+func TestConnectorStates(t *testing.T) {
+	b := New()
+	defer b.Done()
+	s := &testStateSubscriber{states: make(chan ConnectorInfo, 10)}
+	if err := b.SubscribeState(s); err != nil {
+		t.Fatalf("Error TestConnectorStates: subscribe failed (%s).", err.Error())
+	}
+	if err := b.SubscribeState(s); err == nil {
+		t.Fatalf("Error TestConnectorStates: subscribe twice should fail.")
+	}
+	v := virtual.New()
+	b.Attach(v, "virtual")
+	if infos := b.Connectors(); len(infos) != 1 || infos[0].Name != "virtual" ||
+		infos[0].State != connector.StateConnected {
+		t.Fatalf("Error TestConnectorStates: wrong connectors (%v).", infos)
+	}
+	v.Done()
+	for _, expected := range []connector.State{connector.StateConnected, connector.StateDisconnected} {
+		select {
+		case ci := <-s.states:
+			if ci.Name != "virtual" || ci.State != expected {
+				t.Fatalf("Error TestConnectorStates: state %v, expected %s.", ci, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestConnectorStates: no state %s.", expected)
+		}
+	}
+	if ci, err := b.Connector("virtual"); err != nil || ci.State != connector.StateDisconnected {
+		t.Fatalf("Error TestConnectorStates: connector not disconnected (%v).", ci)
+	}
+	b.Release("virtual")
+	if ci := <-s.states; ci.State != connector.StateReleased {
+		t.Fatalf("Error TestConnectorStates: state %v, expected released.", ci)
+	}
+	if _, err := b.Connector("virtual"); err == nil {
+		t.Fatalf("Error TestConnectorStates: released connector is still listed.")
+	}
+	if err := b.UnsubscribeState(s); err != nil {
+		t.Fatalf("Error TestConnectorStates: unsubscribe failed (%s).", err.Error())
+	}
+}
+
+func TestConnectorsSorted(t *testing.T) {
+	b := New()
+	defer b.Done()
+	for _, n := range []string{"usb", "eth", "wifi", "ble"} {
+		attachTestConnector(t, b, n, nil)
+	}
+	for i := 0; i < 10; i++ {
+		infos := b.Connectors()
+		names := make([]string, 0, len(infos))
+		for _, ci := range infos {
+			names = append(names, ci.Name)
+		}
+		if strings.Join(names, ",") != "ble,eth,usb,wifi" {
+			t.Fatalf("Error TestConnectorsSorted: wrong order (%v).", names)
+		}
+	}
+}
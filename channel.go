@@ -0,0 +1,132 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/subscription"
+	"sync"
+)
+
+// Closer is a subscriber, which is closed, when the connector of its subscription goes away
+// (the connector is released or disconnected and could not reconnect).
+// The subscriber is unsubscribed, before it is closed.
+// A subscriber with a filter (see subscription.Filter) is only closed with the connector of the filter.
+type Closer interface {
+	Subscriber
+	Close()
+}
+
+// Internal type: channelSubscriber delivers the events into a channel.
+type channelSubscriber struct {
+	id   string
+	sub  *subscription.Subscription
+	ctx  context.Context
+	gone chan struct{} // closed with the subscriber
+	once sync.Once
+	lock sync.Mutex // orders the deliveries and the close of the channel
+	done bool
+	c    chan *event.Event
+}
+
+/*
+Channel subscribes the subscription with the id and delivers all matching events into the returned channel.
+The destination is the same as for the Subscribe method (connector name or uid).
+The channel has a buffer for size events. If the buffer is full, the delivery of the following events
+of the device waits for the consumer (see the event delivery in the package documentation).
+
+After the context is done, the subscription is unsubscribed and the channel is closed.
+The channel is also closed, when the connector goes away (see Closer) and
+after the event of a subscription, which is not a callback.
+*/
+func (b *Bricker) Channel(ctx context.Context, id string, s *subscription.Subscription,
+	dest interface{}, size int) (<-chan *event.Event, error) {
+	if size < 0 {
+		size = 0
+	}
+	cs := &channelSubscriber{
+		id:   id,
+		sub:  s,
+		ctx:  ctx,
+		gone: make(chan struct{}),
+		c:    make(chan *event.Event, size)}
+	if err := b.Subscribe(cs, dest); err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.Unsubscribe(cs)
+			cs.Close()
+		case <-cs.gone:
+		}
+	}()
+	return cs.c, nil
+}
+
+// Id returns the id of the subscriber (fullfill the Subscriber interface).
+func (cs *channelSubscriber) Id() string {
+	return cs.id
+}
+
+// Subscription returns the subscription (fullfill the Subscriber interface).
+func (cs *channelSubscriber) Subscription() *subscription.Subscription {
+	return cs.sub
+}
+
+// Notify puts the event into the channel, until the context is done or the subscriber is closed.
+func (cs *channelSubscriber) Notify(e *event.Event) {
+	cs.lock.Lock()
+	if !cs.done {
+		select {
+		case cs.c <- e:
+		case <-cs.ctx.Done():
+		case <-cs.gone:
+		}
+	}
+	cs.lock.Unlock()
+	if !cs.sub.Callback { // only one event, the subscriber is already unsubscribed
+		cs.Close()
+	}
+}
+
+// Close closes the channel (fullfill the Closer interface).
+func (cs *channelSubscriber) Close() {
+	cs.once.Do(func() {
+		close(cs.gone) // a waiting delivery stops
+		cs.lock.Lock()
+		defer cs.lock.Unlock()
+		cs.done = true
+		close(cs.c)
+	})
+}
+
+// Internal method: closeSubscriber unsubscribes and closes all subscriber (Closer) of the named connector.
+func (b *Bricker) closeSubscriber(n string) {
+	b.lock.Lock()
+	closers := make([]Closer, 0)
+	for k, name := range b.closers {
+		if name != n {
+			continue
+		}
+		if c, ok := b.subscriber[k.hash][k.id].(Closer); ok {
+			closers = append(closers, c)
+		}
+		b.unsubscribe(k)
+	}
+	b.lock.Unlock()
+	for _, c := range closers {
+		c.Close()
+	}
+}
+
+// Internal method: canReconnect checks, if the named connector could reconnect after a disconnect.
+// The caller has to hold the read lock.
+func (b *Bricker) canReconnect(n string) bool {
+	_, ok := b.connection[n].(connector.StateNotifier)
+	return ok
+}
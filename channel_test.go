@@ -0,0 +1,108 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/subscription"
+	"github.com/dirkjabl/bricker/util/hash"
+	"testing"
+	"time"
+)
+
+// Internal function: waitClosed waits, until the channel is closed.
+func waitClosed(t *testing.T, c <-chan *event.Event) {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-c:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Error %s: channel is not closed.", t.Name())
+		}
+	}
+}
+
+// Internal function: sendCallback puts a callback for the uid and function id into the queue of the device, like a incoming event.
+func sendCallback(b *Bricker, uid uint32, fid uint8, value uint32) {
+	ev := testCallback("virtual", uid, fid, value)
+	b.dispatcher.enqueue(ev)
+}
+
+func TestChannel(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	s := subscription.New(hash.ChoosenFunctionIDUid, 3, 9, nil, true)
+	c, err := b.Channel(ctx, "channel", s, "virtual", 4)
+	if err != nil {
+		t.Fatalf("Error TestChannel: unexpected error (%s).", err.Error())
+	}
+	for i := uint32(1); i <= 4; i++ {
+		sendCallback(b, 3, 9, i)
+	}
+	for i := uint32(1); i <= 4; i++ {
+		select {
+		case e := <-c:
+			tv := new(testValue)
+			if e.Packet.Payload.Decode(tv); tv.Value != i {
+				t.Fatalf("Error TestChannel: wrong value %d, expected %d.", tv.Value, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestChannel: no event.")
+		}
+	}
+	cancel()
+	waitClosed(t, c)
+	if b.Unsubscribe(&noopSubscriber{id: "channel", sub: s}) == nil {
+		t.Fatalf("Error TestChannel: subscription is not released after cancel.")
+	}
+}
+
+func TestChannelRequest(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	s := subscription.New(hash.ChoosenFunctionIDUid, 3, 2, packet.NewSimpleHeaderOnly(3, 2, true), false)
+	c, err := b.Channel(context.Background(), "request", s, "virtual", 0)
+	if err != nil {
+		t.Fatalf("Error TestChannelRequest: unexpected error (%s).", err.Error())
+	}
+	select {
+	case e := <-c:
+		if e == nil || e.Packet.Head.FunctionID != 2 {
+			t.Fatalf("Error TestChannelRequest: wrong response (%v).", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestChannelRequest: no response.")
+	}
+	waitClosed(t, c)
+}
+
+func TestChannelConnectorGone(t *testing.T) {
+	for _, release := range []bool{true, false} {
+		b, v := newTestBricker(t, echoGenerator)
+		ctx, cancel := context.WithCancel(context.Background())
+		s := subscription.New(hash.ChoosenFunctionIDUid, 3, 9, nil, true)
+		c, err := b.Channel(ctx, "gone", s, "virtual", 0)
+		if err != nil {
+			t.Fatalf("Error TestChannelConnectorGone: unexpected error (%s).", err.Error())
+		}
+		if release {
+			b.Release("virtual")
+		} else {
+			v.Done() // disconnected, the virtual connector could not reconnect
+		}
+		waitClosed(t, c)
+		if b.Unsubscribe(&noopSubscriber{id: "gone", sub: s}) == nil {
+			t.Fatalf("Error TestChannelConnectorGone: subscription is not released (release: %t).", release)
+		}
+		cancel()
+		v.Done()
+	}
+}
@@ -10,41 +10,129 @@ import (
 
 // AttachConnector adds a named connector to the bricker.
 // The name must be unique and should not used before.
+// The state subscriber are notified about the connected connector.
+// After attaching, the devices behind the connector are enumerated.
 func (b *Bricker) Attach(c connector.Connector, n string) error {
+	b.statelock.Lock()
+	b.lock.Lock()
+	if b.closing {
+		b.lock.Unlock()
+		b.statelock.Unlock()
+		return NewError(ErrorShutdown)
+	}
 	if _, ok := b.connection[n]; ok { // name exists, no add
+		b.lock.Unlock()
+		b.statelock.Unlock()
+		return NewError(ErrorConnectorNameExists)
+	}
+	if _, ok := b.groups[n]; ok { // name of a group
+		b.lock.Unlock()
+		b.statelock.Unlock()
 		return NewError(ErrorConnectorNameExists)
 	}
 	b.connection[n] = c
+	b.attached = append(b.attached, n)
 	if b.first == "" {
 		b.first = n
 	}
+	if sn, ok := c.(connector.StateNotifier); ok {
+		sn.OnStateChange(func(s connector.State, err error) {
+			b.stateChanged(n, s, err)
+		})
+	}
+	ci, subs := b.setState(n, connector.StateConnected, nil)
+	b.lock.Unlock()
 	go b.read(c, n) // start working for incoming events
+	notifyState(ci, subs)
+	b.statelock.Unlock()
+	b.enumerate(n)
 	return nil
 }
 
 // ReleaseConnector take a connector from the bricker.
+// The state subscriber are notified about the released connector.
+// If the connector is the first one, the next attached connector becomes the first.
+// The session of a member of a group is sent over the next available member (see Group).
 func (b *Bricker) Release(n string) error {
+	b.statelock.Lock()
+	defer b.statelock.Unlock()
+	b.lock.Lock()
 	if _, ok := b.connection[n]; !ok { // name does not exists
+		b.lock.Unlock()
 		return NewError(ErrorNoConnectorToRelease)
 	}
 	if n == b.first {
-		b.first = ""
-	} // TODO: search for a new connector as first
+		b.first = b.electFirst(n)
+	}
+	takeover := b.takeover(n, true)
+	orphaned := b.orphaned(n)
+	ci, subs := b.setState(n, connector.StateReleased, nil)
 	delete(b.connection, n)
+	for i, m := range b.attached {
+		if m == n {
+			b.attached = append(b.attached[:i:i], b.attached[i+1:]...)
+			break
+		}
+	}
+	delete(b.sessions, n)
+	delete(b.disablers, n)
+	delete(b.states, n)
+	b.forget(n)
+	b.lock.Unlock()
+	b.dispatcher.release(n)
+	b.meter.release(n)
+	b.throttle.release(n)
+	notifyState(ci, subs)
+	b.closeSubscriber(n)
+	if orphaned != "" {
+		b.closeSubscriber(orphaned)
+	}
+	for _, ev := range takeover {
+		b.write(ev)
+	}
 	return nil
 }
 
+// ConnectorFor returns the name of the connector, over which the device with the given uid is reachable.
+// If the device is unknown (not enumerated), the first attached connector is the result.
+func (b *Bricker) ConnectorFor(uid uint32) string {
+	return b.computeConnectorsName(uid)
+}
+
 // Internal method: computeConnectorsName try to compute the connectors name from the given parameter.
 // The parameter could be a string with the name, a uid of a device (uint32) or nil, then the
 // first registered connector will be used.
+// The name of a group and a device behind a member of a group are resolved to the member, which sends the request.
 func (b *Bricker) computeConnectorsName(d interface{}) string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
 	switch value := d.(type) {
 	case string:
+		if g, ok := b.groups[value]; ok {
+			return b.elect(g, 0, true)
+		}
 		return value
 	case uint32:
 		if n, ok := b.uids[value]; ok {
+			if g, ok := b.grouped[n]; ok {
+				return b.elect(g, value, true)
+			}
 			return n
 		} // when not ok, than first connector, if possible
 	}
+	if g, ok := b.grouped[b.first]; ok {
+		return b.elect(g, 0, true)
+	}
 	return b.first
 }
+
+// Internal method: connectorNames returns a snapshot of the names of all attached connectors.
+func (b *Bricker) connectorNames() []string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	names := make([]string, 0, len(b.connection))
+	for name := range b.connection {
+		names = append(names, name)
+	}
+	return names
+}
@@ -10,7 +10,8 @@ import (
 	"github.com/dirkjabl/bricker/connector"
 	"github.com/dirkjabl/bricker/event"
 	"github.com/dirkjabl/bricker/net"
-	"github.com/dirkjabl/bricker/net/packet"
+	"io"
+	"sync"
 )
 
 // ConnectorBuffered is the connector with to bufferd channels,
@@ -19,18 +20,32 @@ import (
 // The connector puts all of his readed packets into events in the In channel.
 // The connector waits for packets to write out to the hardware on the Out channel.
 // A close on the Quit channel let the bricker stops all go routines and disconnect to the hardware.
+// If the connection to the hardware is lost, the In channel will be closed and
+// the cause could be get with the Err method.
 type ConnectorBuffered struct {
 	conn *net.Net            // internal, the real connection
 	seq  *connector.Sequence // internal, actual sequence number
 	In   chan *event.Event   // input channel, here the bricker put in the readed packets as events
 	Out  chan *event.Event   // output channel, here the bricker read out the events, which should be send
 	Quit chan struct{}       // quit channel, if closed, the bricker stop working and release resources
+	once sync.Once           // internal, close the quit channel only once
+	lock sync.Mutex          // internal, guards err
+	err  error               // internal, the error, which ends the reading
 }
 
 // New creates the connector object with a connection to the given address (addr).
 // The function takes to integers for the size of the input and output buffer (channels).
-func New(addr string, inbuf, outbuf int) (*ConnectorBuffered, error) {
-	conn, err := net.Dial(addr)
+// The options are done after the connection is established (e.g. authentication, see net/auth).
+func New(addr string, inbuf, outbuf int, opts ...net.Option) (*ConnectorBuffered, error) {
+	return NewDialer(addr, net.TCP(addr), inbuf, outbuf, opts...)
+}
+
+// NewDialer creates the connector object with a connection of the dialer (e.g. a Unix socket, see net.Dialer).
+// The address is only a description of the connection.
+// The function takes to integers for the size of the input and output buffer (channels).
+// The options are done after the connection is established (e.g. authentication, see net/auth).
+func NewDialer(addr string, d net.Dialer, inbuf, outbuf int, opts ...net.Option) (*ConnectorBuffered, error) {
+	conn, err := net.Open(addr, d, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,15 +61,24 @@ func New(addr string, inbuf, outbuf int) (*ConnectorBuffered, error) {
 	return cb, nil
 }
 
+// NewStream creates the connector object with an established stream (e.g. one end of a net.Pipe).
+func NewStream(addr string, rwc io.ReadWriteCloser, inbuf, outbuf int, opts ...net.Option) (*ConnectorBuffered, error) {
+	return NewDialer(addr, net.Stream(rwc), inbuf, outbuf, opts...)
+}
+
 // NewBrickerUnbuffered creates a connector without bufferd channels.
 // It is a buffered bricker with zero buffers.
-func NewUnbuffered(addr string) (*ConnectorBuffered, error) {
-	return New(addr, 0, 0)
+func NewUnbuffered(addr string, opts ...net.Option) (*ConnectorBuffered, error) {
+	return New(addr, 0, 0, opts...)
 }
 
 // Send puts the given event into the channel for writing the packets to the hardware.
+// After Done, the event will be dropped.
 func (cb *ConnectorBuffered) Send(ev *event.Event) {
-	cb.Out <- ev
+	select {
+	case cb.Out <- ev:
+	case <-cb.Quit:
+	}
 }
 
 // Receive reads a event out of the channel for the readed packets form the hardware.
@@ -69,43 +93,58 @@ func (cb *ConnectorBuffered) Receive() *event.Event {
 
 // Done stops the bricker and release all connections
 func (cb *ConnectorBuffered) Done() {
-	close(cb.Quit)
-	cb.conn.Close()
+	cb.once.Do(func() {
+		close(cb.Quit)
+		cb.conn.Close()
+	})
+}
+
+// Err returns the error, which has closed the connection (nil, if the connection is not closed by an error).
+func (cb *ConnectorBuffered) Err() error {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.err
+}
+
+// Address returns the address of the hardware connection (fullfill connector.Addresser).
+func (cb *ConnectorBuffered) Address() string {
+	return cb.conn.Address
 }
 
 // read is a internal method. Method reads from the hardware connection and put the packet into the event.
+// A read error without a packet means, the connection is lost, the reading stops.
 func (cb *ConnectorBuffered) read() {
-	var err error
-	var pck *packet.Packet
-	done := false
 	defer close(cb.In)
 	for {
-		if done {
+		pck, err := cb.conn.ReadPacket()
+		if pck == nil && err != nil { // connection lost or closed
+			select {
+			case <-cb.Quit: // closed by done, no error
+			default:
+				cb.lock.Lock()
+				cb.err = err
+				cb.lock.Unlock()
+			}
+			return
+		}
+		select {
+		case cb.In <- event.NewSimple(err, pck):
+		case <-cb.Quit:
 			return
 		}
-		pck, err = cb.conn.ReadPacket()
-		go func(e error, p *packet.Packet) {
-			if !done {
-				ev := event.NewSimple(e, p)
-				select {
-				case cb.In <- ev:
-				case <-cb.Quit:
-					done = true
-				}
-			}
-		}(err, pck)
 	}
 }
 
 // write is a internal method. Method writes packets to the hardware connection.
 func (cb *ConnectorBuffered) write() {
-	defer close(cb.Out)
 	var ev *event.Event
 	for {
 		select {
 		case ev = <-cb.Out:
 			if ev != nil && ev.Err == nil && ev.Packet != nil {
-				ev.Packet.Head.SetSequence(cb.seq.GetSequence())
+				if ev.Packet.Head.Sequence() == 0 { // sequence not set by the bricker
+					ev.Packet.Head.SetSequence(cb.seq.GetSequence())
+				}
 				ev.Packet.Head.Length = ev.Packet.ComputeLength()
 				cb.conn.WritePacket(ev.Packet)
 			}
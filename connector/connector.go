@@ -15,6 +15,7 @@ package connector
 import (
 	"fmt"
 	"github.com/dirkjabl/bricker/event"
+	"sync"
 )
 
 // Interface to the connector. It should send and receive events to or from the hardware.
@@ -27,15 +28,65 @@ type Connector interface {
 	Done()
 }
 
+// State of a connector.
+type State uint8
+
+// All known states of a connector.
+const (
+	StateConnected State = iota
+	StateDisconnected
+	StateReconnected
+	StateReleased // the connector is released from the bricker
+)
+
+// String fullfill the stringer interface.
+func (s State) String() string {
+	switch s {
+	case StateConnected:
+		return "Connected"
+	case StateDisconnected:
+		return "Disconnected"
+	case StateReconnected:
+		return "Reconnected"
+	case StateReleased:
+		return "Released"
+	default:
+		return "Unknown"
+	}
+}
+
+// StateNotifier is an optional interface for connectors, which could change their state
+// without ending (e.g. a reconnecting connector).
+// The given handler is called on every state change, a disconnect comes with the cause (if known).
+type StateNotifier interface {
+	OnStateChange(handler func(State, error))
+}
+
+// Failer is an optional interface for connectors, which could give the cause,
+// why no more events could be received (the error, which closed the connection).
+type Failer interface {
+	Err() error
+}
+
+// Addresser is an optional interface for connectors, which could give the address
+// of the hardware (e.g. host and port of the brick daemon).
+type Addresser interface {
+	Address() string
+}
+
 // Sequence is a type for sequence in the header.
 // It has to be between 1 and 15 and every connector should use it.
 // It increase the sequence automaticly at call.
+// A sequence is safe for concurrent use.
 type Sequence struct {
+	lock  sync.Mutex
 	value uint8
 }
 
 // GetSequence give back the new sequence number.
 func (s *Sequence) GetSequence() uint8 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	s.value++
 	if s.value > 15 {
 		s.value = 1
@@ -45,5 +96,7 @@ func (s *Sequence) GetSequence() uint8 {
 
 // String to fullfill Stringer interface
 func (s *Sequence) String() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	return fmt.Sprintf("Sequence: [%d]", s.value)
 }
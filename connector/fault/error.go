@@ -0,0 +1,33 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fault
+
+// All known errors for the fault injection.
+const (
+	ErrorUnknown = iota
+	ErrorDisconnected
+)
+
+// Error type for the fault injection.
+type Error struct {
+	Code uint8
+}
+
+// NewError create the error object.
+func NewError(code uint8) Error {
+	return Error{code}
+}
+
+// Error gives a string representation for the error code.
+func (e Error) Error() string {
+	switch e.Code {
+	case ErrorDisconnected:
+		return "Connection is lost (injected fault)."
+	case ErrorUnknown:
+		fallthrough
+	default:
+		return "Unknown error."
+	}
+}
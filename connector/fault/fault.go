@@ -0,0 +1,314 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Fault injection for resilience tests.
+
+The injector wraps any connector and injects faults into the sent and received packets:
+it drops, delays, duplicates, reorders or corrupts packets, answers requests with an error code
+of the brick daemon and simulates disconnects. The rules are deterministic scripts or
+work with a probability, the random numbers come from a seeded source, so every failure is reproducible.
+
+	v := virtual.New()
+	in := fault.New(v, 1,
+		fault.Rule{Fault: fault.Drop, Direction: fault.Received, Probability: 0.1},
+		fault.Rule{Fault: fault.ErrorCode, FunctionID: 1, Skip: 2, Times: 1, Code: errors.ErrorFUNCTIONNOTSUPPORTED},
+		fault.Rule{Fault: fault.Disconnect, Skip: 100, Times: 1, Duration: 2 * time.Second})
+	brick.Attach(in, "flaky")
+
+A disconnect with a duration is a outage, the injector notifies the state changes
+(connector.StateNotifier) and loses all packets until the end of the outage. After a disconnect
+without a duration the injector ends like a lost connection.
+*/
+package fault
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/errors"
+	"github.com/dirkjabl/bricker/net/packet"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// The fault injecting connector type.
+// It wraps a connector and injects faults by rules, the first rule, which applies to a packet, wins.
+// The injector is safe for concurrent use.
+type Injector struct {
+	conn     connector.Connector
+	receive  chan *event.Event
+	ended    chan struct{} // closed, when no more events come (done or lost connection)
+	once     sync.Once
+	lock     sync.Mutex // guards all following fields
+	random   *rand.Rand
+	rules    []*rule
+	held     map[Direction]*event.Event // reordered packets
+	until    time.Time                  // end of the actual outage
+	handler  func(connector.State, error)
+	err      error
+	injected map[Fault]uint64
+}
+
+// New creates a fault injecting connector for the connector with the rules.
+// The seed initializes the random numbers of the probabilities.
+func New(c connector.Connector, seed int64, rules ...Rule) *Injector {
+	in := &Injector{
+		conn:     c,
+		receive:  make(chan *event.Event, 20),
+		ended:    make(chan struct{}),
+		random:   rand.New(rand.NewSource(seed)),
+		held:     make(map[Direction]*event.Event),
+		injected: make(map[Fault]uint64)}
+	in.Add(rules...)
+	if sn, ok := c.(connector.StateNotifier); ok {
+		sn.OnStateChange(in.notify)
+	}
+	go in.run()
+	return in
+}
+
+// Add appends the rules to the rules of the injector.
+func (in *Injector) Add(rules ...Rule) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	for _, r := range rules {
+		in.rules = append(in.rules, &rule{Rule: r})
+	}
+}
+
+// Clear removes all rules, the packets pass without faults.
+func (in *Injector) Clear() {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	in.rules = nil
+}
+
+// Injected returns, how often the fault is injected.
+func (in *Injector) Injected(f Fault) uint64 {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	return in.injected[f]
+}
+
+// Send injects the faults into the packet of the event and sends it with the wrapped connector.
+func (in *Injector) Send(e *event.Event) {
+	if e == nil || in.isEnded() {
+		return
+	}
+	if e.Packet == nil || e.Packet.Head == nil {
+		in.conn.Send(e)
+		return
+	}
+	in.inject(Sent, e)
+}
+
+// Receive reads a event, the faults are injected before.
+// After the end of the wrapped connector or a disconnect the result is nil.
+func (in *Injector) Receive() *event.Event {
+	select {
+	case e := <-in.receive:
+		return e
+	case <-in.ended:
+		return nil
+	}
+}
+
+// Done stops the injector and the wrapped connector.
+func (in *Injector) Done() {
+	in.end()
+}
+
+// Err returns the cause, why no more events come (fullfill connector.Failer).
+// After a injected disconnect this is ErrorDisconnected, otherwise the error of the wrapped connector.
+func (in *Injector) Err() error {
+	in.lock.Lock()
+	err := in.err
+	in.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if f, ok := in.conn.(connector.Failer); ok {
+		return f.Err()
+	}
+	return nil
+}
+
+// OnStateChange sets the handler for state changes (fullfill connector.StateNotifier).
+// The handler gets the simulated outages and the state changes of the wrapped connector.
+func (in *Injector) OnStateChange(handler func(connector.State, error)) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	in.handler = handler
+}
+
+// Internal method: run reads the events of the wrapped connector, until it ends.
+func (in *Injector) run() {
+	for e := in.conn.Receive(); e != nil; e = in.conn.Receive() {
+		if e.Packet == nil || e.Packet.Head == nil {
+			in.deliver(e)
+			continue
+		}
+		in.inject(Received, e)
+	}
+	in.end()
+}
+
+// Internal method: inject injects the fault of the first applying rule into the packet and passes it on.
+// A held back packet of the direction is passed on after the packet.
+func (in *Injector) inject(d Direction, e *event.Event) {
+	pass := in.conn.Send
+	if d == Received {
+		pass = in.deliver
+	}
+	in.lock.Lock()
+	now := time.Now()
+	if now.Before(in.until) { // no connection, packet is lost
+		in.lock.Unlock()
+		return
+	}
+	r, ok := in.choose(d, e)
+	held := in.held[d]
+	delete(in.held, d)
+	pos := 0
+	if ok {
+		switch r.Fault {
+		case Reorder:
+			in.held[d] = e
+		case Corrupt:
+			pos = in.random.Int()
+		case Disconnect:
+			if r.Duration > 0 {
+				in.until = now.Add(r.Duration)
+			} else {
+				in.err = NewError(ErrorDisconnected)
+			}
+		}
+	}
+	in.lock.Unlock()
+	switch {
+	case !ok:
+		pass(e)
+	case r.Fault == Drop, r.Fault == Reorder:
+		// lost or held back
+	case r.Fault == Delay:
+		time.AfterFunc(r.Duration, func() {
+			if !in.isEnded() {
+				pass(e)
+			}
+		})
+	case r.Fault == Duplicate:
+		pass(e.Copy())
+		pass(e)
+	case r.Fault == Corrupt:
+		pass(corrupt(e, pos))
+	case r.Fault == ErrorCode && d == Sent: // not sent, answered like the brick daemon
+		in.deliver(failed(e, r.Code))
+	case r.Fault == ErrorCode:
+		pass(failed(e, r.Code))
+	case r.Fault == Disconnect:
+		in.disconnect(r.Duration)
+		return // all packets are lost
+	}
+	if held != nil {
+		pass(held)
+	}
+}
+
+// Internal method: choose finds the first rule, which injects a fault into the packet, and counts the fault.
+// The caller has to hold the lock.
+func (in *Injector) choose(d Direction, e *event.Event) (Rule, bool) {
+	for _, r := range in.rules {
+		if r.exhausted() || !r.matches(d, e) {
+			continue
+		}
+		r.seen++
+		if r.seen <= r.Skip {
+			continue
+		}
+		if r.Probability > 0 && in.random.Float64() >= r.Probability {
+			continue
+		}
+		r.injected++
+		in.injected[r.Fault]++
+		return r.Rule, true
+	}
+	return Rule{}, false
+}
+
+// Internal method: disconnect simulates a lost connection.
+// With a duration the connection comes back after it, otherwise the injector ends.
+func (in *Injector) disconnect(d time.Duration) {
+	if d <= 0 {
+		in.end()
+		return
+	}
+	in.notify(connector.StateDisconnected, NewError(ErrorDisconnected))
+	time.AfterFunc(d, func() {
+		if !in.isEnded() {
+			in.notify(connector.StateReconnected, nil)
+		}
+	})
+}
+
+// Internal method: notify calls the handler for state changes, if one is set.
+func (in *Injector) notify(s connector.State, err error) {
+	in.lock.Lock()
+	handler := in.handler
+	in.lock.Unlock()
+	if handler != nil {
+		handler(s, err)
+	}
+}
+
+// Internal method: deliver puts the event in the receive channel, until the injector ends.
+func (in *Injector) deliver(e *event.Event) {
+	select {
+	case in.receive <- e:
+	case <-in.ended:
+	}
+}
+
+// Internal method: end stops the injector and the wrapped connector once.
+func (in *Injector) end() {
+	in.once.Do(func() {
+		close(in.ended)
+		in.conn.Done()
+	})
+}
+
+// Internal method: isEnded checks, if the injector has ended.
+func (in *Injector) isEnded() bool {
+	select {
+	case <-in.ended:
+		return true
+	default:
+		return false
+	}
+}
+
+// Internal function: corrupt gives a copy of the event with a inverted byte of the payload
+// (at the position modulo the length) or, without a payload, with a inverted function id.
+func corrupt(e *event.Event, pos int) *event.Event {
+	c := e.Copy()
+	if p := c.Packet.Payload; p != nil && len(*p) > 0 {
+		(*p)[pos%len(*p)] ^= 0xff
+	} else {
+		c.Packet.Head.FunctionID ^= 0xff
+	}
+	return c
+}
+
+// Internal function: failed creates a response with the error code and without a payload
+// for the request or response of the event, like the brick daemon does.
+func failed(e *event.Event, code uint8) *event.Event {
+	if code == errors.ErrorOK {
+		code = errors.ErrorINVALIDPARAMETER
+	}
+	h := e.Packet.Head.Copy()
+	h.ErrorCodeAndFutureUse = code << 6
+	f := event.NewSimple(h.ErrorCode(), packet.New(h, nil, nil))
+	f.ConnectorName = e.ConnectorName
+	return f
+}
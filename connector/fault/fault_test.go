@@ -0,0 +1,183 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fault
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/errors"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newEcho creates a injector for a virtual connector, which answers every request of the device 5
+// with the function id as payload. The counter counts the requests, which reach the virtual connector.
+func newEcho(seed int64, rules ...Rule) (*Injector, *int32) {
+	var count int32
+	v := virtual.New()
+	v.AttachGenerator(hash.New(hash.ChoosenUid, 5, 0), func(e *event.Event) *event.Event {
+		atomic.AddInt32(&count, 1)
+		return event.NewPacket(packet.NewSimpleHeaderPayload(5, e.Packet.Head.FunctionID, true, e.Packet.Head.FunctionID))
+	})
+	return New(v, seed, rules...), &count
+}
+
+// send sends a request with the function id and the sequence number.
+func send(in *Injector, fid, seq uint8) {
+	p := packet.NewSimpleHeaderOnly(5, fid, true)
+	p.Head.SetSequence(seq)
+	in.Send(event.NewPacket(p))
+}
+
+// receive waits for the next event of the injector, the result is nil after the timeout.
+func receive(in *Injector, timeout time.Duration) *event.Event {
+	c := make(chan *event.Event, 1)
+	go func() { c <- in.Receive() }()
+	select {
+	case e := <-c:
+		return e
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+// sequences receives n events and returns their sequence numbers.
+func sequences(t *testing.T, in *Injector, n int) []uint8 {
+	seqs := make([]uint8, 0, n)
+	for i := 0; i < n; i++ {
+		e := receive(in, time.Second)
+		if e == nil {
+			t.Fatalf("Error %s: no event after %v.", t.Name(), seqs)
+		}
+		seqs = append(seqs, e.Packet.Head.Sequence())
+	}
+	return seqs
+}
+
+func TestDrop(t *testing.T) {
+	in, _ := newEcho(1, Rule{Fault: Drop, Direction: Received, FunctionID: 1, Skip: 1, Times: 1})
+	defer in.Done()
+	for seq := uint8(1); seq <= 3; seq++ {
+		send(in, 1, seq)
+	}
+	if seqs := sequences(t, in, 2); seqs[0] != 1 || seqs[1] != 3 {
+		t.Fatalf("Error TestDrop: responses %v, expected [1 3].", seqs)
+	}
+	if e := receive(in, 20*time.Millisecond); e != nil {
+		t.Fatalf("Error TestDrop: unexpected event %v.", e)
+	}
+	if in.Injected(Drop) != 1 {
+		t.Fatalf("Error TestDrop: %d drops, expected 1.", in.Injected(Drop))
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	in, count := newEcho(1,
+		Rule{Fault: ErrorCode, Direction: Sent, FunctionID: 2, Code: errors.ErrorFUNCTIONNOTSUPPORTED},
+		Rule{Fault: ErrorCode, Direction: Received, FunctionID: 3})
+	defer in.Done()
+	send(in, 2, 4)
+	e := receive(in, time.Second)
+	if e == nil || e.Packet.Head.Sequence() != 4 || e.Packet.Head.ErrorCodeNbr() != errors.ErrorFUNCTIONNOTSUPPORTED ||
+		e.Err == nil || e.Packet.Payload != nil || atomic.LoadInt32(count) != 0 {
+		t.Fatalf("Error TestErrorCode: wrong answer %v (%d requests sent).", e, atomic.LoadInt32(count))
+	}
+	send(in, 3, 5)
+	e = receive(in, time.Second)
+	if e == nil || e.Packet.Head.Sequence() != 5 || e.Packet.Head.ErrorCodeNbr() != errors.ErrorINVALIDPARAMETER ||
+		atomic.LoadInt32(count) != 1 {
+		t.Fatalf("Error TestErrorCode: wrong response %v.", e)
+	}
+}
+
+func TestReorderDuplicate(t *testing.T) {
+	in, _ := newEcho(1,
+		Rule{Fault: Reorder, Direction: Received, Times: 1},
+		Rule{Fault: Duplicate, Direction: Sent, FunctionID: 3})
+	defer in.Done()
+	send(in, 1, 1)
+	send(in, 2, 2)
+	send(in, 3, 3)
+	if seqs := sequences(t, in, 4); seqs[0] != 2 || seqs[1] != 1 || seqs[2] != 3 || seqs[3] != 3 {
+		t.Fatalf("Error TestReorderDuplicate: responses %v, expected [2 1 3 3].", seqs)
+	}
+}
+
+func TestCorruptDelay(t *testing.T) {
+	in, _ := newEcho(1,
+		Rule{Fault: Corrupt, Direction: Received, FunctionID: 1},
+		Rule{Fault: Delay, Direction: Sent, FunctionID: 2, Duration: 50 * time.Millisecond})
+	defer in.Done()
+	send(in, 1, 1)
+	var value uint8
+	if e := receive(in, time.Second); e == nil || e.Packet.Payload.Decode(&value) != nil || value != 0xfe {
+		t.Fatalf("Error TestCorruptDelay: payload %d, expected 254.", value)
+	}
+	start := time.Now()
+	send(in, 2, 2)
+	if e := receive(in, time.Second); e == nil || time.Since(start) < 50*time.Millisecond {
+		t.Fatalf("Error TestCorruptDelay: response %v after %s, expected 50ms.", e, time.Since(start))
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	in, count := newEcho(1,
+		Rule{Fault: Disconnect, Direction: Sent, FunctionID: 1, Times: 1, Duration: 50 * time.Millisecond},
+		Rule{Fault: Disconnect, Direction: Sent, FunctionID: 9})
+	states := make(chan connector.State, 2)
+	in.OnStateChange(func(s connector.State, err error) {
+		states <- s
+	})
+	send(in, 1, 1)
+	send(in, 2, 2) // lost
+	if s := <-states; s != connector.StateDisconnected {
+		t.Fatalf("Error TestDisconnect: state %s, expected Disconnected.", s)
+	}
+	if s := <-states; s != connector.StateReconnected {
+		t.Fatalf("Error TestDisconnect: state %s, expected Reconnected.", s)
+	}
+	if atomic.LoadInt32(count) != 0 {
+		t.Fatalf("Error TestDisconnect: %d requests sent while disconnected.", atomic.LoadInt32(count))
+	}
+	send(in, 1, 3)
+	if seqs := sequences(t, in, 1); seqs[0] != 3 {
+		t.Fatalf("Error TestDisconnect: response %v, expected [3].", seqs)
+	}
+	send(in, 9, 4)
+	if e := in.Receive(); e != nil {
+		t.Fatalf("Error TestDisconnect: event %v after the disconnect.", e)
+	}
+	if err, ok := in.Err().(Error); !ok || err.Code != ErrorDisconnected {
+		t.Fatalf("Error TestDisconnect: wrong error %v.", in.Err())
+	}
+}
+
+func TestProbability(t *testing.T) {
+	pattern := func() []uint8 {
+		in, _ := newEcho(42, Rule{Fault: Drop, Direction: Received, Probability: 0.5})
+		defer in.Done()
+		seqs := make([]uint8, 0)
+		for seq := uint8(1); seq <= 15; seq++ {
+			send(in, 1, seq)
+		}
+		for e := receive(in, 20*time.Millisecond); e != nil; e = receive(in, 20*time.Millisecond) {
+			seqs = append(seqs, e.Packet.Head.Sequence())
+		}
+		return seqs
+	}
+	first, second := pattern(), pattern()
+	if len(first) == 0 || len(first) == 15 || len(first) != len(second) {
+		t.Fatalf("Error TestProbability: patterns %v and %v.", first, second)
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Error TestProbability: patterns %v and %v differ.", first, second)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fault
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"time"
+)
+
+// Fault is a kind of failure, which is injected.
+type Fault uint8
+
+// All known faults.
+const (
+	Drop       Fault = iota // packet is lost
+	Delay                   // packet is delivered after the duration of the rule
+	Duplicate               // packet is delivered twice
+	Reorder                 // packet is held back and delivered after the next packet of the same direction
+	Corrupt                 // a byte of the payload is inverted (without a payload the function id)
+	ErrorCode               // request is answered or response comes with the error code of the rule
+	Disconnect              // connection is lost for the duration of the rule (0 for ever)
+)
+
+// String fullfill the stringer interface.
+func (f Fault) String() string {
+	switch f {
+	case Drop:
+		return "Drop"
+	case Delay:
+		return "Delay"
+	case Duplicate:
+		return "Duplicate"
+	case Reorder:
+		return "Reorder"
+	case Corrupt:
+		return "Corrupt"
+	case ErrorCode:
+		return "ErrorCode"
+	case Disconnect:
+		return "Disconnect"
+	default:
+		return "Unknown"
+	}
+}
+
+// Direction of the packets, for which a rule applies.
+type Direction uint8
+
+// All directions.
+const (
+	Both     Direction = iota // sent and received packets
+	Sent                      // packets to the hardware
+	Received                  // packets from the hardware
+)
+
+// String fullfill the stringer interface.
+func (d Direction) String() string {
+	switch d {
+	case Both:
+		return "Both"
+	case Sent:
+		return "Sent"
+	case Received:
+		return "Received"
+	default:
+		return "Unknown"
+	}
+}
+
+/*
+Rule describes, when a fault is injected.
+
+A rule matches the packets of the direction, the device (uid) and the function id,
+a zero value matches all. The first Skip matching packets pass, then the fault is injected
+with the probability for every matching packet, until it is injected Times times.
+So a rule without a probability is a deterministic script (e.g. "drop the third response of the device").
+
+An error code (see net/errors, e.g. ErrorINVALIDPARAMETER or ErrorFUNCTIONNOTSUPPORTED)
+is injected only into requests, which expect a response, and into responses.
+A request is not sent, the injector answers it like the brick daemon.
+*/
+type Rule struct {
+	Fault       Fault
+	Direction   Direction
+	Uid         uint32        // uid of the device (0 for all devices)
+	FunctionID  uint8         // function id (0 for all functions)
+	Skip        int           // matching packets, which pass before the first fault
+	Times       int           // maximal number of injected faults (0 for no limit)
+	Probability float64       // chance of the fault for a matching packet (0 or less for every packet)
+	Duration    time.Duration // delay of a packet, time without a connection after a disconnect
+	Code        uint8         // error code of the brick daemon (0 for errors.ErrorINVALIDPARAMETER)
+}
+
+// Internal type: rule is a rule with its counters.
+type rule struct {
+	Rule
+	seen     int // matching packets
+	injected int // injected faults
+}
+
+// Internal method: matches checks, if the rule applies to the packet of the event.
+func (r *rule) matches(d Direction, e *event.Event) bool {
+	if r.Direction != Both && r.Direction != d {
+		return false
+	}
+	h := e.Packet.Head
+	if r.Fault == ErrorCode && !(d == Sent && h.OptionResponseExpected() || d == Received && h.Sequence() != 0) {
+		return false // only requests with a response and responses have a error code
+	}
+	return (r.Uid == 0 || r.Uid == h.Uid) && (r.FunctionID == 0 || r.FunctionID == h.FunctionID)
+}
+
+// Internal method: exhausted checks, if the rule has injected all its faults.
+func (r *rule) exhausted() bool {
+	return r.Times > 0 && r.injected >= r.Times
+}
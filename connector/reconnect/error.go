@@ -0,0 +1,33 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reconnect
+
+// All known errors for a reconnecting connector.
+const (
+	ErrorUnknown = iota
+	ErrorNotConnected
+)
+
+// Error type for the reconnecting connector.
+type Error struct {
+	Code uint8
+}
+
+// NewError create the error object.
+func NewError(code uint8) Error {
+	return Error{code}
+}
+
+// Error gives a string representation for the error code.
+func (e Error) Error() string {
+	switch e.Code {
+	case ErrorNotConnected:
+		return "Connector is not connected, it is reconnecting."
+	case ErrorUnknown:
+		fallthrough
+	default:
+		return "Unknown error."
+	}
+}
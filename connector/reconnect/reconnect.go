@@ -0,0 +1,269 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Implementation of a reconnecting connector.
+
+The reconnecting connector wraps another connector, which is created by a dialer.
+If the wrapped connector ends (the connection to the brick daemon is lost),
+the reconnecting connector dials again, until a new connector is created.
+Between the dial attempts it waits, the waiting time is computed by a backoff.
+
+The reconnecting connector fullfills the connector.StateNotifier interface.
+A attached bricker gets the state changes and restores the session after a reconnect
+(enumerate and resend of the configuration requests).
+*/
+package reconnect
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/connector/buffered"
+	"github.com/dirkjabl/bricker/connector/simple"
+	"github.com/dirkjabl/bricker/connector/websocket"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net"
+	"sync"
+	"time"
+)
+
+// Dialer creates a new connector with a connection to the hardware.
+type Dialer func() (connector.Connector, error)
+
+// Buffered returns a dialer for a buffered connector (see connector/buffered).
+// The options are done after every dial (e.g. authentication, see net/auth).
+func Buffered(addr string, inbuf, outbuf int, opts ...net.Option) Dialer {
+	return func() (connector.Connector, error) {
+		c, err := buffered.New(addr, inbuf, outbuf, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+// Simple returns a dialer for a simple connector (see connector/simple).
+// The options are done after every dial (e.g. authentication, see net/auth).
+func Simple(addr string, opts ...net.Option) Dialer {
+	return func() (connector.Connector, error) {
+		c, err := simple.New(addr, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+// Stream returns a dialer for a simple connector with a connection of the stream dialer
+// (e.g. a Unix socket, see net.Dialer). The stream dialer is called for every reconnect.
+// The options are done after every dial (e.g. authentication, see net/auth).
+func Stream(addr string, d net.Dialer, opts ...net.Option) Dialer {
+	return func() (connector.Connector, error) {
+		c, err := simple.NewDialer(addr, d, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+// WebSocket returns a dialer for a WebSocket connector (see connector/websocket).
+func WebSocket(address string) Dialer {
+	return func() (connector.Connector, error) {
+		c, err := websocket.New(address)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+// Backoff computes the waiting time between two dial attempts.
+// The first attempt waits Min, every next attempt waits Factor times longer, but not longer than Max.
+type Backoff struct {
+	Min    time.Duration // waiting time before the first attempt
+	Max    time.Duration // maximal waiting time
+	Factor float64       // factor for the next waiting time
+}
+
+// DefaultBackoff starts with 100ms and waits maximal 30s.
+var DefaultBackoff = Backoff{Min: 100 * time.Millisecond, Max: 30 * time.Second, Factor: 2}
+
+// Duration computes the waiting time for the given attempt (starting with 0).
+func (b Backoff) Duration(attempt int) time.Duration {
+	d := float64(b.Min)
+	for i := 0; i < attempt && d < float64(b.Max); i++ {
+		d *= b.Factor
+	}
+	if d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	return time.Duration(d)
+}
+
+// The reconnecting connector type.
+type ConnectorReconnect struct {
+	dial    Dialer
+	backoff Backoff
+	lock    sync.RWMutex                 // guards the following fields
+	conn    connector.Connector          // the actual connector, nil while reconnecting
+	handler func(connector.State, error) // state change handler
+	done    bool                         // connector is done, no more events
+	address string                       // address of the last connector
+	receive chan *event.Event
+	quit    chan struct{}
+	once    sync.Once
+}
+
+// New creates the reconnecting connector and dials the first connection.
+// If the first dial fails, no connector is created.
+func New(dial Dialer, backoff Backoff) (*ConnectorReconnect, error) {
+	conn, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	cr := &ConnectorReconnect{
+		dial:    dial,
+		backoff: backoff,
+		conn:    conn,
+		address: addressOf(conn),
+		receive: make(chan *event.Event),
+		quit:    make(chan struct{})}
+	go cr.run(conn)
+	return cr, nil
+}
+
+// Send sends the event over the actual connector.
+// While reconnecting, the event comes back with an error (ErrorNotConnected).
+func (cr *ConnectorReconnect) Send(ev *event.Event) {
+	if ev == nil {
+		return
+	}
+	cr.lock.RLock()
+	conn := cr.conn
+	cr.lock.RUnlock()
+	if conn != nil {
+		conn.Send(ev)
+		return
+	}
+	ev.Err = NewError(ErrorNotConnected)
+	go cr.deliver(ev)
+}
+
+// Receive reads a event from the actual connector.
+// If Receive returns a nil event, the connector is done.
+func (cr *ConnectorReconnect) Receive() *event.Event {
+	select {
+	case e := <-cr.receive:
+		return e
+	case <-cr.quit:
+		return nil // done
+	}
+}
+
+// Done stops the reconnecting and releases the actual connector.
+func (cr *ConnectorReconnect) Done() {
+	cr.once.Do(func() {
+		close(cr.quit)
+		cr.lock.Lock()
+		cr.done = true
+		conn := cr.conn
+		cr.conn = nil
+		cr.lock.Unlock()
+		if conn != nil {
+			conn.Done()
+		}
+	})
+}
+
+// Address returns the address of the actual connector (fullfill connector.Addresser).
+// While reconnecting, the address of the last connector is the result.
+func (cr *ConnectorReconnect) Address() string {
+	cr.lock.RLock()
+	defer cr.lock.RUnlock()
+	return cr.address
+}
+
+// OnStateChange sets the handler for state changes (fullfill connector.StateNotifier).
+func (cr *ConnectorReconnect) OnStateChange(handler func(connector.State, error)) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+	cr.handler = handler
+}
+
+// Internal method: run reads the events of the actual connector and reconnects, if the connector ends.
+func (cr *ConnectorReconnect) run(conn connector.Connector) {
+	for {
+		for ev := conn.Receive(); ev != nil; ev = conn.Receive() {
+			cr.deliver(ev)
+		}
+		var cause error
+		if f, ok := conn.(connector.Failer); ok {
+			cause = f.Err()
+		}
+		cr.lock.Lock()
+		if cr.done {
+			cr.lock.Unlock()
+			return
+		}
+		cr.conn = nil
+		cr.lock.Unlock()
+		conn.Done()
+		cr.notify(connector.StateDisconnected, cause)
+		if conn = cr.redial(); conn == nil {
+			return // done while reconnecting
+		}
+		cr.notify(connector.StateReconnected, nil)
+	}
+}
+
+// Internal method: redial dials until a new connector is created or the connector is done.
+func (cr *ConnectorReconnect) redial() connector.Connector {
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-time.After(cr.backoff.Duration(attempt)):
+		case <-cr.quit:
+			return nil
+		}
+		conn, err := cr.dial()
+		if err != nil {
+			continue
+		}
+		cr.lock.Lock()
+		if cr.done {
+			cr.lock.Unlock()
+			conn.Done()
+			return nil
+		}
+		cr.conn = conn
+		cr.address = addressOf(conn)
+		cr.lock.Unlock()
+		return conn
+	}
+}
+
+// Internal method: deliver puts a event into the receive channel, if the connector is not done.
+func (cr *ConnectorReconnect) deliver(ev *event.Event) {
+	select {
+	case cr.receive <- ev:
+	case <-cr.quit:
+	}
+}
+
+// Internal method: notify calls the state change handler.
+func (cr *ConnectorReconnect) notify(s connector.State, err error) {
+	cr.lock.RLock()
+	handler := cr.handler
+	cr.lock.RUnlock()
+	if handler != nil {
+		handler(s, err)
+	}
+}
+
+// Internal function: addressOf gives the address of the connector, if it has one.
+func addressOf(c connector.Connector) string {
+	if a, ok := c.(connector.Addresser); ok {
+		return a.Address()
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reconnect
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBackoffDuration(t *testing.T) {
+	b := Backoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond, Factor: 2}
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond,
+		50 * time.Millisecond, 50 * time.Millisecond}
+	for i, e := range expected {
+		if d := b.Duration(i); d != e {
+			t.Fatalf("Error TestBackoffDuration: attempt %d waits %v, expected %v.", i, d, e)
+		}
+	}
+}
+
+// testDialer creates virtual connectors and remembers them.
+type testDialer struct {
+	lock  sync.Mutex
+	conns []*virtual.Virtual
+}
+
+func (td *testDialer) dial() (connector.Connector, error) {
+	td.lock.Lock()
+	defer td.lock.Unlock()
+	v := virtual.New()
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event { // echo
+		return event.NewPacket(packet.NewSimpleHeaderOnly(e.Packet.Head.Uid, e.Packet.Head.FunctionID, false))
+	})
+	td.conns = append(td.conns, v)
+	return v, nil
+}
+
+func (td *testDialer) last() *virtual.Virtual {
+	td.lock.Lock()
+	defer td.lock.Unlock()
+	return td.conns[len(td.conns)-1]
+}
+
+func TestReconnect(t *testing.T) {
+	td := &testDialer{}
+	cr, err := New(td.dial, Backoff{Min: time.Millisecond, Max: 10 * time.Millisecond, Factor: 2})
+	if err != nil {
+		t.Fatalf("Error TestReconnect: could not create connector (%s).", err.Error())
+	}
+	defer cr.Done()
+	states := make(chan connector.State, 2)
+	cr.OnStateChange(func(s connector.State, err error) {
+		states <- s
+	})
+	first := td.last()
+	first.Done() // connection lost
+	for _, expected := range []connector.State{connector.StateDisconnected, connector.StateReconnected} {
+		select {
+		case s := <-states:
+			if s != expected {
+				t.Fatalf("Error TestReconnect: state %s, expected %s.", s, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestReconnect: no state change to %s.", expected)
+		}
+	}
+	if td.last() == first {
+		t.Fatalf("Error TestReconnect: no new connector dialed.")
+	}
+	cr.Send(event.NewPacket(packet.NewSimpleHeaderOnly(1, 1, true)))
+	select {
+	case <-receive(cr):
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestReconnect: no response after reconnect.")
+	}
+}
+
+func TestReconnectDone(t *testing.T) {
+	td := &testDialer{}
+	cr, err := New(td.dial, DefaultBackoff)
+	if err != nil {
+		t.Fatalf("Error TestReconnectDone: could not create connector (%s).", err.Error())
+	}
+	cr.Done()
+	cr.Done() // second done should not panic
+	if e := cr.Receive(); e != nil {
+		t.Fatalf("Error TestReconnectDone: receive after done returns a event.")
+	}
+}
+
+func receive(cr *ConnectorReconnect) <-chan *event.Event {
+	c := make(chan *event.Event, 1)
+	go func() { c <- cr.Receive() }()
+	return c
+}
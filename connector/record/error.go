@@ -0,0 +1,39 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package record
+
+// All known errors for recordings.
+const (
+	ErrorUnknown = iota
+	ErrorNoRecording
+	ErrorVersion
+	ErrorBrokenEntry
+)
+
+// Error type for recordings.
+type Error struct {
+	Code uint8
+}
+
+// NewError create the error object.
+func NewError(code uint8) Error {
+	return Error{code}
+}
+
+// Error gives a string representation for the error code.
+func (e Error) Error() string {
+	switch e.Code {
+	case ErrorNoRecording:
+		return "Data is not a recording."
+	case ErrorVersion:
+		return "Version of the recording is not supported."
+	case ErrorBrokenEntry:
+		return "Entry of the recording is broken."
+	case ErrorUnknown:
+		fallthrough
+	default:
+		return "Unknown error."
+	}
+}
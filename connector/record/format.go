@@ -0,0 +1,165 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/dirkjabl/bricker/net/packet"
+	"io"
+	"time"
+)
+
+// Magic is the start of every recording, the last byte is the version of the format.
+const Magic = "BRICKREC\x01"
+
+// Direction of a recorded packet.
+type Direction uint8
+
+// All directions.
+const (
+	Sent     Direction = iota // packet sent to the hardware
+	Received                  // packet received from the hardware
+)
+
+// String fullfill the stringer interface.
+func (d Direction) String() string {
+	switch d {
+	case Sent:
+		return "Sent"
+	case Received:
+		return "Received"
+	default:
+		return "Unknown"
+	}
+}
+
+// Entry is a recorded packet.
+type Entry struct {
+	Time      time.Duration // time since the start of the recording
+	Direction Direction
+	Packet    *packet.Packet
+}
+
+// String fullfill the stringer interface.
+func (e Entry) String() string {
+	return fmt.Sprintf("Entry [Time: %s, Direction: %s, Packet: %v]", e.Time, e.Direction, e.Packet)
+}
+
+/*
+Writer writes entries in the recording format.
+
+The format is compact: after the magic every entry has the time since the previous entry
+in nanoseconds (unsigned varint), the direction (one byte), the length of the packet (one byte)
+and the raw bytes of the packet, as they are sent over the connection.
+*/
+type Writer struct {
+	w    *bufio.Writer
+	last time.Duration
+}
+
+// NewWriter writes the magic and creates a writer for the entries.
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(Magic); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw}, bw.Flush()
+}
+
+// Write writes the entry, the times of the entries have to be in ascending order.
+// The length of the packet is computed.
+func (w *Writer) Write(e Entry) error {
+	p := e.Packet.Copy()
+	p.Head.Length = p.ComputeLength()
+	var data bytes.Buffer
+	if err := p.Write(&data); err != nil {
+		return err
+	}
+	delta := e.Time - w.last
+	if delta < 0 {
+		delta = 0
+	}
+	w.last += delta
+	var head [binary.MaxVarintLen64 + 2]byte
+	n := binary.PutUvarint(head[:], uint64(delta))
+	head[n] = byte(e.Direction)
+	head[n+1] = byte(data.Len())
+	if _, err := w.w.Write(head[:n+2]); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(data.Bytes()); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// Reader reads entries of a recording.
+type Reader struct {
+	r    *bufio.Reader
+	last time.Duration
+}
+
+// NewReader checks the magic and creates a reader for the entries.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(Magic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, NewError(ErrorNoRecording)
+	}
+	if string(magic[:len(Magic)-1]) != Magic[:len(Magic)-1] {
+		return nil, NewError(ErrorNoRecording)
+	}
+	if magic[len(Magic)-1] != Magic[len(Magic)-1] {
+		return nil, NewError(ErrorVersion)
+	}
+	return &Reader{r: br}, nil
+}
+
+// Read reads the next entry. At the end of the recording the error is io.EOF.
+// A packet with an error code of the brick daemon is returned with the error code.
+func (r *Reader) Read() (Entry, error) {
+	delta, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return Entry{}, io.EOF
+	} else if err != nil {
+		return Entry{}, NewError(ErrorBrokenEntry)
+	}
+	var head [2]byte
+	if _, err := io.ReadFull(r.r, head[:]); err != nil {
+		return Entry{}, NewError(ErrorBrokenEntry)
+	}
+	data := make([]byte, head[1])
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return Entry{}, NewError(ErrorBrokenEntry)
+	}
+	p, err := packet.ReadNew(bytes.NewReader(data))
+	if p == nil {
+		return Entry{}, NewError(ErrorBrokenEntry)
+	}
+	r.last += time.Duration(delta)
+	return Entry{Time: r.last, Direction: Direction(head[0]), Packet: p}, err
+}
+
+// ReadAll reads all entries of a recording.
+func ReadAll(r io.Reader) ([]Entry, error) {
+	rr, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0)
+	for {
+		e, err := rr.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if e.Packet == nil {
+			return entries, err
+		}
+		entries = append(entries, e) // an error code of the brick daemon is part of the packet
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Recording and replay of the packets of a connector for offline debugging.
+
+A recorder wraps any connector and writes every sent and received packet
+with the time and the direction in a compact format (see Writer) into a file.
+
+	f, _ := os.Create("session.rec")
+	conn, _ := buffered.NewUnbuffered("localhost:4223")
+	rec, _ := record.New(conn, f)
+	brick.Attach(rec, "local")
+
+A replay connector plays the recording without hardware. The recorded callbacks come in their original
+timing (or faster), a request is answered with the next recorded response with the same uid and function id.
+
+	f, _ := os.Open("session.rec")
+	replay, _ := record.NewReplay(f, 10) // ten times faster
+	brick.Attach(replay, "local")
+*/
+package record
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"io"
+	"sync"
+	"time"
+)
+
+// The recording connector type.
+// It wraps a connector and records all packets, which pass it.
+type Recorder struct {
+	conn  connector.Connector
+	seq   *connector.Sequence // sequence numbers of the packets without one
+	start time.Time
+	lock  sync.Mutex // guards the writer and err
+	w     *Writer
+	err   error // first error of the writing, the recording stops
+}
+
+// New creates a recording connector for the connector, the packets are written to w.
+func New(c connector.Connector, w io.Writer) (*Recorder, error) {
+	rw, err := NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{conn: c, seq: new(connector.Sequence), start: time.Now(), w: rw}, nil
+}
+
+// Send records the packet of the event and sends it with the wrapped connector.
+// A packet without a sequence number gets it from the recorder (the wrapped connector keeps it),
+// so the recording holds the sent sequence number.
+func (r *Recorder) Send(e *event.Event) {
+	if e != nil && e.Packet != nil && e.Packet.Head != nil {
+		if e.Packet.Head.Sequence() == 0 { // sequence not set by the bricker
+			e.Packet.Head.SetSequence(r.seq.GetSequence())
+		}
+		r.record(Sent, e)
+	}
+	r.conn.Send(e)
+}
+
+// Receive receives a event from the wrapped connector and records its packet.
+func (r *Recorder) Receive() *event.Event {
+	e := r.conn.Receive()
+	if e != nil && e.Packet != nil && e.Packet.Head != nil {
+		r.record(Received, e)
+	}
+	return e
+}
+
+// Done stops the wrapped connector. The written recording is complete.
+func (r *Recorder) Done() {
+	r.conn.Done()
+}
+
+// Err returns the error of the wrapped connector (fullfill connector.Failer), if it has one.
+func (r *Recorder) Err() error {
+	if f, ok := r.conn.(connector.Failer); ok {
+		return f.Err()
+	}
+	return nil
+}
+
+// RecordErr returns the first error of the writing, after such a error nothing is recorded.
+func (r *Recorder) RecordErr() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.err
+}
+
+// Internal method: record writes a entry for the packet of the event.
+func (r *Recorder) record(d Direction, e *event.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.err = r.w.Write(Entry{Time: time.Since(r.start), Direction: d, Packet: e.Packet})
+}
@@ -0,0 +1,157 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package record
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"testing"
+	"time"
+)
+
+// newRequest creates a request with a sequence number.
+func newRequest(uid uint32, fid, seq uint8) *event.Event {
+	p := packet.NewSimpleHeaderOnly(uid, fid, true)
+	p.Head.SetSequence(seq)
+	return event.NewPacket(p)
+}
+
+func TestFormat(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("Error TestFormat: could not create writer (%s).", err.Error())
+	}
+	entries := []Entry{
+		{Time: time.Millisecond, Direction: Sent, Packet: newRequest(5, 1, 3).Packet},
+		{Time: 3 * time.Millisecond, Direction: Received, Packet: packet.NewSimpleHeaderPayload(5, 1, false, uint32(42))},
+		{Time: time.Hour, Direction: Received, Packet: packet.NewSimpleHeaderOnly(5, 2, false)}}
+	entries[2].Packet.Head.ErrorCodeAndFutureUse = 2 << 6 // function not supported
+	for _, e := range entries {
+		if err := w.Write(e); err != nil {
+			t.Fatalf("Error TestFormat: could not write (%s).", err.Error())
+		}
+	}
+	read, err := ReadAll(bytes.NewReader(buf.Bytes()))
+	if err != nil || len(read) != len(entries) {
+		t.Fatalf("Error TestFormat: wrong entries %v (%v).", read, err)
+	}
+	for i, e := range read {
+		if e.Time != entries[i].Time || e.Direction != entries[i].Direction ||
+			!bytes.Equal(e.Packet.Payload.Bytes(), entries[i].Packet.Payload.Bytes()) ||
+			e.Packet.Head.FunctionID != entries[i].Packet.Head.FunctionID ||
+			e.Packet.Head.ErrorCodeNbr() != entries[i].Packet.Head.ErrorCodeNbr() {
+			t.Fatalf("Error TestFormat: entry %d is %v, expected %v.", i, e, entries[i])
+		}
+	}
+	if _, err := ReadAll(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil || err.(Error).Code != ErrorBrokenEntry {
+		t.Fatalf("Error TestFormat: broken entry is read (%v).", err)
+	}
+	if _, err := NewReader(bytes.NewReader([]byte("BRICKREC\x02"))); err == nil || err.(Error).Code != ErrorVersion {
+		t.Fatalf("Error TestFormat: wrong version is read (%v).", err)
+	}
+	if _, err := NewReader(bytes.NewReader([]byte("no recording"))); err == nil || err.(Error).Code != ErrorNoRecording {
+		t.Fatalf("Error TestFormat: wrong data is read (%v).", err)
+	}
+}
+
+// receive waits for the next event of the connector.
+func receive(t *testing.T, rp *Replay) *event.Event {
+	c := make(chan *event.Event, 1)
+	go func() { c <- rp.Receive() }()
+	select {
+	case e := <-c:
+		return e
+	case <-time.After(time.Second):
+		t.Fatalf("Error %s: no event.", t.Name())
+	}
+	return nil
+}
+
+func TestRecordReplay(t *testing.T) {
+	v := virtual.New()
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 5, 1), func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderPayload(5, 1, false, uint32(42)))
+	})
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 5, 2), func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderPayload(5, 3, false, uint32(7))) // a callback
+	})
+	var buf bytes.Buffer
+	rec, err := New(v, &buf)
+	if err != nil {
+		t.Fatalf("Error TestRecordReplay: could not create recorder (%s).", err.Error())
+	}
+	rec.Send(newRequest(5, 1, 4))
+	rec.Receive()
+	rec.Send(newRequest(5, 2, 5))
+	rec.Receive()
+	rec.Done()
+	if rec.RecordErr() != nil {
+		t.Fatalf("Error TestRecordReplay: recording failed (%s).", rec.RecordErr().Error())
+	}
+
+	rp, err := NewReplay(bytes.NewReader(buf.Bytes()), 0)
+	if err != nil {
+		t.Fatalf("Error TestRecordReplay: could not create replay (%s).", err.Error())
+	}
+	defer rp.Done()
+	var value uint32
+	if e := receive(t, rp); e.Packet.Head.FunctionID != 3 || e.Packet.Payload.Decode(&value) != nil || value != 7 {
+		t.Fatalf("Error TestRecordReplay: wrong callback %v.", e)
+	}
+	rp.Send(newRequest(5, 1, 9))
+	if e := receive(t, rp); e.Packet.Head.Sequence() != 9 || e.Packet.Payload.Decode(&value) != nil || value != 42 {
+		t.Fatalf("Error TestRecordReplay: wrong response %v.", e)
+	}
+	rp.Send(newRequest(5, 1, 10))
+	if rp.Unmatched() != 1 {
+		t.Fatalf("Error TestRecordReplay: %d unmatched requests, expected 1.", rp.Unmatched())
+	}
+}
+
+func TestReplayTiming(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf)
+	w.Write(Entry{Time: 200 * time.Millisecond, Direction: Received, Packet: packet.NewSimpleHeaderOnly(5, 3, false)})
+	start := time.Now()
+	rp, err := NewReplay(bytes.NewReader(buf.Bytes()), 10)
+	if err != nil {
+		t.Fatalf("Error TestReplayTiming: could not create replay (%s).", err.Error())
+	}
+	receive(t, rp)
+	if d := time.Since(start); d < 20*time.Millisecond || d > 150*time.Millisecond {
+		t.Fatalf("Error TestReplayTiming: callback after %s, expected 20ms.", d)
+	}
+	rp.Done()
+	if rp.Receive() != nil {
+		t.Fatalf("Error TestReplayTiming: event after done.")
+	}
+}
+
+func TestRecordSequence(t *testing.T) {
+	v := virtual.New()
+	sent := make(chan uint8, 1)
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event {
+		sent <- e.Packet.Head.Sequence()
+		return nil
+	})
+	var buf bytes.Buffer
+	rec, err := New(v, &buf)
+	if err != nil {
+		t.Fatalf("Error TestRecordSequence: could not create recorder (%s).", err.Error())
+	}
+	rec.Send(event.NewPacket(packet.NewSimpleHeaderOnly(5, 1, true))) // without a sequence number
+	rec.Done()
+	read, err := ReadAll(bytes.NewReader(buf.Bytes()))
+	if err != nil || len(read) != 1 {
+		t.Fatalf("Error TestRecordSequence: wrong entries %v (%v).", read, err)
+	}
+	if seq := <-sent; seq == 0 || read[0].Packet.Head.Sequence() != seq {
+		t.Fatalf("Error TestRecordSequence: sequence %d recorded, %d sent.", read[0].Packet.Head.Sequence(), seq)
+	}
+}
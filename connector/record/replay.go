@@ -0,0 +1,188 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package record
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/errors"
+	"github.com/dirkjabl/bricker/net/packet"
+	"io"
+	"sync"
+	"time"
+)
+
+// Internal type: key identifies the responses of a request by uid and function id.
+type key struct {
+	uid uint32
+	fid uint8
+}
+
+// Internal type: request identifies a recorded request by uid, function id and sequence number.
+type request struct {
+	uid uint32
+	fid uint8
+	seq uint8
+}
+
+// Internal type: response is a recorded response with its latency.
+type response struct {
+	packet  *packet.Packet
+	latency time.Duration // time between the recorded request and the response
+}
+
+/*
+Replay is a connector, which plays a recording.
+
+All received packets without a sequence number (callbacks and enumerations) are played
+in the recorded timing, divided by the speed. A request is answered with the next unused recorded
+response with the same uid and function id, after the recorded latency (divided by the speed).
+The response gets the sequence number of the request. A request without a recorded response is not answered.
+*/
+type Replay struct {
+	speed     float64
+	seq       *connector.Sequence
+	start     time.Time
+	receive   chan *event.Event
+	done      chan struct{}
+	once      sync.Once
+	lock      sync.Mutex // guards responses and unmatched
+	responses map[key][]response
+	unmatched int
+}
+
+// NewReplay creates a replay connector for the recording.
+// The speed accelerates the timing (1 is the original timing, 10 is ten times faster),
+// a speed of 0 or less plays everything without waiting.
+func NewReplay(r io.Reader, speed float64) (*Replay, error) {
+	entries, err := ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	rp := &Replay{
+		speed:     speed,
+		seq:       new(connector.Sequence),
+		start:     time.Now(),
+		receive:   make(chan *event.Event, 20),
+		done:      make(chan struct{}),
+		responses: make(map[key][]response)}
+	callbacks := make([]Entry, 0)
+	sent := make(map[request]time.Duration) // send time of the recorded requests
+	for _, e := range entries {
+		h := e.Packet.Head
+		switch {
+		case e.Direction == Sent && h.Sequence() != 0:
+			sent[request{h.Uid, h.FunctionID, h.Sequence()}] = e.Time
+		case e.Direction == Received && h.Sequence() == 0:
+			callbacks = append(callbacks, e)
+		case e.Direction == Received:
+			var latency time.Duration
+			if t, ok := sent[request{h.Uid, h.FunctionID, h.Sequence()}]; ok {
+				latency = e.Time - t
+			}
+			k := key{h.Uid, h.FunctionID}
+			rp.responses[k] = append(rp.responses[k], response{packet: e.Packet, latency: latency})
+		}
+	}
+	go rp.play(callbacks)
+	return rp, nil
+}
+
+// Send answers the request with the next recorded response.
+// Packets without the response expected flag are not answered.
+func (rp *Replay) Send(e *event.Event) {
+	if e == nil || e.Packet == nil || e.Packet.Head == nil || !e.Packet.Head.OptionResponseExpected() {
+		return
+	}
+	h := e.Packet.Head
+	if h.Sequence() == 0 { // sequence not set by the bricker
+		h.SetSequence(rp.seq.GetSequence())
+	}
+	k := key{h.Uid, h.FunctionID}
+	rp.lock.Lock()
+	queue := rp.responses[k]
+	if len(queue) == 0 {
+		rp.unmatched++
+		rp.lock.Unlock()
+		return
+	}
+	res := queue[0]
+	rp.responses[k] = queue[1:]
+	rp.lock.Unlock()
+	p := res.packet.Copy()
+	p.Head.SetSequence(h.Sequence())
+	ev := played(p)
+	time.AfterFunc(rp.scale(res.latency), func() { rp.deliver(ev) })
+}
+
+// Receive returns the next played packet. After Done, the result is nil.
+func (rp *Replay) Receive() *event.Event {
+	select {
+	case e := <-rp.receive:
+		return e
+	case <-rp.done:
+		return nil
+	}
+}
+
+// Done stops the replay.
+func (rp *Replay) Done() {
+	rp.once.Do(func() {
+		close(rp.done)
+	})
+}
+
+// Unmatched returns the number of requests, for which no recorded response was left.
+func (rp *Replay) Unmatched() int {
+	rp.lock.Lock()
+	defer rp.lock.Unlock()
+	return rp.unmatched
+}
+
+// Internal method: play delivers the recorded callbacks in their timing.
+func (rp *Replay) play(callbacks []Entry) {
+	for _, e := range callbacks {
+		if wait := time.Until(rp.start.Add(rp.scale(e.Time))); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-rp.done:
+				timer.Stop()
+				return
+			}
+		}
+		if !rp.deliver(played(e.Packet.Copy())) {
+			return
+		}
+	}
+}
+
+// Internal method: deliver puts the event into the receive channel, until the replay is done.
+func (rp *Replay) deliver(e *event.Event) bool {
+	select {
+	case rp.receive <- e:
+		return true
+	case <-rp.done:
+		return false
+	}
+}
+
+// Internal function: played creates the event for a played packet.
+// A packet with an error code of the brick daemon has the error code as error, like a received packet.
+func played(p *packet.Packet) *event.Event {
+	e := event.NewSimple(nil, p)
+	if p.Head.ErrorCodeNbr() != errors.ErrorOK {
+		e.Err = p.Head.ErrorCode()
+	}
+	return e
+}
+
+// Internal method: scale divides the recorded duration by the speed.
+func (rp *Replay) scale(d time.Duration) time.Duration {
+	if rp.speed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(d) / rp.speed)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/dirkjabl/bricker/connector"
 	"github.com/dirkjabl/bricker/event"
 	"github.com/dirkjabl/bricker/net"
+	"io"
 	"sync"
 )
 
@@ -18,11 +19,21 @@ type ConnectorSimple struct {
 	seq   *connector.Sequence
 	rlock *sync.Mutex
 	wlock *sync.Mutex
+	elock sync.Mutex // guards err
+	err   error      // the error, which ends the reading
 }
 
 // New creates a simple connector with read and write locks.
-func New(addr string) (*ConnectorSimple, error) {
-	conn, err := net.Dial(addr)
+// The options are done after the connection is established (e.g. authentication, see net/auth).
+func New(addr string, opts ...net.Option) (*ConnectorSimple, error) {
+	return NewDialer(addr, net.TCP(addr), opts...)
+}
+
+// NewDialer creates a simple connector with a connection of the dialer (e.g. a Unix socket, see net.Dialer).
+// The address is only a description of the connection.
+// The options are done after the connection is established (e.g. authentication, see net/auth).
+func NewDialer(addr string, d net.Dialer, opts ...net.Option) (*ConnectorSimple, error) {
+	conn, err := net.Open(addr, d, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -34,6 +45,11 @@ func New(addr string) (*ConnectorSimple, error) {
 	return cs, nil
 }
 
+// NewStream creates a simple connector with an established stream (e.g. one end of a net.Pipe).
+func NewStream(addr string, rwc io.ReadWriteCloser, opts ...net.Option) (*ConnectorSimple, error) {
+	return NewDialer(addr, net.Stream(rwc), opts...)
+}
+
 // Send take the packet out of the event, and write it with a write lock to the hardware connection.
 func (cs *ConnectorSimple) Send(ev *event.Event) {
 	if ev == nil || ev.Packet == nil { // no packet, no send
@@ -41,16 +57,28 @@ func (cs *ConnectorSimple) Send(ev *event.Event) {
 	}
 	cs.wlock.Lock()
 	defer cs.wlock.Unlock()
-	ev.Packet.Head.SetSequence(cs.seq.GetSequence())
+	if ev.Packet.Head.Sequence() == 0 { // sequence not set by the bricker
+		ev.Packet.Head.SetSequence(cs.seq.GetSequence())
+	}
 	ev.Packet.Head.Length = ev.Packet.ComputeLength()
 	cs.conn.WritePacket(ev.Packet)
 }
 
 // Receive reads a packet from the hardware connection with a read lock, put it in a event and return it.
+// A read error without a packet means, the connection is lost, then the result is nil.
 func (cs *ConnectorSimple) Receive() *event.Event {
 	cs.rlock.Lock()
 	defer cs.rlock.Unlock()
+	if cs.Err() != nil { // connection lost before
+		return nil
+	}
 	pck, err := cs.conn.ReadPacket()
+	if pck == nil && err != nil {
+		cs.elock.Lock()
+		cs.err = err
+		cs.elock.Unlock()
+		return nil
+	}
 	ev := event.NewSimple(err, pck)
 	return ev
 }
@@ -59,3 +87,15 @@ func (cs *ConnectorSimple) Receive() *event.Event {
 func (cs *ConnectorSimple) Done() {
 	cs.conn.Close()
 }
+
+// Err returns the error, which has closed the connection (nil, if the connection is not lost).
+func (cs *ConnectorSimple) Err() error {
+	cs.elock.Lock()
+	defer cs.elock.Unlock()
+	return cs.err
+}
+
+// Address returns the address of the hardware connection (fullfill connector.Addresser).
+func (cs *ConnectorSimple) Address() string {
+	return cs.conn.Address
+}
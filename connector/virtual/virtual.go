@@ -10,6 +10,7 @@ import (
 	"github.com/dirkjabl/bricker/connector"
 	"github.com/dirkjabl/bricker/event"
 	"github.com/dirkjabl/bricker/util/hash"
+	"sync"
 )
 
 // This is the generator type and it is a function.
@@ -23,7 +24,11 @@ type GeneratorFunc func(*event.Event) *event.Event
 // packet comes in (per Send()).
 // The matching works with hashes (hash.Hash).
 // A fallback generator exists and could overwritten.
+// The virtual connector is safe for concurrent use.
 type Virtual struct {
+	lock      sync.RWMutex // guards generator, fallback and done
+	done      bool
+	quit      chan struct{} // closed by Done, stops a blocked Send and Receive
 	receive   chan *event.Event
 	generator map[hash.Hash]GeneratorFunc
 	fallback  GeneratorFunc
@@ -33,6 +38,7 @@ type Virtual struct {
 // New creates a new virtual connector.
 func New() *Virtual {
 	v := &Virtual{
+		quit:      make(chan struct{}),
 		receive:   make(chan *event.Event, 20),
 		serial:    new(connector.Sequence),
 		generator: make(map[hash.Hash]GeneratorFunc)}
@@ -43,17 +49,23 @@ func New() *Virtual {
 // AttachGenerator add a new generator to the connector.
 // If a generator exists with the same hash, it will be overwritten.
 func (v *Virtual) AttachGenerator(h hash.Hash, f GeneratorFunc) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
 	v.generator[h] = f
 }
 
 // AttachFallbackGenerator change the existing fallback generator to
 // the new given.
 func (v *Virtual) AttachFallbackGenerator(f GeneratorFunc) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
 	v.fallback = f
 }
 
 // DetachGenerator removes a generator.
 func (v *Virtual) DetachGenerator(h hash.Hash) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
 	delete(v.generator, h)
 }
 
@@ -65,40 +77,59 @@ func (v *Virtual) DetachFallbackGenerator() {
 
 // Send takes the given event and looks for a generator for it.
 // If the generator returns a event, it will be put in the receive channel.
+// A returned response (same uid and function id) gets the sequence number of the request.
+// The generator runs without the lock, a Send blocked by a full receive channel ends with Done.
 func (v *Virtual) Send(e *event.Event) {
 	if e == nil { // no event, no processing
 		return
 	}
+	v.lock.RLock()
+	if v.done { // no more events after done
+		v.lock.RUnlock()
+		return
+	}
+	f := v.getGen(e)
+	v.lock.RUnlock()
 	if e.Packet != nil {
-		e.Packet.Head.SetSequence(v.serial.GetSequence())
+		if e.Packet.Head.Sequence() == 0 { // sequence not set by the bricker
+			e.Packet.Head.SetSequence(v.serial.GetSequence())
+		}
 		e.Packet.Head.Length = e.Packet.ComputeLength()
 	}
-	f := v.getGen(e)
 	r := f(e)
 	if r != nil {
-		v.receive <- r
+		answer(e, r)
+		select {
+		case v.receive <- r:
+		case <-v.quit: // done, nobody reads anymore
+		}
 	}
 }
 
 // Receive reads a event from the virtual connector (synchron).
+// After Done the result is nil.
 func (v *Virtual) Receive() *event.Event {
-	e, ok := <-v.receive
-	if !ok {
-		e = nil // done
+	select {
+	case e := <-v.receive:
+		return e
+	case <-v.quit:
+		return nil // done
 	}
-	return e
-
 }
 
 // Done detach all and reset the fallback generator.
-// Close the receive channel.
+// Stops the receiving and all blocked sending.
 // The virtual connector should not longer used.
 func (v *Virtual) Done() {
-	for h, _ := range v.generator {
-		v.DetachGenerator(h)
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.done {
+		return
 	}
-	v.DetachFallbackGenerator()
-	close(v.receive)
+	v.done = true
+	v.generator = make(map[hash.Hash]GeneratorFunc)
+	v.fallback = Fallback
+	close(v.quit)
 }
 
 // Fallback is the basic fallback generator.
@@ -107,14 +138,31 @@ func Fallback(e *event.Event) *event.Event {
 	return nil
 }
 
+// Internal function: answer gives a response the sequence number of the request, like the brick daemon does.
+// A response has the same uid and function id as the request, other packets (callbacks) are not changed.
+func answer(req, res *event.Event) {
+	if req.Packet == nil || req.Packet.Head == nil || res.Packet == nil || res.Packet.Head == nil {
+		return
+	}
+	if res.Packet.Head.Sequence() == 0 &&
+		res.Packet.Head.Uid == req.Packet.Head.Uid &&
+		res.Packet.Head.FunctionID == req.Packet.Head.FunctionID {
+		res.Packet.Head.SetSequence(req.Packet.Head.Sequence())
+	}
+}
+
 // Internal method: getGen find a generator to run with this event.
+// The caller has to hold the read lock.
 func (v *Virtual) getGen(e *event.Event) GeneratorFunc {
 	var h hash.Hash
+	if e.Packet == nil || e.Packet.Head == nil {
+		return v.fallback
+	}
 	for _, c := range hash.All() {
 		h = hash.New(c, e.Packet.Head.Uid, e.Packet.Head.FunctionID)
 		if f, ok := v.generator[h]; ok {
 			return f
 		}
 	}
-	return Fallback
+	return v.fallback
 }
@@ -58,6 +58,57 @@ func TestFallback(t *testing.T) {
 	}
 }
 
+func TestAnswer(t *testing.T) {
+	v := New()
+	defer v.Done()
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderOnly(e.Packet.Head.Uid, e.Packet.Head.FunctionID, false))
+	})
+	p := packet.NewSimpleHeaderOnly(123, 1, true)
+	p.Head.SetSequence(7)
+	v.Send(event.NewPacket(p))
+	r := v.Receive()
+	if r.Packet.Head.Sequence() != 7 {
+		t.Fatalf("Error TestAnswer: response has not the sequence of the request (%d != 7).", r.Packet.Head.Sequence())
+	}
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event { // callback, other function id
+		return event.NewPacket(packet.NewSimpleHeaderOnly(e.Packet.Head.Uid, 2, false))
+	})
+	v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(123, 1, true)))
+	r = v.Receive()
+	if r.Packet.Head.Sequence() != 0 {
+		t.Fatalf("Error TestAnswer: callback get a sequence (%d).", r.Packet.Head.Sequence())
+	}
+}
+
+func TestDoneWhileSending(t *testing.T) {
+	v := New()
+	v.AttachFallbackGenerator(func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderOnly(e.Packet.Head.Uid, 2, false))
+	})
+	sent := make(chan struct{})
+	go func() { // more answers as the receive channel holds, nobody reads
+		for i := 0; i < 30; i++ {
+			v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(123, 1, true)))
+		}
+		close(sent)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		v.Done()
+		close(done)
+	}()
+	for _, c := range []chan struct{}{done, sent} {
+		select {
+		case <-c:
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestDoneWhileSending: Done or Send blocks.")
+		}
+	}
+	v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(123, 1, true))) // no send after done
+}
+
 func nilEventGenerator(e *event.Event) *event.Event {
 	return event.New(errors.New("Error"), time.Now(), nil)
 }
@@ -0,0 +1,219 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultPort is the WebSocket port of the brick daemon.
+const DefaultPort = "4280"
+
+// Protocol is the sub protocol of the brick daemon (Tinkerforge protocol).
+const Protocol = "tfp"
+
+// Internal constant: guid is the fixed value for the computation of the accept key (RFC 6455, section 1.3).
+const guid = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+/*
+Conn is a WebSocket connection, which reads and writes binary messages as a stream of bytes
+(fullfill the io.ReadWriteCloser interface).
+Read returns the data of all binary messages in order, the message boundaries are not kept.
+Every Write sends one binary message.
+Ping frames are answered, a close frame ends the reading with io.EOF.
+*/
+type Conn struct {
+	Address string // address of the WebSocket server (URL)
+	conn    net.Conn
+	br      *bufio.Reader
+	client  bool       // frames of a client are masked
+	rlock   sync.Mutex // guards the reading
+	rest    []byte     // unread data of the actual message
+	wlock   sync.Mutex // guards the writing and closed
+	closed  bool       // a close frame is sent
+}
+
+// Dial connects to the WebSocket server with the address and does the opening handshake.
+// The address is a URL (ws:// or wss://) or only host and port, without a port the DefaultPort is used.
+func Dial(address string) (*Conn, error) {
+	u, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	var conn net.Conn
+	if u.Scheme == "wss" {
+		conn, err = tls.Dial("tcp", u.Host, &tls.Config{ServerName: u.Hostname()})
+	} else {
+		conn, err = net.Dial("tcp", u.Host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err := Client(conn, u)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.Address = address
+	return c, nil
+}
+
+// Client does the opening handshake of a client over an established connection.
+func Client(conn net.Conn, u *url.URL) (*Conn, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+	path := u.RequestURI()
+	req := fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\nSec-WebSocket-Protocol: %s\r\n\r\n",
+		path, u.Host, key, Protocol)
+	if _, err := io.WriteString(conn, req); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		!strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") ||
+		resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return nil, NewError(ErrorHandshake)
+	}
+	return &Conn{Address: u.String(), conn: conn, br: br, client: true}, nil
+}
+
+// Upgrade does the opening handshake of a server for the http request and takes over the connection.
+// It is the counterpart of Dial (e.g. for a test server, which stands in for the brick daemon).
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		http.Error(w, "not a websocket handshake", http.StatusBadRequest)
+		return nil, NewError(ErrorHandshake)
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "no websocket support", http.StatusInternalServerError)
+		return nil, NewError(ErrorHandshake)
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	resp := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n"
+	if strings.Contains(r.Header.Get("Sec-WebSocket-Protocol"), Protocol) {
+		resp += "Sec-WebSocket-Protocol: " + Protocol + "\r\n"
+	}
+	if _, err := io.WriteString(conn, resp+"\r\n"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{Address: r.RemoteAddr, conn: conn, br: rw.Reader}, nil
+}
+
+// Read reads the data of the binary messages (fullfill the io.Reader interface).
+func (c *Conn) Read(p []byte) (int, error) {
+	c.rlock.Lock()
+	defer c.rlock.Unlock()
+	for len(c.rest) == 0 {
+		f, err := readFrame(c.br)
+		if err != nil {
+			return 0, err
+		}
+		switch f.opcode {
+		case opBinary, opContinuation:
+			c.rest = f.payload
+		case opPing:
+			if err := c.write(opPong, f.payload); err != nil {
+				return 0, err
+			}
+		case opPong: // no ping is sent, nothing to do
+		case opClose:
+			c.write(opClose, closePayload(f.payload))
+			return 0, io.EOF
+		default: // the brick daemon uses only binary messages
+			return 0, NewError(ErrorProtocol)
+		}
+	}
+	n := copy(p, c.rest)
+	c.rest = c.rest[n:]
+	return n, nil
+}
+
+// Write sends the data as one binary message (fullfill the io.Writer interface).
+func (c *Conn) Write(p []byte) (int, error) {
+	if err := c.write(opBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close sends a close frame and closes the connection (fullfill the io.Closer interface).
+func (c *Conn) Close() error {
+	c.write(opClose, closePayload(nil))
+	return c.conn.Close()
+}
+
+// Internal method: write sends a frame, after a close frame no more frames are sent.
+func (c *Conn) write(opcode uint8, payload []byte) error {
+	c.wlock.Lock()
+	defer c.wlock.Unlock()
+	if c.closed {
+		return NewError(ErrorClosed)
+	}
+	if opcode == opClose {
+		c.closed = true
+	}
+	return writeFrame(c.conn, opcode, payload, c.client)
+}
+
+// Internal function: closePayload computes the payload of a answering close frame.
+// The status code of the received close frame is sent back, without a status code it is 1000 (normal closure).
+func closePayload(received []byte) []byte {
+	if len(received) >= 2 {
+		return received[:2]
+	}
+	return binary.BigEndian.AppendUint16(nil, 1000)
+}
+
+// Internal function: acceptKey computes the accept key of the server for the key of the client.
+func acceptKey(key string) string {
+	h := sha1.Sum([]byte(key + guid))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// Internal function: parseAddress parses the address to a WebSocket URL.
+func parseAddress(address string) (*url.URL, error) {
+	if !strings.Contains(address, "://") {
+		address = "ws://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return nil, NewError(ErrorHandshake)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+	return u, nil
+}
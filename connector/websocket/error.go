@@ -0,0 +1,42 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package websocket
+
+// All known errors for a WebSocket connection.
+const (
+	ErrorUnknown = iota
+	ErrorHandshake
+	ErrorProtocol
+	ErrorMessageTooLarge
+	ErrorClosed
+)
+
+// Error type for the WebSocket connection.
+type Error struct {
+	Code uint8
+}
+
+// NewError create the error object.
+func NewError(code uint8) Error {
+	return Error{code}
+}
+
+// Error gives a string representation for the error code.
+func (e Error) Error() string {
+	switch e.Code {
+	case ErrorHandshake:
+		return "WebSocket handshake failed."
+	case ErrorProtocol:
+		return "WebSocket protocol error."
+	case ErrorMessageTooLarge:
+		return "WebSocket frame is too large."
+	case ErrorClosed:
+		return "WebSocket connection is closed."
+	case ErrorUnknown:
+		fallthrough
+	default:
+		return "Unknown error."
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// All used opcodes of the frames (RFC 6455, section 5.2).
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// MaxFrameSize is the maximal payload size of a received frame.
+// The packets of the brick daemon are only 80 bytes long, larger frames are a protocol error.
+const MaxFrameSize = 1 << 16
+
+// Internal type: frame is a WebSocket frame.
+type frame struct {
+	fin     bool
+	opcode  uint8
+	payload []byte
+}
+
+// Internal function: isControl checks, if the opcode is a control frame (close, ping, pong).
+func isControl(opcode uint8) bool {
+	return opcode&0x8 != 0
+}
+
+// Internal function: writeFrame writes a complete frame with the payload.
+// The frames of a client are masked with a random key.
+func writeFrame(w io.Writer, opcode uint8, payload []byte, masked bool) error {
+	buf := make([]byte, 0, 14+len(payload))
+	buf = append(buf, 0x80|opcode) // fin, no fragments
+	var mbit byte
+	if masked {
+		mbit = 0x80
+	}
+	switch n := len(payload); {
+	case n < 126:
+		buf = append(buf, mbit|byte(n))
+	case n <= 0xffff:
+		buf = append(buf, mbit|126)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(n))
+	default:
+		buf = append(buf, mbit|127)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(n))
+	}
+	if !masked {
+		buf = append(buf, payload...)
+	} else {
+		var key [4]byte
+		if _, err := rand.Read(key[:]); err != nil {
+			return err
+		}
+		buf = append(buf, key[:]...)
+		start := len(buf)
+		buf = append(buf, payload...)
+		mask(key, buf[start:])
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// Internal function: readFrame reads a frame.
+// A masked frame is unmasked.
+func readFrame(r io.Reader) (*frame, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	f := &frame{fin: head[0]&0x80 != 0, opcode: head[0] & 0x0f}
+	if head[0]&0x70 != 0 { // no extension is negotiated
+		return nil, NewError(ErrorProtocol)
+	}
+	n := uint64(head[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if isControl(f.opcode) && (n > 125 || !f.fin) {
+		return nil, NewError(ErrorProtocol)
+	}
+	if n > MaxFrameSize {
+		return nil, NewError(ErrorMessageTooLarge)
+	}
+	var key [4]byte
+	masked := head[1]&0x80 != 0
+	if masked {
+		if _, err := io.ReadFull(r, key[:]); err != nil {
+			return nil, err
+		}
+	}
+	f.payload = make([]byte, n)
+	if _, err := io.ReadFull(r, f.payload); err != nil {
+		return nil, err
+	}
+	if masked {
+		mask(key, f.payload)
+	}
+	return f, nil
+}
+
+// Internal function: mask masks or unmasks the data with the key.
+func mask(key [4]byte, data []byte) {
+	for i := range data {
+		data[i] ^= key[i%4]
+	}
+}
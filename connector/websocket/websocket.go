@@ -0,0 +1,144 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Implementation of a connector for the WebSocket port of the brick daemon.
+
+The brick daemon could offer its protocol over WebSocket (default port 4280, see the
+brickd option listen.websocket_port). The packets are sent and received in binary messages,
+so a bricker could work through firewalls and proxies, which only pass HTTP.
+
+	conn, err := websocket.New("ws://localhost:4280")
+	if err != nil {
+		fmt.Printf("No connection: %s\n", err.Error())
+		return
+	}
+	brick.Attach(conn, "websocket")
+
+The package has a minimal WebSocket implementation (RFC 6455) without extensions,
+Conn could be used also without the connector (e.g. Upgrade for a test server).
+*/
+package websocket
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"io"
+	"sync"
+)
+
+// maxLength is the maximal length of a packet (header and payload).
+const maxLength = 80
+
+// The WebSocket connector type.
+type ConnectorWebSocket struct {
+	conn  *Conn
+	seq   *connector.Sequence
+	wlock sync.Mutex // orders the sending
+	rlock sync.Mutex // orders the receiving
+	elock sync.Mutex // guards err
+	err   error      // the error, which ends the reading
+}
+
+// New creates a WebSocket connector with a connection to the address (URL or host and port).
+func New(address string) (*ConnectorWebSocket, error) {
+	conn, err := Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn), nil
+}
+
+// NewConn creates a WebSocket connector with an established WebSocket connection.
+func NewConn(conn *Conn) *ConnectorWebSocket {
+	return &ConnectorWebSocket{
+		conn: conn,
+		seq:  new(connector.Sequence)}
+}
+
+// Send takes the packet out of the event and sends it in one binary message.
+func (cw *ConnectorWebSocket) Send(ev *event.Event) {
+	if ev == nil || ev.Packet == nil { // no packet, no send
+		return
+	}
+	cw.wlock.Lock()
+	defer cw.wlock.Unlock()
+	if ev.Packet.Head.Sequence() == 0 { // sequence not set by the bricker
+		ev.Packet.Head.SetSequence(cw.seq.GetSequence())
+	}
+	ev.Packet.Head.Length = ev.Packet.ComputeLength()
+	var buf bytes.Buffer
+	if ev.Packet.Write(&buf) == nil {
+		cw.conn.Write(buf.Bytes())
+	}
+}
+
+// Receive reads a packet from the binary messages, puts it in a event and returns it.
+// A read error without a packet means, the connection is lost, then the result is nil.
+func (cw *ConnectorWebSocket) Receive() *event.Event {
+	cw.rlock.Lock()
+	defer cw.rlock.Unlock()
+	if cw.Err() != nil { // connection lost before
+		return nil
+	}
+	data, err := cw.read()
+	if data == nil && err == nil { // packet skipped, the connection is still usable
+		return event.NewSimple(NewError(ErrorProtocol), nil)
+	}
+	var pck *packet.Packet
+	if err == nil {
+		pck, err = packet.ReadNew(bytes.NewReader(data))
+	}
+	if pck == nil && err != nil {
+		cw.elock.Lock()
+		cw.err = err
+		cw.elock.Unlock()
+		return nil
+	}
+	return event.NewSimple(err, pck)
+}
+
+// Internal method: read reads the bytes of the next packet.
+// A packet could be split over more than one message, so the length is taken from the header.
+// A packet longer than maxLength is read and skipped, then the result is nil without an error.
+func (cw *ConnectorWebSocket) read() ([]byte, error) {
+	data := make([]byte, 8, maxLength)
+	if _, err := io.ReadFull(cw.conn, data); err != nil {
+		return nil, err
+	}
+	l := int(data[4]) // length of the packet (see net/head)
+	if l < 8 {
+		return nil, NewError(ErrorProtocol)
+	}
+	if l > maxLength {
+		if _, err := io.CopyN(io.Discard, cw.conn, int64(l-8)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	data = data[:l]
+	if _, err := io.ReadFull(cw.conn, data[8:]); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Done closes the WebSocket connection.
+func (cw *ConnectorWebSocket) Done() {
+	cw.conn.Close()
+}
+
+// Err returns the error, which has closed the connection (nil, if the connection is not lost).
+func (cw *ConnectorWebSocket) Err() error {
+	cw.elock.Lock()
+	defer cw.elock.Unlock()
+	return cw.err
+}
+
+// Address returns the address of the WebSocket server (fullfill connector.Addresser).
+func (cw *ConnectorWebSocket) Address() string {
+	return cw.conn.Address
+}
@@ -0,0 +1,191 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer starts a WebSocket server, which stands in for the brick daemon.
+// Every connection is handled by the handler.
+func newTestServer(t *testing.T, handler func(c *Conn)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := Upgrade(w, r)
+		if err != nil {
+			t.Errorf("Error %s: upgrade failed (%s).", t.Name(), err.Error())
+			return
+		}
+		defer c.Close()
+		handler(c)
+	}))
+}
+
+// echo answers every request with a response of the same uid, function id and sequence number.
+func echo(c *Conn) {
+	cw := NewConn(c)
+	for {
+		ev := cw.Receive()
+		if ev == nil {
+			return
+		}
+		res := packet.NewSimpleHeaderOnly(ev.Packet.Head.Uid, ev.Packet.Head.FunctionID, false)
+		res.Head.SetSequence(ev.Packet.Head.Sequence())
+		cw.Send(event.NewPacket(res))
+	}
+}
+
+// wsAddress converts the address of the test server to a WebSocket URL.
+func wsAddress(s *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func TestAcceptKey(t *testing.T) {
+	if k := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); k != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" { // RFC 6455, section 1.3
+		t.Fatalf("Error TestAcceptKey: wrong accept key %s.", k)
+	}
+}
+
+func TestFrame(t *testing.T) {
+	for _, n := range []int{0, 20, 200, MaxFrameSize} {
+		for _, masked := range []bool{true, false} {
+			payload := bytes.Repeat([]byte{0xa5}, n)
+			var buf bytes.Buffer
+			if err := writeFrame(&buf, opBinary, payload, masked); err != nil {
+				t.Fatalf("Error TestFrame: write failed (%s).", err.Error())
+			}
+			f, err := readFrame(&buf)
+			if err != nil || !f.fin || f.opcode != opBinary || !bytes.Equal(f.payload, payload) {
+				t.Fatalf("Error TestFrame: wrong frame for %d bytes (masked %t, error %v).", n, masked, err)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	writeFrame(&buf, opBinary, make([]byte, MaxFrameSize+1), false)
+	if _, err := readFrame(&buf); err == nil || err.(Error).Code != ErrorMessageTooLarge {
+		t.Fatalf("Error TestFrame: large frame is accepted (%v).", err)
+	}
+	buf.Reset()
+	writeFrame(&buf, opPing, make([]byte, 126), false)
+	if _, err := readFrame(&buf); err == nil || err.(Error).Code != ErrorProtocol {
+		t.Fatalf("Error TestFrame: large control frame is accepted (%v).", err)
+	}
+}
+
+func TestConnector(t *testing.T) {
+	s := newTestServer(t, echo)
+	defer s.Close()
+	cw, err := New(wsAddress(s))
+	if err != nil {
+		t.Fatalf("Error TestConnector: could not connect (%s).", err.Error())
+	}
+	defer cw.Done()
+	for fid := uint8(1); fid <= 3; fid++ {
+		cw.Send(event.NewPacket(packet.NewSimpleHeaderOnly(42, fid, true)))
+		ev := cw.Receive()
+		if ev == nil || ev.Err != nil || ev.Packet.Head.Uid != 42 || ev.Packet.Head.FunctionID != fid ||
+			ev.Packet.Head.Sequence() == 0 {
+			t.Fatalf("Error TestConnector: wrong response %v.", ev)
+		}
+	}
+	if cw.Address() != wsAddress(s) {
+		t.Fatalf("Error TestConnector: wrong address %s.", cw.Address())
+	}
+}
+
+func TestSplitPacket(t *testing.T) {
+	p := packet.NewSimpleHeaderPayload(42, 7, false, uint32(0xdeadbeef))
+	p.Head.Length = p.ComputeLength()
+	var data bytes.Buffer
+	p.Write(&data)
+	s := newTestServer(t, func(c *Conn) {
+		writeFrame(c.conn, opPing, []byte("ping"), false)
+		c.Write(data.Bytes()[:5]) // the packet in two messages
+		c.Write(data.Bytes()[5:])
+		io.Copy(io.Discard, c) // until closed
+	})
+	defer s.Close()
+	cw, err := New(wsAddress(s))
+	if err != nil {
+		t.Fatalf("Error TestSplitPacket: could not connect (%s).", err.Error())
+	}
+	defer cw.Done()
+	ev := cw.Receive()
+	var v uint32
+	if ev == nil || ev.Packet.Head.FunctionID != 7 || ev.Packet.Payload.Decode(&v) != nil || v != 0xdeadbeef {
+		t.Fatalf("Error TestSplitPacket: wrong packet %v.", ev)
+	}
+}
+
+func TestOversizedPacket(t *testing.T) {
+	p := packet.NewSimpleHeaderOnly(42, 7, false)
+	p.Head.Length = p.ComputeLength()
+	var data bytes.Buffer
+	p.Write(&data)
+	oversized := make([]byte, 200)
+	copy(oversized, data.Bytes())
+	oversized[4] = 200 // longer than any packet
+	s := newTestServer(t, func(c *Conn) {
+		c.Write(oversized)
+		c.Write(data.Bytes())
+		io.Copy(io.Discard, c) // until closed
+	})
+	defer s.Close()
+	cw, err := New(wsAddress(s))
+	if err != nil {
+		t.Fatalf("Error TestOversizedPacket: could not connect (%s).", err.Error())
+	}
+	defer cw.Done()
+	ev := cw.Receive()
+	if ev == nil || ev.Packet != nil {
+		t.Fatalf("Error TestOversizedPacket: no error event %v.", ev)
+	}
+	if err, ok := ev.Err.(Error); !ok || err.Code != ErrorProtocol {
+		t.Fatalf("Error TestOversizedPacket: wrong error %v.", ev.Err)
+	}
+	if ev = cw.Receive(); ev == nil || ev.Packet == nil || ev.Packet.Head.FunctionID != 7 {
+		t.Fatalf("Error TestOversizedPacket: packet after the oversized one not received %v.", ev)
+	}
+}
+
+func TestClose(t *testing.T) {
+	s := newTestServer(t, func(c *Conn) {}) // closes the connection directly
+	defer s.Close()
+	cw, err := New(wsAddress(s))
+	if err != nil {
+		t.Fatalf("Error TestClose: could not connect (%s).", err.Error())
+	}
+	defer cw.Done()
+	if ev := cw.Receive(); ev != nil {
+		t.Fatalf("Error TestClose: event after close %v.", ev)
+	}
+	if cw.Err() != io.EOF {
+		t.Fatalf("Error TestClose: wrong cause %v.", cw.Err())
+	}
+}
+
+func TestHandshakeFail(t *testing.T) {
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+	if _, err := New(wsAddress(s)); err == nil || err.(Error).Code != ErrorHandshake {
+		t.Fatalf("Error TestHandshakeFail: wrong error %v.", err)
+	}
+	if _, err := New("http://localhost"); err == nil {
+		t.Fatalf("Error TestHandshakeFail: wrong scheme is accepted.")
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	u, err := parseAddress("localhost")
+	if err != nil || u.String() != "ws://localhost:4280" {
+		t.Fatalf("Error TestParseAddress: wrong url %v (%v).", u, err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package master
+
+// ExtensionTypeName results a string representation of the given extension type.
+func ExtensionTypeName(t uint32) string {
+	switch t {
+	case ExtensionTypeChibi:
+		return "Chibi"
+	case ExtensionTypeRS485:
+		return "RS485"
+	case ExtensionTypeWifi:
+		return "WIFI"
+	case ExtensionTypeEthernet:
+		return "Ethernet"
+	case ExtensionTypeWifi2:
+		return "WIFI 2.0"
+	default:
+		return "Unknown"
+	}
+}
+
+// Float64 convert the voltage from mV (uint16) to V (float64).
+func (v *Voltage) Float64() float64 {
+	f := float64(v.Value) / 1000.0
+	return f
+}
+
+// Float32 convert the voltage from mV (uint16) to V (float32).
+func (v *Voltage) Float32() float32 {
+	f := float32(v.Value) / 1000.0
+	return f
+}
+
+// Float64 convert the current from mA (uint16) to A (float64).
+func (c *Current) Float64() float64 {
+	f := float64(c.Value) / 1000.0
+	return f
+}
+
+// Float32 convert the current from mA (uint16) to A (float32).
+func (c *Current) Float32() float32 {
+	f := float32(c.Value) / 1000.0
+	return f
+}
+
+// Float64 convert the chip temperature from °C/10 (int16) to °C (float64).
+func (ct *ChipTemperature) Float64() float64 {
+	f := float64(ct.Value) / 10.0
+	return f
+}
+
+// Float32 convert the chip temperature from °C/10 (int16) to °C (float32).
+func (ct *ChipTemperature) Float32() float32 {
+	f := float32(ct.Value) / 10.0
+	return f
+}
@@ -0,0 +1,197 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package master
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"testing"
+	"time"
+)
+
+const testUid = uint32(42)
+
+func newMasterBricker(t *testing.T) (*bricker.Bricker, *virtual.Virtual) {
+	brick := bricker.New()
+	v := virtual.New()
+	if err := brick.Attach(v, "virtual"); err != nil {
+		t.Fatalf("Error %s: could not attach virtual connector (%s).", t.Name(), err.Error())
+	}
+	return brick, v
+}
+
+// Internal function: respond attaches a generator, which answers the function with the payload.
+func respond(v *virtual.Virtual, fid uint8, payload interface{}) {
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, testUid, fid), func(e *event.Event) *event.Event {
+		if payload == nil {
+			return event.NewPacket(packet.NewSimpleHeaderOnly(testUid, fid, false))
+		}
+		return event.NewPacket(packet.NewSimpleHeaderPayload(testUid, fid, false, payload))
+	})
+}
+
+func newContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), time.Second)
+}
+
+func TestGetStackVoltage(t *testing.T) {
+	brick, v := newMasterBricker(t)
+	defer v.Done()
+	respond(v, function_get_stack_voltage, &Voltage{Value: 12345})
+	ctx, cancel := newContext()
+	defer cancel()
+	r, err := GetStackVoltageFutureContext(ctx, brick, "virtual", testUid)
+	if err != nil {
+		t.Fatalf("Error TestGetStackVoltage: unexpected error (%s).", err.Error())
+	}
+	if r.Value != 12345 || r.Float64() != 12.345 {
+		t.Fatalf("Error TestGetStackVoltage: wrong result (%s).", r)
+	}
+}
+
+func TestGetStackCurrent(t *testing.T) {
+	brick, v := newMasterBricker(t)
+	defer v.Done()
+	respond(v, function_get_stack_current, &Current{Value: 1500})
+	r := GetStackCurrentFuture(brick, "virtual", testUid)
+	if r == nil || r.Value != 1500 || r.Float64() != 1.5 {
+		t.Fatalf("Error TestGetStackCurrent: wrong result (%s).", r)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	brick, v := newMasterBricker(t)
+	defer v.Done()
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, testUid, function_get_extension_type),
+		func(e *event.Event) *event.Event {
+			ex := new(Extension)
+			if err := e.Packet.Payload.Decode(ex); err != nil {
+				return nil
+			}
+			et := &ExtensionType{Type: ExtensionTypeChibi}
+			if ex.Value == 1 {
+				et.Type = ExtensionTypeEthernet
+			}
+			return event.NewPacket(packet.NewSimpleHeaderPayload(testUid, function_get_extension_type, false, et))
+		})
+	respond(v, function_is_ethernet_present, &Present{IsPresent: true})
+	respond(v, function_is_wifi_present, &Present{IsPresent: false})
+	m := New(brick, testUid)
+	ctx, cancel := newContext()
+	defer cancel()
+	et, err := m.GetExtensionType(ctx, &Extension{Value: 1})
+	if err != nil {
+		t.Fatalf("Error TestExtensions: unexpected error (%s).", err.Error())
+	}
+	if et.Type != ExtensionTypeEthernet || ExtensionTypeName(et.Type) != "Ethernet" {
+		t.Fatalf("Error TestExtensions: wrong extension type (%s).", et)
+	}
+	p, err := m.IsEthernetPresent(ctx)
+	if err != nil || !p.IsPresent {
+		t.Fatalf("Error TestExtensions: ethernet is not present (%s, %v).", p, err)
+	}
+	p, err = m.IsWifiPresent(ctx)
+	if err != nil || p.IsPresent {
+		t.Fatalf("Error TestExtensions: wifi is present (%s, %v).", p, err)
+	}
+}
+
+func TestSetStackCurrentCallbackThreshold(t *testing.T) {
+	brick, v := newMasterBricker(t)
+	defer v.Done()
+	got := make(chan *device.Threshold16, 1)
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, testUid, function_set_stack_current_callback_threshold),
+		func(e *event.Event) *event.Event {
+			th := new(device.Threshold16)
+			if e.Packet.Payload.Decode(th) == nil {
+				got <- th
+			}
+			return event.NewPacket(packet.NewSimpleHeaderOnly(testUid, function_set_stack_current_callback_threshold, false))
+		})
+	ctx, cancel := newContext()
+	defer cancel()
+	th := &device.Threshold16{Option: 'o', Min: 100, Max: 2000}
+	if err := New(brick, testUid).SetStackCurrentCallbackThreshold(ctx, th); err != nil {
+		t.Fatalf("Error TestSetStackCurrentCallbackThreshold: unexpected error (%s).", err.Error())
+	}
+	select {
+	case r := <-got:
+		if *r != *th {
+			t.Fatalf("Error TestSetStackCurrentCallbackThreshold: wrong threshold (%s).", r)
+		}
+	default:
+		t.Fatalf("Error TestSetStackCurrentCallbackThreshold: no threshold send.")
+	}
+}
+
+func TestStackVoltageReached(t *testing.T) {
+	brick, v := newMasterBricker(t)
+	defer v.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := New(brick, testUid).StackVoltageReached(ctx)
+	if err != nil {
+		t.Fatalf("Error TestStackVoltageReached: unexpected error (%s).", err.Error())
+	}
+	trigger := uint8(200) // the virtual connector sends the callback for this request
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, testUid, trigger), func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderPayload(testUid, callback_stack_voltage_reached, false, &Voltage{Value: 23000}))
+	})
+	v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(testUid, trigger, false)))
+	select {
+	case r := <-c:
+		if r.Value != 23000 {
+			t.Fatalf("Error TestStackVoltageReached: wrong result (%s).", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestStackVoltageReached: no callback.")
+	}
+}
+
+func TestHandleGroupFailover(t *testing.T) {
+	brick, usb := newMasterBricker(t)
+	defer usb.Done()
+	eth := virtual.New()
+	defer eth.Done()
+	if err := brick.Attach(eth, "eth"); err != nil {
+		t.Fatalf("Error TestHandleGroupFailover: could not attach connector (%s).", err.Error())
+	}
+	brick.Group("stack", bricker.Failover, "virtual", "eth")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := New(brick, testUid).StackVoltageReached(ctx)
+	if err != nil {
+		t.Fatalf("Error TestHandleGroupFailover: unexpected error (%s).", err.Error())
+	}
+	brick.Release("virtual") // the callback follows the group
+	trigger := uint8(200)    // the virtual connector sends the callback for this request
+	eth.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, testUid, trigger), func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderPayload(testUid, callback_stack_voltage_reached, false, &Voltage{Value: 23000}))
+	})
+	eth.Send(event.NewPacket(packet.NewSimpleHeaderOnly(testUid, trigger, false)))
+	select {
+	case r, ok := <-c:
+		if !ok || r.Value != 23000 {
+			t.Fatalf("Error TestHandleGroupFailover: wrong result (%v, open: %t).", r, ok)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestHandleGroupFailover: no callback.")
+	}
+}
+
+func TestChipTemperature(t *testing.T) {
+	ct := &ChipTemperature{Value: 356}
+	if ct.Float64() != 35.6 {
+		t.Fatalf("Error TestChipTemperature: wrong conversion (%f).", ct.Float64())
+	}
+	if ct.String() != "Chip temperature [Value: 356, Temperature: 35.6°C]" {
+		t.Fatalf("Error TestChipTemperature: wrong string (%s).", ct.String())
+	}
+}
@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Ambient Light Bricklet.
 package ambientlight
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Ambient Light Bricklet (see device/name).
+const DeviceIdentifier = uint16(21)
+
 const (
 	function_get_illuminance                     = uint8(1)
 	function_get_analog_value                    = uint8(2)
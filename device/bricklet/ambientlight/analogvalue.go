@@ -2,20 +2,21 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package ambientlight
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-GetAnalogValue creates A subscriber to return the raw 12-bit analog value (0 up to 4095).
-It is only useful, if you need the full resolution of the analog-to-digital converter.
-Please use normaly GetIlluminance.
-*/
+// GetAnalogValue creates A subscriber to return the raw 12-bit analog value (0 up to 4095).
+// It is only useful, if you need the full resolution of the analog-to-digital converter.
+// Please use normaly GetIlluminance.
 func GetAnalogValue(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "GetAnalogValue"),
@@ -23,44 +24,43 @@ func GetAnalogValue(id string, uid uint32, handler func(device.Resulter, error))
 		Uid:        uid,
 		Result:     &AnalogValue{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetAnalogValueFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetAnalogValueFuture(brick bricker.Bricker, connectorname string, uid uint32) *AnalogValue {
-	future := make(chan *AnalogValue)
-	defer close(future)
-	sub := GetAnalogValue("getanalogvaluefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *AnalogValue = nil
-			if err == nil {
-				if value, ok := r.(*AnalogValue); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetAnalogValueFuture(brick *bricker.Bricker, connectorname string, uid uint32) *AnalogValue {
+	v, _ := GetAnalogValueFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAnalogValueFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAnalogValueFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*AnalogValue, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAnalogValue("getanalogvaluefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	v := <-future
-	return v
+	if v, ok := res.(*AnalogValue); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-AnalogValue is a type for the 12-bit analog-to-digial converter value.
-It can have values between 0 and 4095. This is the raw unfiltered analog value.
-Please see the original documentation
-http://www.tinkerforge.com/en/doc/Software/Bricklets/AmbientLight_Bricklet_TCPIP.html#BrickletAmbientLight.get_analog_value
-for more information.
-*/
+// AnalogValue is a type for the 12-bit analog-to-digial converter value.
+// It can have values between 0 and 4095. This is the raw unfiltered analog value.
+// Please see the original documentation
+// http://www.tinkerforge.com/en/doc/Software/Bricklets/AmbientLight_Bricklet_TCPIP.html#BrickletAmbientLight.get_analog_value
+// for more information.
 type AnalogValue struct {
 	Value uint16
 }
 
-// FromPacket creates from a packet a analog value.
+// FromPacket converts the packet payload to the AnalogValue type.
 func (av *AnalogValue) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(av, p); err != nil {
 		return err
@@ -84,5 +84,7 @@ func (av *AnalogValue) Copy() device.Resulter {
 	if av == nil {
 		return nil
 	}
-	return &AnalogValue{Value: av.Value}
+	return &AnalogValue{
+		Value: av.Value,
+	}
 }
@@ -2,19 +2,20 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package ambientlight
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
 
-/*
-SetDebouncePeriod creates the subscriber to get the debounce period.
-The default value is 100 (ms).
-This sets the period in ms in which the threshold callbacks are triggered,
-only if the threshold are being reached.
-*/
+// SetDebouncePeriod creates the subscriber to get the debounce period.
+// The default value is 100 (ms).
+// This sets the period in ms in which the threshold callbacks are triggered,
+// only if the threshold are being reached.
 func SetDebouncePeriod(id string, uid uint32, d *device.Debounce, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetDebouncePeriod"),
@@ -22,23 +23,24 @@ func SetDebouncePeriod(id string, uid uint32, d *device.Debounce, handler func(d
 		Uid:        uid,
 		Data:       d,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetDebouncePeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetDebouncePeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32, d *device.Debounce) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetDebouncePeriod("setdebounceperiodfuture"+device.GenId(), uid, d,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetDebouncePeriodFutureContext(context.Background(), brick, connectorname, uid, d) == nil
+}
+
+// SetDebouncePeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetDebouncePeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, d *device.Debounce) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetDebouncePeriod("setdebounceperiodfuture"+device.GenId(), uid, d, nil))
+	return err
 }
 
 // GetDebouncePeriod creates the subscriber to set the debounce period.
@@ -49,27 +51,29 @@ func GetDebouncePeriod(id string, uid uint32, handler func(device.Resulter, erro
 		Uid:        uid,
 		Result:     &device.Debounce{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetDebouncePeriodFuture is a future pattern version for a synchronized all of the subscriber.
+// GetDebouncePeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetDebouncePeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Debounce {
-	future := make(chan *device.Debounce)
-	defer close(future)
-	sub := GetDebouncePeriod("getdebounceperiodfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Debounce = nil
-			if err == nil {
-				if value, ok := r.(*device.Debounce); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetDebouncePeriodFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetDebouncePeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetDebouncePeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Debounce, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetDebouncePeriod("getdebounceperiodfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Debounce); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
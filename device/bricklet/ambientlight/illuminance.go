@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package ambientlight
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -18,29 +21,31 @@ func GetIlluminance(id string, uid uint32, handler func(device.Resulter, error))
 		Uid:        uid,
 		Result:     &Illuminance{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetIlluminanceFuture is a future pattern version for a synchronized calll of the subscriber.
+// GetIlluminanceFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetIlluminanceFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Illuminance {
-	future := make(chan *Illuminance)
-	defer close(future)
-	sub := GetIlluminance("getilluminancefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Illuminance = nil
-			if err == nil {
-				if value, ok := r.(*Illuminance); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetIlluminanceFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetIlluminanceFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetIlluminanceFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Illuminance, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetIlluminance("getilluminancefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Illuminance); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Illuminance is a type for the illuminance value.
@@ -49,7 +54,7 @@ type Illuminance struct {
 	Value uint16
 }
 
-// FromPacket creates from a packet a illuminance value.
+// FromPacket converts the packet payload to the Illuminance type.
 func (i *Illuminance) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(i, p); err != nil {
 		return err
@@ -63,8 +68,7 @@ func (i *Illuminance) String() string {
 	if i == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Value: %d, Illuminance: %03.02 Lux]",
-			i.Value, i.Float64())
+		txt += fmt.Sprintf("[Value: %d, Illuminance: %06.2f Lux]", i.Value, i.Float64())
 	}
 	return txt
 }
@@ -74,17 +78,7 @@ func (i *Illuminance) Copy() device.Resulter {
 	if i == nil {
 		return nil
 	}
-	return &Illuminance{Value: i.Value}
-}
-
-// Float64 converts the illuminance value from int16 to float64.
-func (i *Illuminance) Float64() float64 {
-	f := float64(i.Value) / 10.00
-	return f
-}
-
-// Float32 converts the illuminance value from int16 to float32.
-func (i *Illuminance) Float32() float32 {
-	f := float32(i.Value) / 10.00
-	return f
+	return &Illuminance{
+		Value: i.Value,
+	}
 }
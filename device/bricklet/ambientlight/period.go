@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package ambientlight
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -19,23 +22,25 @@ func SetIlluminanceCallbackPeriod(id string, uid uint32, pe *device.Period, hand
 		Uid:        uid,
 		Data:       pe,
 		Handler:    handler,
+		Restore:    true,
+		Disable:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetIlluminanceCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetIlluminanceCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32, pe *device.Period) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetIlluminanceCallbackPeriod("setilluminancecallbackperiodfuture"+device.GenId(), uid, pe,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetIlluminanceCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid, pe) == nil
+}
+
+// SetIlluminanceCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetIlluminanceCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pe *device.Period) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetIlluminanceCallbackPeriod("setilluminancecallbackperiodfuture"+device.GenId(), uid, pe, nil))
+	return err
 }
 
 // GetIlluminanceCallbackPeriod creates a subsctiber to get the callback period value.
@@ -46,29 +51,31 @@ func GetIlluminanceCallbackPeriod(id string, uid uint32, handler func(device.Res
 		Uid:        uid,
 		Result:     &device.Period{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetIlluminanceCallbackPeriodFuture is a future pattern version for a synchronized call of the subsctiber.
+// GetIlluminanceCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetIlluminanceCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Period {
-	future := make(chan *device.Period)
-	defer close(future)
-	sub := GetIlluminanceCallbackPeriod("getilluminancecallbackperiodfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Period = nil
-			if err == nil {
-				if value, ok := r.(*device.Period); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetIlluminanceCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetIlluminanceCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetIlluminanceCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Period, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetIlluminanceCallbackPeriod("getilluminancecallbackperiodfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Period); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetAnalogValueCallbackPeriod creates the subscriber to set the callback period.
@@ -81,23 +88,25 @@ func SetAnalogValueCallbackPeriod(id string, uid uint32, pe *device.Period, hand
 		Uid:        uid,
 		Data:       pe,
 		Handler:    handler,
+		Restore:    true,
+		Disable:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetAnalogValueCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetAnalogValueCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32, pe *device.Period) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAnalogValueCallbackPeriod("setanalogvaluecallbackperiodfuture"+device.GenId(), uid, pe,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetAnalogValueCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid, pe) == nil
+}
+
+// SetAnalogValueCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAnalogValueCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pe *device.Period) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAnalogValueCallbackPeriod("setanalogvaluecallbackperiodfuture"+device.GenId(), uid, pe, nil))
+	return err
 }
 
 // GetAnalogValueCallbackPeriod creates a subsctiber to get the callback period value.
@@ -108,29 +117,31 @@ func GetAnalogValueCallbackPeriod(id string, uid uint32, handler func(device.Res
 		Uid:        uid,
 		Result:     &device.Period{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetAnalogValueCallbackPeriodFuture is a future pattern version for a synchronized call of the subsctiber.
+// GetAnalogValueCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAnalogValueCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Period {
-	future := make(chan *device.Period)
-	defer close(future)
-	sub := GetAnalogValueCallbackPeriod("getanalogvaluecallbackperiodfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Period = nil
-			if err == nil {
-				if value, ok := r.(*device.Period); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAnalogValueCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAnalogValueCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAnalogValueCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Period, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAnalogValueCallbackPeriod("getanalogvaluecallbackperiodfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Period); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // IlluminancePeriod creates a subscriber for the periodical illuminance callback.
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package ambientlight
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -18,23 +21,24 @@ func SetIlluminanceCallbackThreshold(id string, uid uint32, t *device.Threshold1
 		Uid:        uid,
 		Data:       t,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetIlluminanceCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetIlluminanceCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32, t *device.Threshold16) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetIlluminanceCallbackThreshold("setilluminancecallbackthresholdfuture"+device.GenId(), uid, t,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetIlluminanceCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid, t) == nil
+}
+
+// SetIlluminanceCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetIlluminanceCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, t *device.Threshold16) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetIlluminanceCallbackThreshold("setilluminancecallbackthresholdfuture"+device.GenId(), uid, t, nil))
+	return err
 }
 
 // GetIlluminanceCallbackThreshold creates the subscriber to get the callback thresold.
@@ -45,29 +49,31 @@ func GetIlluminanceCallbackThreshold(id string, uid uint32, handler func(device.
 		Uid:        uid,
 		Result:     &device.Threshold16{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetIlluminanceCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetIlluminanceCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Threshold16 {
-	future := make(chan *device.Threshold16)
-	defer close(future)
-	sub := GetIlluminanceCallbackThreshold("getilluminancecallbackthresholdfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Threshold16 = nil
-			if err == nil {
-				if value, ok := r.(*device.Threshold16); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetIlluminanceCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetIlluminanceCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetIlluminanceCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Threshold16, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetIlluminanceCallbackThreshold("getilluminancecallbackthresholdfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Threshold16); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetAnalogValueCallbackThreshold creates the subscriber to set the callback thresold.
@@ -79,23 +85,24 @@ func SetAnalogValueCallbackThreshold(id string, uid uint32, t *device.Threshold1
 		Uid:        uid,
 		Data:       t,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetAnalogValueCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetAnalogValueCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32, t *device.Threshold16) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAnalogValueCallbackThreshold("setanalogvaluecallbackthresholdfuture"+device.GenId(), uid, t,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetAnalogValueCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid, t) == nil
+}
+
+// SetAnalogValueCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAnalogValueCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, t *device.Threshold16) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAnalogValueCallbackThreshold("setanalogvaluecallbackthresholdfuture"+device.GenId(), uid, t, nil))
+	return err
 }
 
 // GetAnalogValueCallbackThreshold creates the subscriber to get the callback thresold.
@@ -106,29 +113,31 @@ func GetAnalogValueCallbackThreshold(id string, uid uint32, handler func(device.
 		Uid:        uid,
 		Result:     &device.Threshold16{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetAnalogValueCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAnalogValueCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Threshold16 {
-	future := make(chan *device.Threshold16)
-	defer close(future)
-	sub := GetAnalogValueCallbackThreshold("getanalogvaluecallbackthresholdfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Threshold16 = nil
-			if err == nil {
-				if value, ok := r.(*device.Threshold16); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAnalogValueCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAnalogValueCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAnalogValueCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Threshold16, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAnalogValueCallbackThreshold("getanalogvaluecallbackthresholdfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Threshold16); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // IlluminanceReached creates a subscriber for the theshold triggered voltage callback.
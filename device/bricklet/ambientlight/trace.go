@@ -0,0 +1,34 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package ambientlight
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Ambient Light Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_get_analog_value:                    {Name: "GetAnalogValue", Response: func() interface{} { return new(AnalogValue) }},
+		function_set_debounce_period:                 {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:                 {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_get_illuminance:                     {Name: "GetIlluminance", Response: func() interface{} { return new(Illuminance) }},
+		function_set_illuminance_callback_period:     {Name: "SetIlluminanceCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_illuminance_callback_period:     {Name: "GetIlluminanceCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		function_set_analog_value_callback_period:    {Name: "SetAnalogValueCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_analog_value_callback_period:    {Name: "GetAnalogValueCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		callback_illuminance:                         {Name: "IlluminancePeriod", Response: func() interface{} { return new(Illuminance) }},
+		callback_analog_value:                        {Name: "AnalogValuePeriod", Response: func() interface{} { return new(AnalogValue) }},
+		function_set_illuminance_callback_threshold:  {Name: "SetIlluminanceCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_illuminance_callback_threshold:  {Name: "GetIlluminanceCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		function_set_analog_value_callback_threshold: {Name: "SetAnalogValueCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_analog_value_callback_threshold: {Name: "GetAnalogValueCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		callback_illuminance_reached:                 {Name: "IlluminanceReached", Response: func() interface{} { return new(Illuminance) }},
+		callback_analog_value_reached:                {Name: "AnalogValueReached", Response: func() interface{} { return new(AnalogValue) }},
+	})
+}
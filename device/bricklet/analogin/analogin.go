@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Analog In Bricklet.
 package analogin
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Analog In Bricklet (see device/name).
+const DeviceIdentifier = uint16(219)
+
 const (
 	function_get_voltage                         = uint8(1)
 	function_set_range                           = uint8(17)
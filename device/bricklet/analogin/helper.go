@@ -0,0 +1,25 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package analogin
+
+// Name converts the range identifer value to a readable string.
+func (r *Range) Name() string {
+	switch r.Value {
+	case RangeAutomaticallySwitched:
+		return "Automatically switched"
+	case Range0V6_05V1_48mV:
+		return "0V - 6.05V,  1.48mV resolution"
+	case Range0V10_32V2_52mV: // String fullfill the stringer interface.
+		return "0V - 10.32V,  2.52mV resolution"
+	case Range0V36_30V8_86mv:
+		return "0V - 36.30V,  8.86mV resolution"
+	case Range0V45V11_25mv:
+		return "0V - 45.00V,  11.25mV resolution"
+	case Range0V3_3V0_81mV:
+		return "0V - 3.3V,  0.81mV resolution"
+	default:
+		return "Unknown"
+	}
+}
@@ -2,25 +2,37 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package analogin
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-SetRange creates a subscriber to set the measurement range.
-The default value is 0.
-  0: Automatically switched
-  1: 0V - 6.05V,  1.48mV resolution
-  2: 0V - 10.32V,  2.52mV resolution
-  3: 0V - 36.30V,  8.86mV resolution
-  4: 0V - 45.00V,  11.25mV resolution
-  5: 0V - 3.3V,  0.81mV resolution,
-*/
+// Constants for the range.
+const (
+	RangeAutomaticallySwitched = 0
+	Range0V6_05V1_48mV         = 1
+	Range0V10_32V2_52mV        = 2
+	Range0V36_30V8_86mv        = 3
+	Range0V45V11_25mv          = 4
+	Range0V3_3V0_81mV          = 5
+)
+
+// SetRange creates a subscriber to set the measurement range.
+// The default value is 0.
+//
+//	0: Automatically switched
+//	1: 0V - 6.05V,  1.48mV resolution
+//	2: 0V - 10.32V,  2.52mV resolution
+//	3: 0V - 36.30V,  8.86mV resolution
+//	4: 0V - 45.00V,  11.25mV resolution
+//	5: 0V - 3.3V,  0.81mV resolution,
 func SetRange(id string, uid uint32, r *Range, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetRange"),
@@ -33,18 +45,18 @@ func SetRange(id string, uid uint32, r *Range, handler func(device.Resulter, err
 
 // SetRangeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func SetRangeFuture(brick bricker.Bricker, connectorname string, uid uint32, r *Range) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetRange("setrangefuture"+device.GenId(), uid, r,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func SetRangeFuture(brick *bricker.Bricker, connectorname string, uid uint32, r *Range) bool {
+	return SetRangeFutureContext(context.Background(), brick, connectorname, uid, r) == nil
+}
+
+// SetRangeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetRangeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, r *Range) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetRange("setrangefuture"+device.GenId(), uid, r, nil))
+	return err
 }
 
 // GetRange creates a subscriber to get the measurement range value.
@@ -55,47 +67,39 @@ func GetRange(id string, uid uint32, handler func(device.Resulter, error)) *devi
 		Uid:        uid,
 		Result:     &Range{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetRangeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetRangeFuture(brick bricker.Bricker, connectorname string, uid uint32) *Range {
-	future := make(chan *Range)
-	defer close(future)
-	sub := GetRange("getrangefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Range = nil
-			if err == nil {
-				if value, ok := r.(*Range); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetRangeFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Range {
+	v, _ := GetRangeFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetRangeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetRangeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Range, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetRange("getrangefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Range); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-// Constants for the range.
-const (
-	RangeAutomaticallySwitched = 0
-	Range0V6_05V1_48mV         = 1
-	Range0V10_32V2_52mV        = 2
-	Range0V36_30V8_86mv        = 3
-	Range0V45V11_25mv          = 4
-	Range0V3_3V0_81mV          = 5
-)
-
 // Range result type
 type Range struct {
 	Value uint8 // range identifer
 }
 
-// FromPacket creates a Range from a packet.
+// FromPacket converts the packet payload to the Range type.
 func (r *Range) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(r, p); err != nil {
 		return err
@@ -103,26 +107,6 @@ func (r *Range) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(r)
 }
 
-// Name converts the range identifer value to a readable string.
-func (r *Range) Name() string {
-	switch r.Value {
-	case RangeAutomaticallySwitched:
-		return "Automatically switched"
-	case Range0V6_05V1_48mV:
-		return "0V - 6.05V,  1.48mV resolution"
-	case Range0V10_32V2_52mV: // String fullfill the stringer interface.
-		return "0V - 10.32V,  2.52mV resolution"
-	case Range0V36_30V8_86mv:
-		return "0V - 36.30V,  8.86mV resolution"
-	case Range0V45V11_25mv:
-		return "0V - 45.00V,  11.25mV resolution"
-	case Range0V3_3V0_81mV:
-		return "0V - 3.3V,  0.81mV resolution"
-	default:
-		return "Unknown"
-	}
-}
-
 // String fullfill the stringer interface.
 func (r *Range) String() string {
 	txt := "Range "
@@ -139,5 +123,7 @@ func (r *Range) Copy() device.Resulter {
 	if r == nil {
 		return nil
 	}
-	return &Range{Value: r.Value}
+	return &Range{
+		Value: r.Value,
+	}
 }
@@ -0,0 +1,38 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package analogin
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Analog In Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_get_analog_value:                    {Name: "GetAnalogValue", Response: func() interface{} { return new(AnalogValue) }},
+		function_set_averaging:                       {Name: "SetAveraging", Request: func() interface{} { return new(Average) }},
+		function_get_averaging:                       {Name: "GetAveraging", Response: func() interface{} { return new(Average) }},
+		function_set_debounce_period:                 {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:                 {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_set_voltage_callback_period:         {Name: "SetVoltageCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_voltage_callback_period:         {Name: "GetVoltageCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		function_set_analog_value_callback_period:    {Name: "SetAnalogValueCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_analog_value_callback_period:    {Name: "GetAnalogValueCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		callback_voltage:                             {Name: "VoltagePeriod", Response: func() interface{} { return new(Voltage) }},
+		callback_analog_value:                        {Name: "AnalogValuePeriod", Response: func() interface{} { return new(AnalogValue) }},
+		function_set_range:                           {Name: "SetRange", Request: func() interface{} { return new(Range) }},
+		function_get_range:                           {Name: "GetRange", Response: func() interface{} { return new(Range) }},
+		function_set_voltage_callback_threshold:      {Name: "SetVoltageCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_voltage_callback_threshold:      {Name: "GetVoltageCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		function_set_analog_value_callback_threshold: {Name: "SetAnalogValueCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_analog_value_callback_threshold: {Name: "GetAnalogValueCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		callback_voltage_reached:                     {Name: "VoltageReached", Response: func() interface{} { return new(Voltage) }},
+		callback_analog_value_reached:                {Name: "AnalogValueReached", Response: func() interface{} { return new(AnalogValue) }},
+		function_get_voltage:                         {Name: "GetVoltage", Response: func() interface{} { return new(Voltage) }},
+	})
+}
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package analogin
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -19,30 +22,31 @@ func GetVoltage(id string, uid uint32, handler func(device.Resulter, error)) *de
 		Uid:        uid,
 		Result:     &Voltage{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetVoltageFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetVoltageFuture(brick bricker.Bricker, connectorname string, uid uint32) *Voltage {
-	future := make(chan *Voltage)
-	defer close(future)
-	sub := GetVoltage("getvoltagefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Voltage = nil
-			if err == nil {
-				if value, ok := r.(*Voltage); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetVoltageFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Voltage {
+	v, _ := GetVoltageFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetVoltageFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetVoltageFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Voltage, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetVoltage("getvoltagefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	v := <-future
-	return v
+	if v, ok := res.(*Voltage); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Voltage result type
@@ -50,7 +54,7 @@ type Voltage struct {
 	Value uint16 // mV
 }
 
-// FromPacket creates from a packet a Voltage.
+// FromPacket converts the packet payload to the Voltage type.
 func (v *Voltage) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(v, p); err != nil {
 		return err
@@ -74,5 +78,7 @@ func (v *Voltage) Copy() device.Resulter {
 	if v == nil {
 		return nil
 	}
-	return &Voltage{Value: v.Value}
+	return &Voltage{
+		Value: v.Value,
+	}
 }
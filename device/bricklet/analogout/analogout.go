@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Analog Out Bricklet.
 package analogout
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Analog Out Bricklet (see device/name).
+const DeviceIdentifier = uint16(220)
+
 const (
 	function_set_voltage = uint8(1)
 	function_get_voltage = uint8(2)
@@ -0,0 +1,21 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package analogout
+
+// Name converts the mode identifer value to a readable string.
+func (m *Mode) Name() string {
+	switch m.Value {
+	case ModeNormal:
+		return "Normal Mode"
+	case Mode1kResistorGround:
+		return "1k Ohm resistor to ground"
+	case Mode100kResistorGround:
+		return "100k Ohm resistor to ground"
+	case Mode500kResistorGround:
+		return "500k Ohm resistor to ground"
+	default:
+		return "Unknown"
+	}
+}
@@ -2,24 +2,33 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package analogout
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-SetMode creates a subscriber to set the modes of the analog value.
-The default value is 0.
+// Constants for the modes.
+const (
+	ModeNormal             = 0
+	Mode1kResistorGround   = 1
+	Mode100kResistorGround = 2
+	Mode500kResistorGround = 3
+)
 
-  0: Normal Mode (Analog value as set by set_voltage is applied)
-  1: 1k Ohm resistor to ground
-  2: 100k Ohm resistor to ground
-  3: 500k Ohm resistor to ground
-*/
+// SetMode creates a subscriber to set the modes of the analog value.
+// The default value is 0.
+//
+//	0: Normal Mode (Analog value as set by set_voltage is applied)
+//	1: 1k Ohm resistor to ground
+//	2: 100k Ohm resistor to ground
+//	3: 500k Ohm resistor to ground
 func SetMode(id string, uid uint32, m *Mode, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetMode"),
@@ -32,18 +41,18 @@ func SetMode(id string, uid uint32, m *Mode, handler func(device.Resulter, error
 
 // SetModeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func SetModeFuture(brick bricker.Bricker, connectorname string, uid uint32, m *Mode) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetMode("setmodefuture"+device.GenId(), uid, m,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func SetModeFuture(brick *bricker.Bricker, connectorname string, uid uint32, m *Mode) bool {
+	return SetModeFutureContext(context.Background(), brick, connectorname, uid, m) == nil
+}
+
+// SetModeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetModeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, m *Mode) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetMode("setmodefuture"+device.GenId(), uid, m, nil))
+	return err
 }
 
 // GetMode creates a subscriber to get the measurement mode value.
@@ -54,45 +63,39 @@ func GetMode(id string, uid uint32, handler func(device.Resulter, error)) *devic
 		Uid:        uid,
 		Result:     &Mode{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetModeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetModeFuture(brick bricker.Bricker, connectorname string, uid uint32) *Mode {
-	future := make(chan *Mode)
-	defer close(future)
-	sub := GetMode("getmodefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Mode = nil
-			if err == nil {
-				if value, ok := r.(*Mode); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetModeFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Mode {
+	v, _ := GetModeFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetModeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetModeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Mode, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMode("getmodefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Mode); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-// Constants for the modes.
-const (
-	ModeNormal             = 0
-	Mode1kResistorGround   = 1
-	Mode100kResistorGround = 2
-	Mode500kResistorGround = 3
-)
-
 // Mode result type
 type Mode struct {
 	Value uint8 // range identifer
 }
 
-// FromPacket creates a Range from a packet.
+// FromPacket converts the packet payload to the Mode type.
 func (m *Mode) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -100,22 +103,6 @@ func (m *Mode) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(m)
 }
 
-// Name converts the mode identifer value to a readable string.
-func (m *Mode) Name() string {
-	switch m.Value {
-	case ModeNormal:
-		return "Normal Mode"
-	case Mode1kResistorGround:
-		return "1k Ohm resistor to ground"
-	case Mode100kResistorGround:
-		return "100k Ohm resistor to ground"
-	case Mode500kResistorGround:
-		return "500k Ohm resistor to ground"
-	default:
-		return "Unknown"
-	}
-}
-
 // String fullfill the stringer interface.
 func (m *Mode) String() string {
 	txt := "Mode "
@@ -132,5 +119,7 @@ func (m *Mode) Copy() device.Resulter {
 	if m == nil {
 		return nil
 	}
-	return &Mode{Value: m.Value}
+	return &Mode{
+		Value: m.Value,
+	}
 }
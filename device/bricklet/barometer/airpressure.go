@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -19,41 +22,41 @@ func GetAirPressure(id string, uid uint32, handler func(device.Resulter, error))
 		Uid:        uid,
 		Result:     &AirPressure{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetAirPressureFuture is a future pattern version for a synchronized all of the subscriber.
+// GetAirPressureFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAirPressureFuture(brick *bricker.Bricker, connectorname string, uid uint32) *AirPressure {
-	future := make(chan *AirPressure)
-	defer close(future)
-	sub := GetAirPressure("getairpressurefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *AirPressure = nil
-			if err == nil {
-				if value, ok := r.(*AirPressure); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAirPressureFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAirPressureFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAirPressureFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*AirPressure, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAirPressure("getairpressurefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*AirPressure); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-AirPressure is the type for the air pressure value.
-
-The value has a range of 10000 to 1200000 and is given in mbar/1000
-*/
+// AirPressure is the type for the air pressure value.
+//
+// The value has a range of 10000 to 1200000 and is given in mbar/1000
 type AirPressure struct {
 	Value int32
 }
 
-// FromPacket converts from packet into AirPressure.
+// FromPacket converts the packet payload to the AirPressure type.
 func (a *AirPressure) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(a, p); err != nil {
 		return err
@@ -61,13 +64,13 @@ func (a *AirPressure) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(a)
 }
 
-// fullfill the stringer interface.
+// String fullfill the stringer interface.
 func (a *AirPressure) String() string {
 	txt := "Air Pressure "
 	if a == nil {
-		txt += "[]"
+		txt += "[nil]"
 	} else {
-		fmt.Sprintf("[Value: %d, Air Pressure: %7.3f mbar]", a.Value, a.Float64())
+		txt += fmt.Sprintf("[Value: %d, Air Pressure: %7.3f mbar]", a.Value, a.Float64())
 	}
 	return txt
 }
@@ -77,17 +80,7 @@ func (a *AirPressure) Copy() device.Resulter {
 	if a == nil {
 		return nil
 	}
-	return &AirPressure{Value: a.Value}
-}
-
-// Float64 converts the int32 value (mbar/1000) into float64 (mbar)
-func (a *AirPressure) Float64() float64 {
-	f := float64(a.Value) / 1000.0
-	return f
-}
-
-// Float32 converts the int32 value (mbar/1000) into float32 (mbar)
-func (a *AirPressure) Float32() float32 {
-	f := float32(a.Value) / 1000.0
-	return f
+	return &AirPressure{
+		Value: a.Value,
+	}
 }
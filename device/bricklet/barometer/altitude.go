@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -19,43 +22,43 @@ func GetAltitude(id string, uid uint32, handler func(device.Resulter, error)) *d
 		Uid:        uid,
 		Result:     &Altitude{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetAltitudeFuture is a future pattern version for a synchronized all of the subscriber.
+// GetAltitudeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAltitudeFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Altitude {
-	future := make(chan *Altitude)
-	defer close(future)
-	sub := GetAltitude("getaltitudefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Altitude = nil
-			if err == nil {
-				if value, ok := r.(*Altitude); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAltitudeFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAltitudeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAltitudeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Altitude, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAltitude("getaltitudefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Altitude); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-Altitude is a type for the altitude value.
-
-The value is given in cm and is calculated based on the difference
-between the current air pressure and the reference air pressure
-that can be set with SetReferenceAirPressure.
-*/
+// Altitude is a type for the altitude value.
+//
+// The value is given in cm and is calculated based on the difference
+// between the current air pressure and the reference air pressure
+// that can be set with SetReferenceAirPressure.
 type Altitude struct {
 	Value int32
 }
 
-// FromPacket converts from packet into Altitude.
+// FromPacket converts the packet payload to the Altitude type.
 func (a *Altitude) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(a, p); err != nil {
 		return err
@@ -69,7 +72,7 @@ func (a *Altitude) String() string {
 	if a == nil {
 		txt += "[nil]"
 	} else {
-		fmt.Sprintf("[Value: %d cm]", a.Value)
+		txt += fmt.Sprintf("[Value: %d cm]", a.Value)
 	}
 	return txt
 }
@@ -79,5 +82,7 @@ func (a *Altitude) Copy() device.Resulter {
 	if a == nil {
 		return nil
 	}
-	return &Altitude{Value: a.Value}
+	return &Altitude{
+		Value: a.Value,
+	}
 }
@@ -2,27 +2,28 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-SetAveraging creates a subscriber to set the different averaging parameters.
-There is no moving average for the temperature.
-
-The maximum length for the pressure average is 10,
-for the temperature average is 255 and for the moving average is 25.
-The default values are 10 for the normal averages and 25 for the moving average.
-
-Setting the all three parameters to 0 will turn the averaging completely off.
-
-This brings the data without delay but with much more noise.
-*/
+// SetAveraging creates a subscriber to set the different averaging parameters.
+// There is no moving average for the temperature.
+//
+// The maximum length for the pressure average is 10,
+// for the temperature average is 255 and for the moving average is 25.
+// The default values are 10 for the normal averages and 25 for the moving average.
+//
+// Setting the all three parameters to 0 will turn the averaging completely off.
+//
+// This brings the data without delay but with much more noise.
 func SetAveraging(id string, uid uint32, a *Average, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetAveraging"),
@@ -35,18 +36,18 @@ func SetAveraging(id string, uid uint32, a *Average, handler func(device.Resulte
 
 // SetAveragingFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func SetAveragingFuture(brick bricker.Bricker, connectorname string, uid uint32, a *Average) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAveraging("setaveragingfuture"+device.GenId(), uid, a,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func SetAveragingFuture(brick *bricker.Bricker, connectorname string, uid uint32, a *Average) bool {
+	return SetAveragingFutureContext(context.Background(), brick, connectorname, uid, a) == nil
+}
+
+// SetAveragingFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAveragingFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, a *Average) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAveraging("setaveragingfuture"+device.GenId(), uid, a, nil))
+	return err
 }
 
 // GetAveraging creates a subscriber to get the different averaging values.
@@ -57,29 +58,31 @@ func GetAveraging(id string, uid uint32, handler func(device.Resulter, error)) *
 		Uid:        uid,
 		Result:     &Average{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetAveragingFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetAveragingFuture(brick bricker.Bricker, connectorname string, uid uint32) *Average {
-	future := make(chan *Average)
-	defer close(future)
-	sub := GetAveraging("getaveragingfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Average = nil
-			if err == nil {
-				if value, ok := r.(*Average); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetAveragingFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Average {
+	v, _ := GetAveragingFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAveragingFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAveragingFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Average, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAveraging("getaveragingfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Average); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Average is the type for the length of a averaging for the voltage value.
@@ -89,7 +92,7 @@ type Average struct {
 	Temperature    uint8
 }
 
-// FromPacket creates from a packet a Average.
+// FromPacket converts the packet payload to the Average type.
 func (a *Average) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(a, p); err != nil {
 		return err
@@ -103,8 +106,7 @@ func (a *Average) String() string {
 	if a == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Moving Pressure: %d, Pressure: %d, Temperature: %d]",
-			a.MovingPressure, a.Pressure, a.Temperature)
+		txt += fmt.Sprintf("[Moving Pressure: %d, Pressure: %d, Temperature: %d]", a.MovingPressure, a.Pressure, a.Temperature)
 	}
 	return txt
 }
@@ -117,5 +119,6 @@ func (a *Average) Copy() device.Resulter {
 	return &Average{
 		MovingPressure: a.MovingPressure,
 		Pressure:       a.Pressure,
-		Temperature:    a.Temperature}
+		Temperature:    a.Temperature,
+	}
 }
@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Barometer Bricklet.
 package barometer
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Barometer Bricklet (see device/name).
+const DeviceIdentifier = uint16(221)
+
 const (
 	function_get_air_pressure                    = uint8(1)
 	function_get_altitude                        = uint8(2)
@@ -0,0 +1,29 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barometer
+
+// Float64 converts the int32 value (mbar/1000) into float64 (mbar)
+func (a *AirPressure) Float64() float64 {
+	f := float64(a.Value) / 1000.0
+	return f
+}
+
+// Float32 converts the int32 value (mbar/1000) into float32 (mbar)
+func (a *AirPressure) Float32() float32 {
+	f := float32(a.Value) / 1000.0
+	return f
+}
+
+// Float64 converts the temperature value to a float.
+func (t *Temperature) Float64() float64 {
+	f := float64(t.Value) / 100.00
+	return f
+}
+
+// Float32 converts the temperature value to a float.
+func (t *Temperature) Float32() float32 {
+	f := float32(t.Value) / 100.00
+	return f
+}
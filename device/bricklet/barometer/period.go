@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -19,23 +22,25 @@ func SetAirPressureCallbackPeriod(id string, uid uint32, pe *device.Period, hand
 		Uid:        uid,
 		Data:       pe,
 		Handler:    handler,
+		Restore:    true,
+		Disable:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetAirPressureCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetAirPressureCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32, pe *device.Period) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAirPressureCallbackPeriod("setairpressurecallbackperiodfuture"+device.GenId(), uid, pe,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetAirPressureCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid, pe) == nil
+}
+
+// SetAirPressureCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAirPressureCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pe *device.Period) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAirPressureCallbackPeriod("setairpressurecallbackperiodfuture"+device.GenId(), uid, pe, nil))
+	return err
 }
 
 // GetAirPressureCallbackPeriod creates a subsctiber to get the callback period value.
@@ -46,29 +51,31 @@ func GetAirPressureCallbackPeriod(id string, uid uint32, handler func(device.Res
 		Uid:        uid,
 		Result:     &device.Period{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetAirPressureCallbackPeriodFuture is a future pattern version for a synchronized call of the subsctiber.
+// GetAirPressureCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAirPressureCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Period {
-	future := make(chan *device.Period)
-	defer close(future)
-	sub := GetAirPressureCallbackPeriod("getairpressurecallbackperiod"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Period = nil
-			if err == nil {
-				if value, ok := r.(*device.Period); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAirPressureCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAirPressureCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAirPressureCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Period, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAirPressureCallbackPeriod("getairpressurecallbackperiodfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Period); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetAltitudeCallbackPeriod creates the subscriber to set the callback period.
@@ -81,23 +88,25 @@ func SetAltitudeCallbackPeriod(id string, uid uint32, pe *device.Period, handler
 		Uid:        uid,
 		Data:       pe,
 		Handler:    handler,
+		Restore:    true,
+		Disable:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetAltitudeCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetAltitudeCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32, pe *device.Period) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAltitudeCallbackPeriod("setaltitudecallbackperiodfuture"+device.GenId(), uid, pe,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetAltitudeCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid, pe) == nil
+}
+
+// SetAltitudeCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAltitudeCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pe *device.Period) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAltitudeCallbackPeriod("setaltitudecallbackperiodfuture"+device.GenId(), uid, pe, nil))
+	return err
 }
 
 // GetAltitudeCallbackPeriod creates a subscriber to get the callback period value.
@@ -108,29 +117,31 @@ func GetAltitudeCallbackPeriod(id string, uid uint32, handler func(device.Result
 		Uid:        uid,
 		Result:     &device.Period{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetAltitudeCallbackPeriodFuture is a future pattern version for a synchronized call of the subsctiber.
+// GetAltitudeCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAltitudeCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Period {
-	future := make(chan *device.Period)
-	defer close(future)
-	sub := GetAltitudeCallbackPeriod("getaltitudecallbackperiodfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Period = nil
-			if err == nil {
-				if value, ok := r.(*device.Period); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAltitudeCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAltitudeCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAltitudeCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Period, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAltitudeCallbackPeriod("getaltitudecallbackperiodfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Period); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // AirPressurePeriod creates a subscriber for the periodical air pressure callback.
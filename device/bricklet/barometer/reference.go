@@ -2,25 +2,26 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
 
-/*
-SetReferenceAirPressure creates the subscriber to set the reference air pressure.
-Setting the reference to the current air pressure results in a calculated altitude of 0cm.
-Passing 0 is a shortcut for passing the current air pressure as reference.
-
-Well known reference values are the Q codes QNH and QFE used in aviation.
-
-The default value is 1013.25mbar.
-*/
+// SetReferenceAirPressure creates the subscriber to set the reference air pressure.
+// Setting the reference to the current air pressure results in a calculated altitude of 0cm.
+// Passing 0 is a shortcut for passing the current air pressure as reference.
+//
+// Well known reference values are the Q codes QNH and QFE used in aviation.
+//
+// The default value is 1013.25mbar.
 func SetReferenceAirPressure(id string, uid uint32, a *AirPressure, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
-		Id:         device.FallbackId(id, ""),
+		Id:         device.FallbackId(id, "SetReferenceAirPressure"),
 		Fid:        function_set_reference_air_pressure,
 		Uid:        uid,
 		Data:       a,
@@ -31,17 +32,17 @@ func SetReferenceAirPressure(id string, uid uint32, a *AirPressure, handler func
 // SetReferenceAirPressureFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetReferenceAirPressureFuture(brick *bricker.Bricker, connectorname string, uid uint32, a *AirPressure) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetReferenceAirPressure("setreferenceairpressurefuture"+device.GenId(), uid, a,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetReferenceAirPressureFutureContext(context.Background(), brick, connectorname, uid, a) == nil
+}
+
+// SetReferenceAirPressureFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetReferenceAirPressureFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, a *AirPressure) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetReferenceAirPressure("setreferenceairpressurefuture"+device.GenId(), uid, a, nil))
+	return err
 }
 
 // GetReferenceAirPressure creates the subscriber to get reference air pressure.
@@ -52,27 +53,29 @@ func GetReferenceAirPressure(id string, uid uint32, handler func(device.Resulter
 		Uid:        uid,
 		Result:     &AirPressure{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetReferenceAirPressureFuture is a future pattern version for a synchronized all of the subscriber.
+// GetReferenceAirPressureFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetReferenceAirPressureFuture(brick *bricker.Bricker, connectorname string, uid uint32) *AirPressure {
-	future := make(chan *AirPressure)
-	defer close(future)
-	sub := GetReferenceAirPressure("getreferenceairpressure"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *AirPressure = nil
-			if err == nil {
-				if value, ok := r.(*AirPressure); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetReferenceAirPressureFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetReferenceAirPressureFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetReferenceAirPressureFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*AirPressure, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetReferenceAirPressure("getreferenceairpressurefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*AirPressure); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -19,29 +22,31 @@ func GetChipTemperature(id string, uid uint32, handler func(device.Resulter, err
 		Uid:        uid,
 		Result:     &Temperature{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetAveragingFuture is a future pattern version for a synchronized call of the subscriber.
+// GetChipTemperatureFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetChipTemperatureFuture(brick bricker.Bricker, connectorname string, uid uint32) *Temperature {
-	future := make(chan *Temperature)
-	defer close(future)
-	sub := GetChipTemperature("getchiptemperaturefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Temperature = nil
-			if err == nil {
-				if value, ok := r.(*Temperature); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetChipTemperatureFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Temperature {
+	v, _ := GetChipTemperatureFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetChipTemperatureFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetChipTemperatureFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Temperature, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetChipTemperature("getchiptemperaturefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Temperature); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Temperature type with a value 100/°C in a range between -4000 to 8500.
@@ -49,7 +54,7 @@ type Temperature struct {
 	Value int16
 }
 
-// FromPacket creates from a packet a Temperature.
+// FromPacket converts the packet payload to the Temperature type.
 func (t *Temperature) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(t, p); err != nil {
 		return err
@@ -63,7 +68,7 @@ func (t *Temperature) String() string {
 	if t == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Value: %d, Temperature: &02.02f °C]", t.Value, t.Float64())
+		txt += fmt.Sprintf("[Value: %d, Temperature: %5.2f °C]", t.Value, t.Float64())
 	}
 	return txt
 }
@@ -73,17 +78,7 @@ func (t *Temperature) Copy() device.Resulter {
 	if t == nil {
 		return nil
 	}
-	return &Temperature{Value: t.Value}
-}
-
-// Float64 converts the temperature value to a float.
-func (t *Temperature) Float64() float64 {
-	f := float64(t.Value) / 100.00
-	return f
-}
-
-// Float32 converts the temperature value to a float.
-func (t *Temperature) Float32() float32 {
-	f := float32(t.Value) / 100.00
-	return f
+	return &Temperature{
+		Value: t.Value,
+	}
 }
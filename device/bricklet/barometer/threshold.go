@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package barometer
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -18,23 +21,24 @@ func SetAirPressureCallbackThreshold(id string, uid uint32, t *device.Threshold3
 		Uid:        uid,
 		Data:       t,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetAirPressureCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetAirPressureCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32, t *device.Threshold32) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAirPressureCallbackThreshold("setairpressurecallbackthresholdfuture"+device.GenId(), uid, t,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetAirPressureCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid, t) == nil
+}
+
+// SetAirPressureCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAirPressureCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, t *device.Threshold32) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAirPressureCallbackThreshold("setairpressurecallbackthresholdfuture"+device.GenId(), uid, t, nil))
+	return err
 }
 
 // GetAirPressureCallbackThreshold creates the subscriber to get the callback threshold.
@@ -45,29 +49,31 @@ func GetAirPressureCallbackThreshold(id string, uid uint32, handler func(device.
 		Uid:        uid,
 		Result:     &device.Threshold32{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetAirPressureCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAirPressureCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Threshold32 {
-	future := make(chan *device.Threshold32)
-	defer close(future)
-	sub := GetAirPressureCallbackThreshold("getairpressurecallbackthresholdfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Threshold32 = nil
-			if err == nil {
-				if value, ok := r.(*device.Threshold32); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAirPressureCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAirPressureCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAirPressureCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Threshold32, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAirPressureCallbackThreshold("getairpressurecallbackthresholdfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Threshold32); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetAltitudeCallbackThreshold creates the subscriber to set the callback threshold.
@@ -79,23 +85,24 @@ func SetAltitudeCallbackThreshold(id string, uid uint32, t *device.Threshold32,
 		Uid:        uid,
 		Data:       t,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetAltitudeCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetAltitudeCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32, t *device.Threshold32) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetAltitudeCallbackThreshold("setaltitudecallbackthresholdfuture"+device.GenId(), uid, t,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetAltitudeCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid, t) == nil
+}
+
+// SetAltitudeCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetAltitudeCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, t *device.Threshold32) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetAltitudeCallbackThreshold("setaltitudecallbackthresholdfuture"+device.GenId(), uid, t, nil))
+	return err
 }
 
 // GetAltitudeCallbackThreshold creates the subscriber to get the callback threshold.
@@ -106,29 +113,31 @@ func GetAltitudeCallbackThreshold(id string, uid uint32, handler func(device.Res
 		Uid:        uid,
 		Result:     &device.Threshold32{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetAltitudeCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetAltitudeCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Threshold32 {
-	future := make(chan *device.Threshold32)
-	defer close(future)
-	sub := GetAltitudeCallbackThreshold("getaltitudecallbackthresholdfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Threshold32 = nil
-			if err == nil {
-				if value, ok := r.(*device.Threshold32); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetAltitudeCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetAltitudeCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetAltitudeCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Threshold32, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetAltitudeCallbackThreshold("getaltitudecallbackthresholdfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Threshold32); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // AirPressureReached creates a subscriber for the theshold triggered air pressure callback.
@@ -0,0 +1,39 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package barometer
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Barometer Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_get_air_pressure:                    {Name: "GetAirPressure", Response: func() interface{} { return new(AirPressure) }},
+		function_get_altitude:                        {Name: "GetAltitude", Response: func() interface{} { return new(Altitude) }},
+		function_set_averaging:                       {Name: "SetAveraging", Request: func() interface{} { return new(Average) }},
+		function_get_averaging:                       {Name: "GetAveraging", Response: func() interface{} { return new(Average) }},
+		function_set_debounce_period:                 {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:                 {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_set_air_pressure_callback_period:    {Name: "SetAirPressureCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_air_pressure_callback_period:    {Name: "GetAirPressureCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		function_set_altitude_callback_period:        {Name: "SetAltitudeCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_altitude_callback_period:        {Name: "GetAltitudeCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		callback_air_pressure:                        {Name: "AirPressurePeriod", Response: func() interface{} { return new(AirPressure) }},
+		callback_altitude:                            {Name: "AltitudePeriod", Response: func() interface{} { return new(Altitude) }},
+		function_set_reference_air_pressure:          {Name: "SetReferenceAirPressure", Request: func() interface{} { return new(AirPressure) }},
+		function_get_reference_air_pressure:          {Name: "GetReferenceAirPressure", Response: func() interface{} { return new(AirPressure) }},
+		function_get_chip_temperature:                {Name: "GetChipTemperature", Response: func() interface{} { return new(Temperature) }},
+		function_set_air_pressure_callback_threshold: {Name: "SetAirPressureCallbackThreshold", Request: func() interface{} { return new(device.Threshold32) }},
+		function_get_air_pressure_callback_threshold: {Name: "GetAirPressureCallbackThreshold", Response: func() interface{} { return new(device.Threshold32) }},
+		function_set_altitude_callback_threshold:     {Name: "SetAltitudeCallbackThreshold", Request: func() interface{} { return new(device.Threshold32) }},
+		function_get_altitude_callback_threshold:     {Name: "GetAltitudeCallbackThreshold", Response: func() interface{} { return new(device.Threshold32) }},
+		callback_air_pressure_reached:                {Name: "AirPressureReached", Response: func() interface{} { return new(AirPressure) }},
+		callback_altitude_reached:                    {Name: "AltitudeReached", Response: func() interface{} { return new(Altitude) }},
+	})
+}
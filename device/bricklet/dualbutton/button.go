@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package dualbutton
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -19,43 +22,43 @@ func GetButtonState(id string, uid uint32, handler func(device.Resulter, error))
 		Uid:        uid,
 		Result:     &ButtonState{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetButtonStateFuture is a future pattern version for a synchronized all of the subscriber.
+// GetButtonStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetButtonStateFuture(brick *bricker.Bricker, connectorname string, uid uint32) *ButtonState {
-	future := make(chan *ButtonState)
-	defer close(future)
-	sub := GetButtonState("getbuttonstatefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *ButtonState = nil
-			if err == nil {
-				if value, ok := r.(*ButtonState); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetButtonStateFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetButtonStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetButtonStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*ButtonState, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetButtonState("getbuttonstatefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*ButtonState); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-ButtonState is the type for the state of the buttons.
-
-    0 - button pressed
-    1 - button released
-*/
+// ButtonState is the type for the state of the buttons.
+//
+//	0 - button pressed
+//	1 - button released
 type ButtonState struct {
 	ButtonLeft  uint8 // button left
 	ButtonRight uint8 // button right
 }
 
-// FromPacket creates a ButtonState from a packet.
+// FromPacket converts the packet payload to the ButtonState type.
 func (bs *ButtonState) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(bs, p); err != nil {
 		return err
@@ -69,9 +72,7 @@ func (bs *ButtonState) String() string {
 	if bs == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Button left: %s (%d), Button right: %s (%d)]",
-			ButtonStateName(bs.ButtonLeft), bs.ButtonLeft,
-			ButtonStateName(bs.ButtonRight), bs.ButtonRight)
+		txt += fmt.Sprintf("[Button left: %s (%d), Button right: %s (%d)]", ButtonStateName(bs.ButtonLeft), bs.ButtonLeft, ButtonStateName(bs.ButtonRight), bs.ButtonRight)
 	}
 	return txt
 }
@@ -83,5 +84,6 @@ func (bs *ButtonState) Copy() device.Resulter {
 	}
 	return &ButtonState{
 		ButtonLeft:  bs.ButtonLeft,
-		ButtonRight: bs.ButtonRight}
+		ButtonRight: bs.ButtonRight,
+	}
 }
@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Dual Button Bricklet.
 package dualbutton
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Dual Button Bricklet (see device/name).
+const DeviceIdentifier = uint16(230)
+
 const (
 	function_set_led_state          = uint8(1)
 	function_get_led_state          = uint8(2)
@@ -20,31 +27,3 @@ const (
 	ButtonStatePressed  = uint8(0) // Button pressed.
 	ButtonStateReleased = uint8(1) // Button released.
 )
-
-// LedStateName results a string representation of the given led state.
-func LedStateName(s uint8) string {
-	switch s {
-	case LedStateAutoToggleOn:
-		return "Auto toggle enabled and LED on"
-	case LedStateAutoToggleOff:
-		return "Auto toggle enablde and LED off"
-	case LedStateOn:
-		return "LED on (auto toggle is disabled)"
-	case LedStateOff:
-		return "LED off (auto toggle is disabled)"
-	default:
-		return "Unknown"
-	}
-}
-
-// ButtonStateName give back the corresponding string representation of the given button state.
-func ButtonStateName(s uint8) string {
-	switch s {
-	case ButtonStatePressed:
-		return "Button Pressed"
-	case ButtonStateReleased:
-		return "Button Released"
-	default:
-		return "Unknown"
-	}
-}
@@ -0,0 +1,56 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package dualbutton
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/device"
+)
+
+// Bricklet is the handle for a Dual Button Bricklet.
+// Every method is a synchronized call (see the FutureContext functions) or a callback subscription.
+// The uid is the destination of every call, the bricker resolves the connector (or the member
+// of a connector group) for every subscription (see bricker.ConnectorFor).
+type Bricklet struct {
+	brick  *bricker.Bricker
+	Uid    uint32
+	Buffer int // buffer size of the callback channels
+}
+
+// New creates the handle for the bricklet with the given uid.
+func New(brick *bricker.Bricker, uid uint32) *Bricklet {
+	return &Bricklet{brick: brick, Uid: uid}
+}
+
+// GetButtonState is the handle version of GetButtonStateFutureContext.
+func (bl *Bricklet) GetButtonState(ctx context.Context) (*ButtonState, error) {
+	return GetButtonStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// SetLedState is the handle version of SetLedStateFutureContext.
+func (bl *Bricklet) SetLedState(ctx context.Context, ls *LedState) error {
+	return SetLedStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, ls)
+}
+
+// GetLedState is the handle version of GetLedStateFutureContext.
+func (bl *Bricklet) GetLedState(ctx context.Context) (*LedState, error) {
+	return GetLedStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// SetSelectedLedState is the handle version of SetSelectedLedStateFutureContext.
+func (bl *Bricklet) SetSelectedLedState(ctx context.Context, sls *SelectedLedState) error {
+	return SetSelectedLedStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, sls)
+}
+
+// StateChanged subscribes the StateChanged callback, until the context is done.
+// The results come in the returned channel (with a buffer of Buffer results),
+// the channel is closed after the context is done or the connector goes away.
+func (bl *Bricklet) StateChanged(ctx context.Context) (<-chan *States, error) {
+	return device.Channel[*States](ctx, bl.brick, bl.Uid,
+		StateChanged("statechanged"+device.GenId(), bl.Uid, nil), bl.Buffer)
+}
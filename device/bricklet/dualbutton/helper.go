@@ -0,0 +1,33 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dualbutton
+
+// LedStateName results a string representation of the given led state.
+func LedStateName(s uint8) string {
+	switch s {
+	case LedStateAutoToggleOn:
+		return "Auto toggle enabled and LED on"
+	case LedStateAutoToggleOff:
+		return "Auto toggle enablde and LED off"
+	case LedStateOn:
+		return "LED on (auto toggle is disabled)"
+	case LedStateOff:
+		return "LED off (auto toggle is disabled)"
+	default:
+		return "Unknown"
+	}
+}
+
+// ButtonStateName give back the corresponding string representation of the given button state.
+func ButtonStateName(s uint8) string {
+	switch s {
+	case ButtonStatePressed:
+		return "Button Pressed"
+	case ButtonStateReleased:
+		return "Button Released"
+	default:
+		return "Unknown"
+	}
+}
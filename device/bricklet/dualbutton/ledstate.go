@@ -2,20 +2,21 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package dualbutton
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-SetLedState creates a subscriber to set the led states.
-With auto toggle the led is switched on and off (toggle) by pressing the button.
-For setting only one led state use SetSelectedLedState.
-*/
+// SetLedState creates a subscriber to set the led states.
+// With auto toggle the led is switched on and off (toggle) by pressing the button.
+// For setting only one led state use SetSelectedLedState.
 func SetLedState(id string, uid uint32, ls *LedState, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetLedState"),
@@ -29,17 +30,17 @@ func SetLedState(id string, uid uint32, ls *LedState, handler func(device.Result
 // SetLedStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetLedStateFuture(brick *bricker.Bricker, connectorname string, uid uint32, ls *LedState) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetLedState("setledstatefuture"+device.GenId(), uid, ls,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetLedStateFutureContext(context.Background(), brick, connectorname, uid, ls) == nil
+}
+
+// SetLedStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetLedStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, ls *LedState) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetLedState("setledstatefuture"+device.GenId(), uid, ls, nil))
+	return err
 }
 
 // GetLedState creates the subscriber to get the led states.
@@ -50,29 +51,31 @@ func GetLedState(id string, uid uint32, handler func(device.Resulter, error)) *d
 		Uid:        uid,
 		Result:     &LedState{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetLedStateFuture is a future pattern version for a synchronized all of the subscriber.
+// GetLedStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetLedStateFuture(brick *bricker.Bricker, connectorname string, uid uint32) *LedState {
-	future := make(chan *LedState)
-	defer close(future)
-	sub := GetLedState("getledstatefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *LedState = nil
-			if err == nil {
-				if value, ok := r.(*LedState); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetLedStateFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetLedStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetLedStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*LedState, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetLedState("getledstatefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*LedState); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetSelectedLedState creates a subscriber for setting a selected led state.
@@ -90,24 +93,24 @@ func SetSelectedLedState(id string, uid uint32, sls *SelectedLedState, handler f
 // SetSelectedLedStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetSelectedLedStateFuture(brick *bricker.Bricker, connectorname string, uid uint32, sls *SelectedLedState) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetSelectedLedState("setselectedledstatefuture"+device.GenId(), uid, sls,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetSelectedLedStateFutureContext(context.Background(), brick, connectorname, uid, sls) == nil
+}
+
+// SetSelectedLedStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetSelectedLedStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, sls *SelectedLedState) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetSelectedLedState("setselectedledstatefuture"+device.GenId(), uid, sls, nil))
+	return err
 }
 
 // StateChanged creates a subscriber for the state changed callback.
 // This callback is triggered whenever a button is pressed.
 func StateChanged(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
-		Id:         device.FallbackId(id, "InterruptTrigger"),
+		Id:         device.FallbackId(id, "StateChanged"),
 		Fid:        callback_state_changed,
 		Uid:        uid,
 		Result:     &States{},
@@ -116,20 +119,18 @@ func StateChanged(id string, uid uint32, handler func(device.Resulter, error)) *
 		WithPacket: false}.CreateDevice()
 }
 
-/*
-Led states for the left and the right led.
-
-    0 - activate auto toggle
-    1 - deactivate auto toggle
-    2 - led on (auto toggle disabled)
-    3 - led off (auto toggle disabled)
-*/
+// Led states for the left and the right led.
+//
+//	0 - activate auto toggle
+//	1 - deactivate auto toggle
+//	2 - led on (auto toggle disabled)
+//	3 - led off (auto toggle disabled)
 type LedState struct {
 	LedLeft  uint8 // led state for the left led
 	LedRight uint8 // led state for the right led
 }
 
-// Converts a packet to a LedState type.
+// FromPacket converts the packet payload to the LedState type.
 func (ls *LedState) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(ls, p); err != nil {
 		return err
@@ -143,9 +144,7 @@ func (ls *LedState) String() string {
 	if ls == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Led left: %s (%d), Led right: %s (%d)]",
-			LedStateName(ls.LedLeft), ls.LedLeft,
-			LedStateName(ls.LedRight), ls.LedRight)
+		txt += fmt.Sprintf("[Led left: %s (%d), Led right: %s (%d)]", LedStateName(ls.LedLeft), ls.LedLeft, LedStateName(ls.LedRight), ls.LedRight)
 	}
 	return txt
 }
@@ -157,7 +156,8 @@ func (ls *LedState) Copy() device.Resulter {
 	}
 	return &LedState{
 		LedLeft:  ls.LedLeft,
-		LedRight: ls.LedRight}
+		LedRight: ls.LedRight,
+	}
 }
 
 // SelectedLedState is a type for select a led and set the state.
@@ -174,7 +174,7 @@ type States struct {
 	LedRight    uint8 // state from led right
 }
 
-// Converts a packet to a States type.
+// FromPacket converts the packet payload to the States type.
 func (s *States) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(s, p); err != nil {
 		return err
@@ -188,11 +188,7 @@ func (s *States) String() string {
 	if s == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Button left: %s (%d), Button right: %s (%d), Led left: %s (%d), Led right: %s (%d)]",
-			ButtonStateName(s.ButtonLeft), s.ButtonLeft,
-			ButtonStateName(s.ButtonRight), s.ButtonRight,
-			LedStateName(s.LedLeft), s.LedLeft,
-			LedStateName(s.LedRight), s.LedRight)
+		txt += fmt.Sprintf("[Button left: %s (%d), Button right: %s (%d), Led left: %s (%d), Led right: %s (%d)]", ButtonStateName(s.ButtonLeft), s.ButtonLeft, ButtonStateName(s.ButtonRight), s.ButtonRight, LedStateName(s.LedLeft), s.LedLeft, LedStateName(s.LedRight), s.LedRight)
 	}
 	return txt
 }
@@ -206,5 +202,6 @@ func (s *States) Copy() device.Resulter {
 		ButtonLeft:  s.ButtonLeft,
 		ButtonRight: s.ButtonRight,
 		LedLeft:     s.LedLeft,
-		LedRight:    s.LedRight}
+		LedRight:    s.LedRight,
+	}
 }
@@ -0,0 +1,22 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package dualbutton
+
+import (
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Dual Button Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_get_button_state:       {Name: "GetButtonState", Response: func() interface{} { return new(ButtonState) }},
+		function_set_led_state:          {Name: "SetLedState", Request: func() interface{} { return new(LedState) }},
+		function_get_led_state:          {Name: "GetLedState", Response: func() interface{} { return new(LedState) }},
+		function_set_selected_led_state: {Name: "SetSelectedLedState", Request: func() interface{} { return new(SelectedLedState) }},
+		callback_state_changed:          {Name: "StateChanged", Response: func() interface{} { return new(States) }},
+	})
+}
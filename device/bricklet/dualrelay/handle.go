@@ -0,0 +1,61 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package dualrelay
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/device"
+)
+
+// Bricklet is the handle for a Dual Relay Bricklet.
+// Every method is a synchronized call (see the FutureContext functions) or a callback subscription.
+// The uid is the destination of every call, the bricker resolves the connector (or the member
+// of a connector group) for every subscription (see bricker.ConnectorFor).
+type Bricklet struct {
+	brick  *bricker.Bricker
+	Uid    uint32
+	Buffer int // buffer size of the callback channels
+}
+
+// New creates the handle for the bricklet with the given uid.
+func New(brick *bricker.Bricker, uid uint32) *Bricklet {
+	return &Bricklet{brick: brick, Uid: uid}
+}
+
+// SetMonoflop is the handle version of SetMonoflopFutureContext.
+func (bl *Bricklet) SetMonoflop(ctx context.Context, m *Monoflops) error {
+	return SetMonoflopFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, m)
+}
+
+// GetMonoflop is the handle version of GetMonoflopFutureContext.
+func (bl *Bricklet) GetMonoflop(ctx context.Context, r *Relay) (*Monoflop, error) {
+	return GetMonoflopFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, r)
+}
+
+// MonoflopDone subscribes the MonoflopDone callback, until the context is done.
+// The results come in the returned channel (with a buffer of Buffer results),
+// the channel is closed after the context is done or the connector goes away.
+func (bl *Bricklet) MonoflopDone(ctx context.Context) (<-chan *Value, error) {
+	return device.Channel[*Value](ctx, bl.brick, bl.Uid,
+		MonoflopDone("monoflopdone"+device.GenId(), bl.Uid, nil), bl.Buffer)
+}
+
+// SetState is the handle version of SetStateFutureContext.
+func (bl *Bricklet) SetState(ctx context.Context, s *State) error {
+	return SetStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, s)
+}
+
+// GetState is the handle version of GetStateFutureContext.
+func (bl *Bricklet) GetState(ctx context.Context) (*State, error) {
+	return GetStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// SetSelectedState is the handle version of SetSelectedStateFutureContext.
+func (bl *Bricklet) SetSelectedState(ctx context.Context, s *SelectedState) error {
+	return SetSelectedStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, s)
+}
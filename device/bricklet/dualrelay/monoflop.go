@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package dualrelay
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 // SetMonoflop creates the subscriber to set the monoflop timer value for specifed output relay.
@@ -18,7 +20,7 @@ func SetMonoflop(id string, uid uint32, m *Monoflops, handler func(device.Result
 		Id:         device.FallbackId(id, "SetMonoflop"),
 		Fid:        function_set_monoflop,
 		Uid:        uid,
-		Data:       NewMonoflopsRaw(m),
+		Data:       m,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
@@ -26,17 +28,17 @@ func SetMonoflop(id string, uid uint32, m *Monoflops, handler func(device.Result
 // SetMonoflopFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetMonoflopFuture(brick *bricker.Bricker, connectorname string, uid uint32, m *Monoflops) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetMonoflop("setmonoflopfuture"+device.GenId(), uid, m,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetMonoflopFutureContext(context.Background(), brick, connectorname, uid, m) == nil
+}
+
+// SetMonoflopFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetMonoflopFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, m *Monoflops) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetMonoflop("setmonoflopfuture"+device.GenId(), uid, m, nil))
+	return err
 }
 
 // GetMonoflop creates a subscriber for getting the actual monoflop value.
@@ -48,35 +50,35 @@ func GetMonoflop(id string, uid uint32, r *Relay, handler func(device.Resulter,
 		Result:     &Monoflop{},
 		Data:       r,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetMonoflopFuture is a future pattern version for a synchronized all of the subscriber.
+// GetMonoflopFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetMonoflopFuture(brick *bricker.Bricker, connectorname string, uid uint32, r *Relay) *Monoflop {
-	future := make(chan *Monoflop)
-	defer close(future)
-	sub := GetMonoflop("getmonoflopfuture"+device.GenId(), uid, r,
-		func(r device.Resulter, err error) {
-			var v *Monoflop = nil
-			if err == nil {
-				if value, ok := r.(*Monoflop); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetMonoflopFutureContext(context.Background(), brick, connectorname, uid, r)
+	return v
+}
+
+// GetMonoflopFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMonoflopFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, r *Relay) (*Monoflop, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMonoflop("getmonoflopfuture"+device.GenId(), uid, r, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Monoflop); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-MonoflopDone creates a subscriber for the monoflop done callback.
-This callback is triggered whenever a monoflop timer reaches 0.
-*/
+// MonoflopDone creates a subscriber for the monoflop done callback.
+// This callback is triggered whenever a monoflop timer reaches 0.
 func MonoflopDone(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "MonoflopDone"),
@@ -98,25 +100,6 @@ type Monoflops struct {
 	Time  uint32 // ms
 }
 
-// MonoflopsRaw is a en/decoding for Monoflops
-type MonoflopsRaw struct {
-	Relay uint8
-	State uint8
-	Time  uint32
-}
-
-// NewMonoflopsRaw creates a new MonoflopsRaw object from a Monoflops object.
-func NewMonoflopsRaw(m *Monoflops) *MonoflopsRaw {
-	if m == nil {
-		return nil
-	}
-	mr := new(MonoflopsRaw)
-	mr.Relay = m.Relay
-	mr.Time = m.Time
-	mr.State = misc.BoolToUint8(m.State)
-	return mr
-}
-
 // Monoflop is the monflop timer value of a specified relay.
 type Monoflop struct {
 	State         bool   // true - on, false - off
@@ -124,7 +107,7 @@ type Monoflop struct {
 	TimeRemaining uint32 // in ms
 }
 
-// FromPacket creates a Monoflop from a packet.
+// FromPacket converts the packet payload to the Monoflop type.
 func (m *Monoflop) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -138,8 +121,7 @@ func (m *Monoflop) String() string {
 	if m == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[State: %t, Time: %d ms, Time Remaining: %d ms]",
-			m.State, m.Time, m.TimeRemaining)
+		txt += fmt.Sprintf("[State: %t, Time: %d ms, Time Remaining: %d ms]", m.State, m.Time, m.TimeRemaining)
 	}
 	return txt
 }
@@ -152,17 +134,8 @@ func (m *Monoflop) Copy() device.Resulter {
 	return &Monoflop{
 		State:         m.State,
 		Time:          m.Time,
-		TimeRemaining: m.TimeRemaining}
-}
-
-// FromMonoflopRaw converts a en/decoding type MonoflopRaw into Monoflop.
-func (m *Monoflop) FromMonoflopRaw(mr *MonoflopRaw) {
-	if m == nil || mr == nil {
-		return
+		TimeRemaining: m.TimeRemaining,
 	}
-	m.Time = mr.Time
-	m.TimeRemaining = mr.TimeRemaining
-	m.State = misc.Uint8ToBool(mr.State)
 }
 
 // Relay type to define a specific relay.
@@ -170,13 +143,6 @@ type Relay struct {
 	Value uint8 // 1 or 2
 }
 
-// MonoflopRaw is a de/encoding type for Monoflop.
-type MonoflopRaw struct {
-	State         uint8
-	Time          uint32
-	TimeRemaining uint32
-}
-
 // Value is a type for the MonoflopDone callback.
 // Inside the struct is the relay and the state.
 type Value struct {
@@ -184,35 +150,12 @@ type Value struct {
 	State bool  // true - on, false - false
 }
 
-// FromPacket converts the packet payoad to the Value type.
+// FromPacket converts the packet payload to the Value type.
 func (v *Value) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(v, p); err != nil {
+		return err
 	}
-	vr := new(ValueRaw)
-	err := p.Payload.Decode(vr)
-	if err == nil && vr != nil {
-		v.FromValueRaw(vr)
-	}
-	return err
-}
-
-// FromValueRaw converts a ValueRaw into Value.
-func (v *Value) FromValueRaw(vr *ValueRaw) {
-	if v == nil || vr == nil {
-		return
-	}
-	v.Relay = vr.Relay
-	v.State = misc.Uint8ToBool(vr.State)
-}
-
-// Copy creates a copy of the content.
-func (v *Value) Copy() device.Resulter {
-	if v == nil {
-		return nil
-	}
-	return &Value{
-		Relay: v.Relay,
-		State: v.State}
+	return p.Payload.Decode(v)
 }
 
 // String fullfill the stringer interface.
@@ -226,8 +169,13 @@ func (v *Value) String() string {
 	return txt
 }
 
-// ValueRaw is a encoding/decoding type for Value
-type ValueRaw struct {
-	Relay uint8
-	State uint8
+// Copy creates a copy of the content.
+func (v *Value) Copy() device.Resulter {
+	if v == nil {
+		return nil
+	}
+	return &Value{
+		Relay: v.Relay,
+		State: v.State,
+	}
 }
@@ -0,0 +1,148 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The raw types are kept for compatibility, the bool fields of the types are encoded directly.
+
+package dualrelay
+
+import (
+	misc "github.com/dirkjabl/bricker/util/miscellaneous"
+)
+
+// MonoflopsRaw is a en/decoding for Monoflops
+//
+// Deprecated: Monoflops is encoded directly.
+type MonoflopsRaw struct {
+	Relay uint8
+	State uint8
+	Time  uint32
+}
+
+// NewMonoflopsRaw creates a new MonoflopsRaw object from a Monoflops object.
+//
+// Deprecated: Monoflops is encoded directly.
+func NewMonoflopsRaw(m *Monoflops) *MonoflopsRaw {
+	if m == nil {
+		return nil
+	}
+	mr := new(MonoflopsRaw)
+	mr.Relay = m.Relay
+	mr.Time = m.Time
+	mr.State = misc.BoolToUint8(m.State)
+	return mr
+}
+
+// MonoflopRaw is a de/encoding type for Monoflop.
+//
+// Deprecated: Monoflop is decoded directly.
+type MonoflopRaw struct {
+	State         uint8
+	Time          uint32
+	TimeRemaining uint32
+}
+
+// FromMonoflopRaw converts a en/decoding type MonoflopRaw into Monoflop.
+//
+// Deprecated: Monoflop is decoded directly.
+func (m *Monoflop) FromMonoflopRaw(mr *MonoflopRaw) {
+	if m == nil || mr == nil {
+		return
+	}
+	m.Time = mr.Time
+	m.TimeRemaining = mr.TimeRemaining
+	m.State = misc.Uint8ToBool(mr.State)
+}
+
+// ValueRaw is a encoding/decoding type for Value
+//
+// Deprecated: Value is decoded directly.
+type ValueRaw struct {
+	Relay uint8
+	State uint8
+}
+
+// FromValueRaw converts a ValueRaw into Value.
+//
+// Deprecated: Value is decoded directly.
+func (v *Value) FromValueRaw(vr *ValueRaw) {
+	if v == nil || vr == nil {
+		return
+	}
+	v.Relay = vr.Relay
+	v.State = misc.Uint8ToBool(vr.State)
+}
+
+// StateRaw is a de/encoding type for State.
+//
+// Deprecated: State is encoded directly.
+type StateRaw struct {
+	Relay1 uint8
+	Relay2 uint8
+}
+
+// Creates a new StateRaw from a State.
+//
+// Deprecated: State is encoded directly.
+func NewStateRaw(s *State) *StateRaw {
+	if s == nil {
+		return nil
+	}
+	sr := new(StateRaw)
+	sr.Relay1 = misc.BoolToUint8(s.Relay1)
+	sr.Relay2 = misc.BoolToUint8(s.Relay2)
+	return sr
+}
+
+// FromStateRaw converts a StateRaw to a State
+//
+// Deprecated: State is decoded directly.
+func (s *State) FromStateRaw(sr *StateRaw) {
+	if s == nil || sr == nil {
+		return
+	}
+	s.Relay1 = misc.Uint8ToBool(sr.Relay1)
+	s.Relay2 = misc.Uint8ToBool(sr.Relay2)
+}
+
+// SelectedStateRaw is a en/decoding type for SelectedState.
+//
+// Deprecated: SelectedState is encoded directly.
+type SelectedStateRaw struct {
+	Relay uint8
+	State uint8
+}
+
+// Creates a new SelectedStateRaw from a SelectedState.
+//
+// Deprecated: SelectedState is encoded directly.
+func NewSelectedStateRaw(s *SelectedState) *SelectedStateRaw {
+	if s == nil {
+		return nil
+	}
+	sr := new(SelectedStateRaw)
+	sr.FromSelectedState(s)
+	return sr
+}
+
+// FromSelectedState converts a SelectedState into a SelectedStateRaw.
+//
+// Deprecated: SelectedState is encoded directly.
+func (sr *SelectedStateRaw) FromSelectedState(s *SelectedState) {
+	if sr == nil || s == nil {
+		return
+	}
+	sr.Relay = s.Relay
+	sr.State = misc.BoolToUint8(s.State)
+}
+
+// FromSelectedStateRaw converts the contet of a SelectedStateRaw into the object.
+//
+// Deprecated: SelectedState is decoded directly.
+func (s *SelectedState) FromSelectedStateRaw(sr *SelectedStateRaw) {
+	if s == nil || sr == nil {
+		return
+	}
+	s.Relay = sr.Relay
+	s.State = (sr.State & 0x01) == 0x01
+}
@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package dualrelay
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 // SetState creates a subscriber to set the dual relays.
@@ -24,25 +26,25 @@ func SetState(id string, uid uint32, s *State, handler func(device.Resulter, err
 		Id:         device.FallbackId(id, "SetState"),
 		Fid:        function_set_state,
 		Uid:        uid,
-		Data:       NewStateRaw(s),
+		Data:       s,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func SetStateFuture(brick bricker.Bricker, connectorname string, uid uint32, s *State) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetState("setstatefuture"+device.GenId(), uid, s,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func SetStateFuture(brick *bricker.Bricker, connectorname string, uid uint32, s *State) bool {
+	return SetStateFutureContext(context.Background(), brick, connectorname, uid, s) == nil
+}
+
+// SetStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, s *State) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetState("setstatefuture"+device.GenId(), uid, s, nil))
+	return err
 }
 
 // GetState creates a subscriber to get the relay states.
@@ -53,29 +55,31 @@ func GetState(id string, uid uint32, handler func(device.Resulter, error)) *devi
 		Uid:        uid,
 		Result:     &State{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetStateFuture(brick bricker.Bricker, connectorname string, uid uint32) *State {
-	future := make(chan *State)
-	defer close(future)
-	sub := GetState("getstatefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *State = nil
-			if err == nil {
-				if value, ok := r.(*State); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetStateFuture(brick *bricker.Bricker, connectorname string, uid uint32) *State {
+	v, _ := GetStateFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*State, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetState("getstatefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*State); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetSelectedState creates a subscriber to set only one relay.
@@ -85,25 +89,25 @@ func SetSelectedState(id string, uid uint32, s *SelectedState, handler func(devi
 		Id:         device.FallbackId(id, "SetSelectedState"),
 		Fid:        function_set_selected_state,
 		Uid:        uid,
-		Data:       NewSelectedStateRaw(s),
+		Data:       s,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetSelectedStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func SetSelectedStateFuture(brick bricker.Bricker, connectorname string, uid uint32, s *SelectedState) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetSelectedState("setselectedstatefuture"+device.GenId(), uid, s,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func SetSelectedStateFuture(brick *bricker.Bricker, connectorname string, uid uint32, s *SelectedState) bool {
+	return SetSelectedStateFutureContext(context.Background(), brick, connectorname, uid, s) == nil
+}
+
+// SetSelectedStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetSelectedStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, s *SelectedState) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetSelectedState("setselectedstatefuture"+device.GenId(), uid, s, nil))
+	return err
 }
 
 // State holds the state of the relays.
@@ -113,20 +117,15 @@ type State struct {
 	Relay2 bool
 }
 
-// FromPacket converts the payload data from a packet into a State type object.
+// FromPacket converts the packet payload to the State type.
 func (s *State) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(s, p); err != nil {
 		return err
 	}
-	sr := new(StateRaw)
-	err := p.Payload.Decode(sr)
-	if err == nil && sr != nil {
-		s.FromStateRaw(sr)
-	}
-	return err
+	return p.Payload.Decode(s)
 }
 
-// String fullfill the stringer interface
+// String fullfill the stringer interface.
 func (s *State) String() string {
 	txt := "State "
 	if s == nil {
@@ -144,33 +143,8 @@ func (s *State) Copy() device.Resulter {
 	}
 	return &State{
 		Relay1: s.Relay1,
-		Relay2: s.Relay2}
-}
-
-// FromStateRaw converts a StateRaw to a State
-func (s *State) FromStateRaw(sr *StateRaw) {
-	if s == nil || sr == nil {
-		return
-	}
-	s.Relay1 = misc.Uint8ToBool(sr.Relay1)
-	s.Relay2 = misc.Uint8ToBool(sr.Relay2)
-}
-
-// StateRaw is a de/encoding type for State.
-type StateRaw struct {
-	Relay1 uint8
-	Relay2 uint8
-}
-
-// Creates a new StateRaw from a State.
-func NewStateRaw(s *State) *StateRaw {
-	if s == nil {
-		return nil
+		Relay2: s.Relay2,
 	}
-	sr := new(StateRaw)
-	sr.Relay1 = misc.BoolToUint8(s.Relay1)
-	sr.Relay2 = misc.BoolToUint8(s.Relay2)
-	return sr
 }
 
 // SelectedState is a type to address one specific relay (1 or 2).
@@ -182,17 +156,12 @@ type SelectedState struct {
 	State bool
 }
 
-// FromPacket converts the payload data from a packet into a SelectedState type object.
+// FromPacket converts the packet payload to the SelectedState type.
 func (s *SelectedState) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(s, p); err != nil {
 		return err
 	}
-	sr := new(SelectedStateRaw)
-	err := p.Payload.Decode(sr)
-	if err == nil && sr != nil {
-		s.FromSelectedStateRaw(sr)
-	}
-	return err
+	return p.Payload.Decode(s)
 }
 
 // String fullfill the stringer interface.
@@ -213,43 +182,6 @@ func (s *SelectedState) Copy() device.Resulter {
 	}
 	return &SelectedState{
 		Relay: s.Relay,
-		State: s.State}
-}
-
-// FromSelectedStateRaw converts the contet of a SelectedStateRaw into the object.
-func (s *SelectedState) FromSelectedStateRaw(sr *SelectedStateRaw) {
-	if s == nil || sr == nil {
-		return
-	}
-	s.Relay = sr.Relay
-	s.State = (sr.State & 0x01) == 0x01
-}
-
-// SelectedStateRaw is a en/decoding type for SelectedState.
-type SelectedStateRaw struct {
-	Relay uint8
-	State uint8
-}
-
-// Creates a new SelectedStateRaw from a SelectedState.
-func NewSelectedStateRaw(s *SelectedState) *SelectedStateRaw {
-	if s == nil {
-		return nil
-	}
-	sr := new(SelectedStateRaw)
-	sr.FromSelectedState(s)
-	return sr
-}
-
-// FromState converts a State into a StateRaw.
-func (sr *SelectedStateRaw) FromSelectedState(s *SelectedState) {
-	if sr == nil || s == nil {
-		return
-	}
-	sr.Relay = s.Relay
-	if s.State {
-		sr.State = 0x01
-	} else {
-		sr.State = 0x00
+		State: s.State,
 	}
 }
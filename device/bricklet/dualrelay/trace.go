@@ -0,0 +1,23 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package dualrelay
+
+import (
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Dual Relay Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_set_monoflop:       {Name: "SetMonoflop", Request: func() interface{} { return new(Monoflops) }},
+		function_get_monoflop:       {Name: "GetMonoflop", Request: func() interface{} { return new(Relay) }, Response: func() interface{} { return new(Monoflop) }},
+		callback_monoflop_done:      {Name: "MonoflopDone", Response: func() interface{} { return new(Value) }},
+		function_set_state:          {Name: "SetState", Request: func() interface{} { return new(State) }},
+		function_get_state:          {Name: "GetState", Response: func() interface{} { return new(State) }},
+		function_set_selected_state: {Name: "SetSelectedState", Request: func() interface{} { return new(SelectedState) }},
+	})
+}
@@ -0,0 +1,373 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Header of every generated file.
+const header = `// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+`
+
+// Known imports of the generated code, found by the package identifier.
+var imports = []struct {
+	ident, path string
+}{
+	{"context", `"context"`},
+	{"fmt", `"fmt"`},
+	{"bricker", `"github.com/dirkjabl/bricker"`},
+	{"device", `"github.com/dirkjabl/bricker/device"`},
+	{"packet", `"github.com/dirkjabl/bricker/net/packet"`},
+	{"trace", `"github.com/dirkjabl/bricker/trace"`},
+	{"misc", `misc "github.com/dirkjabl/bricker/util/miscellaneous"`},
+}
+
+var funcs = template.FuncMap{
+	"doc": func(lines []string) string {
+		txt := ""
+		for _, l := range lines {
+			if l == "" {
+				txt += "//\n"
+			} else {
+				txt += "// " + l + "\n"
+			}
+		}
+		return txt
+	},
+	"quote": strconv.Quote,
+	"lower": strings.ToLower,
+	"join":  func(s []string) string { return strings.Join(s, ", ") },
+	"param": func(p *Param) string {
+		if p == nil {
+			return ""
+		}
+		return ", " + p.Name + " " + p.Type
+	},
+	"arg": func(p *Param) string {
+		if p == nil {
+			return ""
+		}
+		return ", " + p.Name
+	},
+	"field": fieldName,
+	"deref": func(t string) string { return strings.TrimPrefix(t, "*") },
+	"result": func(f *Function) string {
+		if f.Result == "" {
+			return "device.EmptyResult"
+		}
+		return f.Result
+	},
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(funcs).Parse(`
+{{- if .Main}}// Collection of subscriber for the {{.Spec.Name}}.
+{{end -}}
+package {{.Spec.Package}}
+
+{{if .Main}}//go:generate go run {{.Spec.GeneratorPath}} spec.json
+
+{{end -}}
+IMPORTS
+
+{{if and .Main .Spec.Identifier}}// DeviceIdentifier identifies the {{.Spec.Name}} (see device/name).
+const DeviceIdentifier = uint16({{.Spec.Identifier}})
+
+{{end -}}
+{{range .File.Constants}}{{doc .Doc}}const (
+{{range .Values}}{{doc .Doc}}	{{.Name}} = {{.Value}}{{if .Comment}} // {{.Comment}}{{end}}
+{{end}})
+
+{{end}}
+{{- range .File.Functions}}{{doc .Doc -}}
+func {{.Name}}(id string, uid uint32{{param .Param}}, handler func(device.Resulter, error)) *device.Device {
+	return device.Generator{
+		Id:         device.FallbackId(id, {{quote .Name}}),
+		Fid:        {{.Fid}},
+		Uid:        uid,
+{{- if .Result}}
+		Result:     &{{.Result}}{},
+{{- end}}
+{{- if .Param}}
+		Data:       {{.Param.Name}},
+{{- end}}
+		Handler:    handler,
+{{- if .Callback}}
+		IsCallback: true,
+{{- end}}
+{{- if .Restore}}
+		Restore:    true,
+{{- end}}
+{{- if .IsIdempotent}}
+		Idempotent: true,
+{{- end}}
+{{- if .CanDisable}}
+		Disable:    true,
+{{- end}}
+		WithPacket: {{not .Callback}}}.CreateDevice()
+}
+{{if not .Callback}}{{if .Result}}
+// {{.Name}}Future is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is nil.
+func {{.Name}}Future(brick *bricker.Bricker, connectorname string, uid uint32{{param .Param}}) *{{.Result}} {
+	v, _ := {{.Name}}FutureContext(context.Background(), brick, connectorname, uid{{arg .Param}})
+	return v
+}
+
+// {{.Name}}FutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func {{.Name}}FutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32{{param .Param}}) (*{{.Result}}, error) {
+	res, err := device.Future(ctx, brick, dest,
+		{{.Name}}({{quote (printf "%sfuture" (lower .Name))}}+device.GenId(), uid{{arg .Param}}, nil))
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := res.(*{{.Result}}); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
+}
+{{else}}
+// {{.Name}}Future is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is false.
+func {{.Name}}Future(brick *bricker.Bricker, connectorname string, uid uint32{{param .Param}}) bool {
+	return {{.Name}}FutureContext(context.Background(), brick, connectorname, uid{{arg .Param}}) == nil
+}
+
+// {{.Name}}FutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func {{.Name}}FutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32{{param .Param}}) error {
+	_, err := device.Future(ctx, brick, dest,
+		{{.Name}}({{quote (printf "%sfuture" (lower .Name))}}+device.GenId(), uid{{arg .Param}}, nil))
+	return err
+}
+{{end}}{{end}}
+{{end}}
+{{- range .File.Types}}{{$r := .Receiver}}{{doc .Doc -}}
+type {{.Name}} struct {
+{{range .Fields}}	{{if .Name}}{{.Name}} {{end}}{{.Type}}{{if .Comment}} // {{.Comment}}{{end}}
+{{end}}}
+{{if not .Param}}
+// FromPacket converts the packet payload to the {{.Name}} type.
+func ({{$r}} *{{.Name}}) FromPacket(p *packet.Packet) error {
+	if err := device.CheckForFromPacket({{$r}}, p); err != nil {
+		return err
+	}
+	return p.Payload.Decode({{$r}})
+}
+{{if not .CustomString}}
+// String fullfill the stringer interface.
+func ({{$r}} *{{.Name}}) String() string {
+	txt := {{quote .Title}}
+	if {{$r}} == nil {
+		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf({{quote .Format}}, {{join .Args}})
+	}
+	return txt
+}
+{{end}}
+// Copy creates a copy of the content.
+func ({{$r}} *{{.Name}}) Copy() device.Resulter {
+	if {{$r}} == nil {
+		return nil
+	}
+	return &{{.Name}}{
+{{range .Fields}}		{{field .}}: {{$r}}.{{field .}},
+{{end}}	}
+}
+{{end}}
+{{end}}`))
+
+var handleTemplate = template.Must(template.New("handle").Funcs(funcs).Parse(`package {{.Spec.Package}}
+
+IMPORTS
+
+// {{.Handle}} is the handle for a {{.Spec.Name}}.
+// Every method is a synchronized call (see the FutureContext functions) or a callback subscription.
+// The uid is the destination of every call, the bricker resolves the connector (or the member
+// of a connector group) for every subscription (see bricker.ConnectorFor).
+type {{.Handle}} struct {
+	brick  *bricker.Bricker
+	Uid    uint32
+	Buffer int // buffer size of the callback channels
+}
+
+// New creates the handle for the {{lower .Handle}} with the given uid.
+func New(brick *bricker.Bricker, uid uint32) *{{.Handle}} {
+	return &{{.Handle}}{brick: brick, Uid: uid}
+}
+{{$h := .Handle}}{{$r := .R}}{{range .Functions}}{{if not .Callback}}
+// {{.Name}} is the handle version of {{.Name}}FutureContext.
+func ({{$r}} *{{$h}}) {{.Name}}(ctx context.Context{{param .Param}}) {{if .Result}}(*{{.Result}}, error){{else}}error{{end}} {
+	return {{.Name}}FutureContext(ctx, {{$r}}.brick, {{$r}}.Uid, {{$r}}.Uid{{arg .Param}})
+}
+{{else}}
+// {{.Name}} subscribes the {{.Name}} callback, until the context is done.
+// The results come in the returned channel (with a buffer of Buffer results),
+// the channel is closed after the context is done or the connector goes away.
+func ({{$r}} *{{$h}}) {{.Name}}(ctx context.Context) (<-chan *{{result .}}, error) {
+	return device.Channel[*{{result .}}](ctx, {{$r}}.brick, {{$r}}.Uid,
+		{{.Name}}({{quote (lower .Name)}}+device.GenId(), {{$r}}.Uid, nil), {{$r}}.Buffer)
+}
+{{end}}{{end}}`))
+
+var traceTemplate = template.Must(template.New("trace").Funcs(funcs).Parse(`package {{.Spec.Package}}
+
+IMPORTS
+
+// Internal function: init registers the functions and callbacks of the {{.Spec.Name}} for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+{{range .Functions}}		{{.Fid}}: {Name: {{quote .Name}}
+{{- with .Param}}, Request: func() interface{} { return new({{deref .Type}}) }{{end}}
+{{- with .Result}}, Response: func() interface{} { return new({{.}}) }{{end}}},
+{{end}}	})
+}
+`))
+
+// Internal function: generate creates the sources of all files (by file name) of the spec.
+func generate(spec *Spec) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	main := spec.Package + ".go"
+	functions := make([]*Function, 0)
+	for _, f := range spec.Files {
+		for _, t := range f.Types {
+			if t.Receiver == "p" && !t.Param {
+				return nil, fmt.Errorf("%s: receiver of type %s clashes with the packet parameter", f.Name, t.Name)
+			}
+			if t.Format == "" && !t.CustomString && !t.Param {
+				t.Format, t.Args = defaultFormat(t)
+			}
+		}
+		src, err := render(fileTemplate, map[string]interface{}{
+			"Spec": spec,
+			"File": f,
+			"Main": f.Name == main})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.Name, err.Error())
+		}
+		files[f.Name] = src
+		functions = append(functions, f.Functions...)
+	}
+	if _, ok := files[main]; !ok {
+		return nil, fmt.Errorf("spec needs a file %s with the package documentation", main)
+	}
+	if _, ok := files["handle.go"]; ok {
+		return nil, fmt.Errorf("handle.go is reserved for the handle")
+	}
+	src, err := render(handleTemplate, map[string]interface{}{
+		"Spec":      spec,
+		"Handle":    spec.HandleName(),
+		"R":         handleReceiver(spec.HandleName()),
+		"Functions": functions})
+	if err != nil {
+		return nil, fmt.Errorf("handle.go: %s", err.Error())
+	}
+	files["handle.go"] = src
+	if spec.Identifier == 0 { // without a device identifier no tracing
+		return files, nil
+	}
+	if _, ok := files["trace.go"]; ok {
+		return nil, fmt.Errorf("trace.go is reserved for the tracing")
+	}
+	src, err = render(traceTemplate, map[string]interface{}{
+		"Spec":      spec,
+		"Functions": uniqueFids(functions)})
+	if err != nil {
+		return nil, fmt.Errorf("trace.go: %s", err.Error())
+	}
+	files["trace.go"] = src
+	return files, nil
+}
+
+// Internal function: uniqueFids gives the functions with different function ids, the first function of a id wins.
+func uniqueFids(functions []*Function) []*Function {
+	seen := make(map[string]bool)
+	result := make([]*Function, 0, len(functions))
+	for _, f := range functions {
+		if !seen[f.Fid] {
+			seen[f.Fid] = true
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// Internal function: handleReceiver gives the receiver name for the methods of the handle type.
+func handleReceiver(handle string) string {
+	if handle == "Bricklet" || len(handle) < 2 {
+		return "bl"
+	}
+	return strings.ToLower(handle[:2])
+}
+
+// Internal function: defaultFormat creates the format and the arguments for the
+// string representation of a type with all fields.
+func defaultFormat(t *Type) (string, []string) {
+	names := make([]string, 0, len(t.Fields))
+	args := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		names = append(names, fieldName(f)+": %v")
+		args = append(args, t.Receiver+"."+fieldName(f))
+	}
+	return "[" + strings.Join(names, ", ") + "]", args
+}
+
+// Internal function: fieldName gives the name of the field, for a embedded type the name of the type.
+func fieldName(f *Field) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	n := strings.TrimPrefix(f.Type, "*")
+	return n[strings.LastIndex(n, ".")+1:]
+}
+
+// Internal function: render executes the template, adds the header and the imports and formats the source.
+func render(t *template.Template, data interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	body := buf.String()
+	used := make([]string, 0)
+	for _, i := range imports {
+		if regexp.MustCompile(`\b` + i.ident + `\.`).MatchString(body) {
+			used = append(used, i.path)
+		}
+	}
+	sort.SliceStable(used, func(i, j int) bool { return importPath(used[i]) < importPath(used[j]) })
+	block := ""
+	if len(used) > 0 {
+		block = "import (\n\t" + strings.Join(used, "\n\t") + "\n)"
+	}
+	src := header + strings.Replace(body, "IMPORTS", block, 1)
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		return nil, fmt.Errorf("%s\n%s", err.Error(), src)
+	}
+	return out, nil
+}
+
+// Internal function: importPath gives the path of a import line (without a name).
+func importPath(i string) string {
+	return i[strings.Index(i, `"`):]
+}
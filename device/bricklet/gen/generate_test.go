@@ -0,0 +1,208 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+	"package": "sample",
+	"name": "Sample Bricklet",
+	"identifier": 999,
+	"files": [
+		{
+			"name": "sample.go",
+			"constants": [{"values": [
+				{"name": "function_get_value", "value": "uint8(1)"},
+				{"name": "function_set_value", "value": "uint8(2)"},
+				{"name": "callback_value", "value": "uint8(3)"}
+			]}],
+			"functions": [
+				{"name": "GetValue", "doc": ["GetValue creates a subscriber to get the value."],
+				 "fid": "function_get_value", "result": "Value"},
+				{"name": "SetValue", "doc": ["SetValue creates a subscriber to set the value."],
+				 "fid": "function_set_value", "param": {"name": "v", "type": "*Value"}, "restore": true},
+				{"name": "ValueChanged", "doc": ["ValueChanged creates a subscriber for the value callback."],
+				 "fid": "callback_value", "result": "Value", "callback": true}
+			],
+			"types": [
+				{"name": "Value", "doc": ["Value is the value of the sample."], "receiver": "v",
+				 "fields": [{"name": "Value", "type": "uint16", "comment": "the value"}, {"name": "On", "type": "bool"}],
+				 "title": "Value "}
+			]
+		}
+	]
+}`
+
+// Internal function: testGenerate generates the test spec and parses all files.
+func testGenerate(t *testing.T) map[string]*ast.File {
+	spec := &Spec{}
+	if err := json.Unmarshal([]byte(testSpec), spec); err != nil {
+		t.Fatalf("Error TestGenerate: could not read spec (%s).", err.Error())
+	}
+	if err := spec.check(); err != nil {
+		t.Fatalf("Error TestGenerate: spec is invalid (%s).", err.Error())
+	}
+	files, err := generate(spec)
+	if err != nil {
+		t.Fatalf("Error TestGenerate: could not generate (%s).", err.Error())
+	}
+	parsed := make(map[string]*ast.File)
+	fset := token.NewFileSet()
+	for name, src := range files {
+		if !strings.Contains(string(src), "DO NOT EDIT.") {
+			t.Fatalf("Error TestGenerate: file %s has no generated marker.", name)
+		}
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Error TestGenerate: could not parse %s (%s).", name, err.Error())
+		}
+		parsed[name] = f
+	}
+	return parsed
+}
+
+// Internal function: declared gives all declared functions and methods (with receiver type) of the file.
+func declared(f *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		name := fd.Name.Name
+		if fd.Recv != nil {
+			recv := fd.Recv.List[0].Type
+			if s, ok := recv.(*ast.StarExpr); ok {
+				recv = s.X
+			}
+			name = recv.(*ast.Ident).Name + "." + name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+func TestGenerate(t *testing.T) {
+	files := testGenerate(t)
+	if len(files) != 3 {
+		t.Fatalf("Error TestGenerate: expected 3 files, got %d.", len(files))
+	}
+	main := declared(files["sample.go"])
+	for _, name := range []string{"GetValue", "GetValueFuture", "GetValueFutureContext",
+		"SetValue", "SetValueFuture", "SetValueFutureContext", "ValueChanged",
+		"Value.FromPacket", "Value.String", "Value.Copy"} {
+		if !main[name] {
+			t.Fatalf("Error TestGenerate: %s is not generated.", name)
+		}
+	}
+	for _, name := range []string{"ValueChangedFuture", "ValueChangedFutureContext"} {
+		if main[name] {
+			t.Fatalf("Error TestGenerate: callback has a future %s.", name)
+		}
+	}
+	if files["sample.go"].Doc == nil || files["sample.go"].Doc.Text() != "Collection of subscriber for the Sample Bricklet.\n" {
+		t.Fatalf("Error TestGenerate: package documentation is missing.")
+	}
+	if files["sample.go"].Scope.Lookup("DeviceIdentifier") == nil || files["handle.go"].Scope.Lookup("DeviceIdentifier") != nil {
+		t.Fatalf("Error TestGenerate: device identifier is missing.")
+	}
+	if trace := declared(files["trace.go"]); !trace["init"] {
+		t.Fatalf("Error TestGenerate: tracing registration is not generated.")
+	}
+	handle := declared(files["handle.go"])
+	for _, name := range []string{"New", "Bricklet.GetValue", "Bricklet.SetValue", "Bricklet.ValueChanged"} {
+		if !handle[name] {
+			t.Fatalf("Error TestGenerate: %s is not generated in the handle.", name)
+		}
+	}
+}
+
+func TestGenerateParam(t *testing.T) {
+	spec := &Spec{}
+	json.Unmarshal([]byte(testSpec), spec)
+	spec.Files[0].Types[0].Param = true
+	files, err := generate(spec)
+	if err != nil {
+		t.Fatalf("Error TestGenerateParam: could not generate (%s).", err.Error())
+	}
+	if strings.Contains(string(files["sample.go"]), "func (v *Value)") {
+		t.Fatalf("Error TestGenerateParam: parameter type has methods.")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for i, s := range []string{
+		`{"package": "sample"}`,
+		`{"package": "sample", "name": "Sample", "files": [{"name": "sample.go", "functions": [{"name": "Get"}]}]}`,
+		`{"package": "sample", "name": "Sample", "files": [{"name": "sample.go", "functions": [
+			{"name": "Get", "fid": "function_get"}, {"name": "Get", "fid": "function_get"}]}]}`,
+		`{"package": "sample", "name": "Sample", "files": [{"name": "sample.go", "functions": [
+			{"name": "Changed", "fid": "callback_changed", "callback": true, "param": {"name": "v", "type": "uint8"}}]}]}`,
+		`{"package": "sample", "name": "Sample", "files": [{"name": "sample.go", "types": [{"name": "Value"}]}]}`,
+	} {
+		spec := &Spec{}
+		if err := json.Unmarshal([]byte(s), spec); err != nil {
+			t.Fatalf("Error TestCheck: could not read spec %d (%s).", i, err.Error())
+		}
+		if spec.check() == nil {
+			t.Fatalf("Error TestCheck: spec %d is not detected as invalid.", i)
+		}
+	}
+}
+
+func TestGenerateNoMain(t *testing.T) {
+	spec := &Spec{}
+	json.Unmarshal([]byte(testSpec), spec)
+	spec.Files[0].Name = "value.go"
+	if _, err := generate(spec); err == nil {
+		t.Fatalf("Error TestGenerateNoMain: missing main file is not detected.")
+	}
+}
+
+func TestGenerateNoIdentifier(t *testing.T) {
+	spec := &Spec{}
+	json.Unmarshal([]byte(testSpec), spec)
+	spec.Identifier = 0
+	files, err := generate(spec)
+	if err != nil {
+		t.Fatalf("Error TestGenerateNoIdentifier: could not generate (%s).", err.Error())
+	}
+	if _, ok := files["trace.go"]; ok {
+		t.Fatalf("Error TestGenerateNoIdentifier: tracing without a device identifier.")
+	}
+}
+
+func TestUniqueFids(t *testing.T) {
+	fs := uniqueFids([]*Function{{Name: "A", Fid: "a"}, {Name: "B", Fid: "b"}, {Name: "C", Fid: "a"}})
+	if len(fs) != 2 || fs[0].Name != "A" || fs[1].Name != "B" {
+		t.Fatalf("Error TestUniqueFids: wrong functions (%d).", len(fs))
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	no := false
+	for _, c := range []struct {
+		f                   Function
+		idempotent, disable bool
+	}{
+		{Function{Name: "GetValue"}, true, false},
+		{Function{Name: "IsOn"}, true, false},
+		{Function{Name: "GetEdgeCount", Idempotent: &no}, false, false},
+		{Function{Name: "SetValueCallbackPeriod"}, false, true},
+		{Function{Name: "SetValueCallbackPeriod", Disable: &no}, false, false},
+		{Function{Name: "Value", Callback: true}, false, false},
+	} {
+		if c.f.IsIdempotent() != c.idempotent || c.f.CanDisable() != c.disable {
+			t.Fatalf("Error TestDefaults: wrong defaults for %s.", c.f.Name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Gen generates a bricklet package from a declarative specification (spec.json).
+
+The spec describes the functions and callbacks of a bricklet (function id, parameter, result)
+and the payload layouts (types), which are used as parameter or result.
+For every function the generator emits a subscriber, a future and a context aware future,
+for every callback a subscriber. Every payload layout gets a struct type, which fullfills
+the device.Resulter interface. The handle type (Bricklet or the name given in the spec) gets a method for every function and callback.
+All parts, which are not generated (e.g. conversions), are hand written in other files of the package.
+
+Usage (inside the package directory, normally called by go generate):
+
+	go run ../gen spec.json
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: gen [-o directory] spec.json\n")
+		flag.PrintDefaults()
+	}
+	dir := flag.String("o", "", "output directory (default: directory of the spec)")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+	if *dir == "" {
+		*dir = filepath.Dir(name)
+	}
+	if err := run(name, *dir); err != nil {
+		fmt.Fprintf(os.Stderr, "gen: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// Internal function: run reads the spec and writes all generated files into the directory.
+func run(name, dir string) error {
+	spec, err := readSpec(name)
+	if err != nil {
+		return err
+	}
+	files, err := generate(spec)
+	if err != nil {
+		return err
+	}
+	for fname, src := range files {
+		if err = os.WriteFile(filepath.Join(dir, fname), src, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
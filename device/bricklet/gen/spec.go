@@ -0,0 +1,172 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Spec is the declarative description of a bricklet package.
+type Spec struct {
+	Package    string  `json:"package"`              // name of the go package
+	Name       string  `json:"name"`                 // name of the device (e.g. "Temperature Bricklet")
+	Identifier uint16  `json:"identifier,omitempty"` // device identifier (see device/name)
+	Handle     string  `json:"handle,omitempty"`     // name of the handle type (default "Bricklet")
+	Generator  string  `json:"generator,omitempty"`  // path of the generator relative to the package (default "../gen")
+	Files      []*File `json:"files"`                // generated files, in order
+}
+
+// HandleName gives the name of the handle type.
+func (s *Spec) HandleName() string {
+	if s.Handle == "" {
+		return "Bricklet"
+	}
+	return s.Handle
+}
+
+// GeneratorPath gives the path of the generator relative to the package (for go generate).
+func (s *Spec) GeneratorPath() string {
+	if s.Generator == "" {
+		return "../gen"
+	}
+	return s.Generator
+}
+
+// File describes one generated file with constants, functions and types.
+type File struct {
+	Name      string       `json:"name"`
+	Constants []*Constants `json:"constants,omitempty"`
+	Functions []*Function  `json:"functions,omitempty"`
+	Types     []*Type      `json:"types,omitempty"`
+}
+
+// Constants is a group of constants (one const block).
+type Constants struct {
+	Doc    []string `json:"doc,omitempty"`
+	Values []*Value `json:"values"`
+}
+
+// Value is a single constant.
+type Value struct {
+	Doc     []string `json:"doc,omitempty"`
+	Name    string   `json:"name"`
+	Value   string   `json:"value"` // go expression
+	Comment string   `json:"comment,omitempty"`
+}
+
+// Function describes a function or a callback of the bricklet.
+// For every function a subscriber, a future and a context aware future are generated,
+// for every callback only a subscriber. The handle gets a method for both.
+type Function struct {
+	Name       string   `json:"name"`
+	Doc        []string `json:"doc"`
+	Fid        string   `json:"fid"`              // name of the constant with the function id
+	Param      *Param   `json:"param,omitempty"`  // parameter of the request
+	Result     string   `json:"result,omitempty"` // type of the result (e.g. "Temperature" or "device.Period")
+	Callback   bool     `json:"callback,omitempty"`
+	Restore    bool     `json:"restore,omitempty"`    // send the request again after a reconnect
+	Idempotent *bool    `json:"idempotent,omitempty"` // request could be retried (default: getters)
+	Disable    *bool    `json:"disable,omitempty"`    // request enables callbacks (default: callback period setters)
+}
+
+// IsIdempotent checks, if the request of the function could be sent again without a side effect.
+// Without a setting in the spec, the getters (Get and Is functions) are idempotent.
+func (f *Function) IsIdempotent() bool {
+	if f.Idempotent != nil {
+		return *f.Idempotent
+	}
+	return !f.Callback && (strings.HasPrefix(f.Name, "Get") || strings.HasPrefix(f.Name, "Is"))
+}
+
+// CanDisable checks, if the request of the function enables callbacks, which the same request
+// with a zero payload disables. Without a setting in the spec, the callback period setters
+// (Set...CallbackPeriod functions) could disable their callbacks.
+func (f *Function) CanDisable() bool {
+	if f.Disable != nil {
+		return *f.Disable
+	}
+	return !f.Callback && strings.HasPrefix(f.Name, "Set") && strings.HasSuffix(f.Name, "CallbackPeriod")
+}
+
+// Param is the parameter of a function, it is the payload of the request.
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // go type (e.g. "*device.Period" or "uint8")
+}
+
+// Type describes a payload layout (a struct), which is used as parameter or result.
+// The generated type fullfills the device.Resulter interface and the stringer interface.
+type Type struct {
+	Name         string   `json:"name"`
+	Doc          []string `json:"doc"`
+	Receiver     string   `json:"receiver"`
+	Fields       []*Field `json:"fields"`
+	Title        string   `json:"title"`                  // prefix of the string representation
+	Format       string   `json:"format,omitempty"`       // format of the string representation of the values
+	Args         []string `json:"args,omitempty"`         // arguments (go expressions) for the format
+	CustomString bool     `json:"customstring,omitempty"` // the String method is hand written
+	Param        bool     `json:"param,omitempty"`        // only a request payload, no methods are generated
+}
+
+// Field is a field of a payload layout.
+// A field without a name is a embedded type.
+type Field struct {
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Internal function: readSpec reads and checks the spec from the given file.
+func readSpec(name string) (*Spec, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err = json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
+	}
+	return spec, spec.check()
+}
+
+// Internal method: check validates the spec.
+func (s *Spec) check() error {
+	if s.Package == "" || s.Name == "" {
+		return fmt.Errorf("spec needs a package and a name")
+	}
+	names := make(map[string]bool)
+	for _, f := range s.Files {
+		if f.Name == "" {
+			return fmt.Errorf("file without a name")
+		}
+		for _, fn := range f.Functions {
+			if fn.Name == "" || fn.Fid == "" {
+				return fmt.Errorf("%s: function needs a name and a function id", f.Name)
+			}
+			if names[fn.Name] {
+				return fmt.Errorf("%s: function %s is defined twice", f.Name, fn.Name)
+			}
+			names[fn.Name] = true
+			if fn.Callback && fn.Param != nil {
+				return fmt.Errorf("%s: callback %s could not have a parameter", f.Name, fn.Name)
+			}
+		}
+		for _, t := range f.Types {
+			if t.Name == "" || t.Receiver == "" {
+				return fmt.Errorf("%s: type needs a name and a receiver", f.Name)
+			}
+			if t.Param && t.Format != "" {
+				return fmt.Errorf("%s: parameter type %s could not have a generated string representation", f.Name, t.Name)
+			}
+			if len(t.Args) > 0 && t.Format == "" {
+				return fmt.Errorf("%s: type %s has arguments without a format", f.Name, t.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package humidity
+
+// Float64 converts the humidity value from int16 to float64.
+func (h *Humidity) Float64() float64 {
+	f := float64(h.Value) / 10.00
+	return f
+}
+
+// Float32 converts the humidity value from int16 to float32.
+func (h *Humidity) Float32() float32 {
+	f := float32(h.Value) / 10.00
+	return f
+}
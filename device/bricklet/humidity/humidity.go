@@ -2,16 +2,24 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Humidity Bricklet.
 package humidity
 
+//go:generate go run ../gen spec.json
+
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
+// DeviceIdentifier identifies the Humidity Bricklet (see device/name).
+const DeviceIdentifier = uint16(27)
+
 const (
 	function_get_humidity                        = uint8(1)
 	function_get_analog_value                    = uint8(2)
@@ -40,41 +48,42 @@ func GetHumidity(id string, uid uint32, handler func(device.Resulter, error)) *d
 		Uid:        uid,
 		Result:     &Humidity{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetHumidityFuture is a future pattern version for a synchronized calll of the subscriber.
+// GetHumidityFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetHumidityFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Humidity {
-	future := make(chan *Humidity)
-	defer close(future)
-	sub := GetHumidity("gethumidityfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Humidity = nil
-			if err == nil {
-				if value, ok := r.(*Humidity); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetHumidityFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetHumidityFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetHumidityFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Humidity, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetHumidity("gethumidityfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Humidity); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
- Humidity type.
-The value has a range of 0 to 1000 and is given in %RH/10 (Relative Humidity),
-i.e. a value of 421 means that a humidity of 42.1 %RH is measured.
-*/
+//	Humidity type.
+//
+// The value has a range of 0 to 1000 and is given in %RH/10 (Relative Humidity),
+// i.e. a value of 421 means that a humidity of 42.1 %RH is measured.
 type Humidity struct {
 	Value uint16
 }
 
-// FromPacket create from a packet a Humidity.
+// FromPacket converts the packet payload to the Humidity type.
 func (h *Humidity) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(h, p); err != nil {
 		return err
@@ -98,17 +107,7 @@ func (h *Humidity) Copy() device.Resulter {
 	if h == nil {
 		return nil
 	}
-	return &Humidity{Value: h.Value}
-}
-
-// Float64 converts the humidity value from int16 to float64.
-func (h *Humidity) Float64() float64 {
-	f := float64(h.Value) / 10.00
-	return f
-}
-
-// Float32 converts the humidity value from int16 to float32.
-func (h *Humidity) Float32() float32 {
-	f := float32(h.Value) / 10.00
-	return f
+	return &Humidity{
+		Value: h.Value,
+	}
 }
@@ -0,0 +1,34 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package humidity
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Humidity Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_get_analog_value:                    {Name: "GetAnalogValue", Response: func() interface{} { return new(AnalogValue) }},
+		function_set_debounce_period:                 {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:                 {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_get_humidity:                        {Name: "GetHumidity", Response: func() interface{} { return new(Humidity) }},
+		function_set_humidity_callback_period:        {Name: "SetHumidityCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_humidity_callback_period:        {Name: "GetHumidityCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		function_set_analog_value_callback_period:    {Name: "SetAnalogValueCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_analog_value_callback_period:    {Name: "GetAnalogValueCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		callback_humidity:                            {Name: "HumidityPeriod", Response: func() interface{} { return new(Humidity) }},
+		callback_analog_value:                        {Name: "AnalogValuePeriod", Response: func() interface{} { return new(AnalogValue) }},
+		function_set_humidity_callback_threshold:     {Name: "SetHumidityCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_humidity_callback_threshold:     {Name: "GetHumidityCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		function_set_analog_value_callback_threshold: {Name: "SetAnalogValueCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_analog_value_callback_threshold: {Name: "GetAnalogValueCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		callback_humidity_reached:                    {Name: "HumidityReached", Response: func() interface{} { return new(Humidity) }},
+		callback_analog_value_reached:                {Name: "AnalogValueReached", Response: func() interface{} { return new(AnalogValue) }},
+	})
+}
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io16
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -21,7 +24,7 @@ func SetPortConfiguration(id string, uid uint32, c *Configuration, handler func(
 		Id:         device.FallbackId(id, "SetPortConfiguration"),
 		Fid:        function_set_port_configuration,
 		Uid:        uid,
-		Data:       NewConfigurationRaw(c),
+		Data:       c,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
@@ -29,17 +32,17 @@ func SetPortConfiguration(id string, uid uint32, c *Configuration, handler func(
 // SetPortConfigurationFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetPortConfigurationFuture(brick *bricker.Bricker, connectorname string, uid uint32, c *Configuration) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetPortConfiguration("setportconfigurationfuture"+device.GenId(), uid, c,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetPortConfigurationFutureContext(context.Background(), brick, connectorname, uid, c) == nil
+}
+
+// SetPortConfigurationFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetPortConfigurationFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, c *Configuration) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetPortConfiguration("setportconfigurationfuture"+device.GenId(), uid, c, nil))
+	return err
 }
 
 // GetPortConfiguration creates the subscriber to get the configuration of all pins.
@@ -53,29 +56,31 @@ func GetPortConfiguration(id string, uid uint32, po *Port, handler func(device.R
 		Result:     &Configurations{},
 		Data:       po,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetPortConfigurationFuture is a future pattern version for a synchronized all of the subscriber.
+// GetPortConfigurationFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetPortConfigurationFuture(brick *bricker.Bricker, connectorname string, uid uint32, po *Port) *Configurations {
-	future := make(chan *Configurations)
-	defer close(future)
-	sub := GetPortConfiguration("getportconfigurationfuture"+device.GenId(), uid, po,
-		func(r device.Resulter, err error) {
-			var v *Configurations = nil
-			if err == nil {
-				if value, ok := r.(*Configurations); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetPortConfigurationFutureContext(context.Background(), brick, connectorname, uid, po)
+	return v
+}
+
+// GetPortConfigurationFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetPortConfigurationFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, po *Port) (*Configurations, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetPortConfiguration("getportconfigurationfuture"+device.GenId(), uid, po, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Configurations); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Configuration is a type to set the direction and the value of the specified pin(s).
@@ -86,34 +91,13 @@ type Configuration struct {
 	Value         bool  // true - hight, pull-up; false - low, default
 }
 
-// ConfigurationRaw is the raw type of the Configuration (for de/encoding).
-type ConfigurationRaw struct {
-	Port          byte  // 'a' - port a, 'b' - port b
-	SelectionMask uint8 // bitmask (8bit)
-	Direction     byte  // 'i' - input, 'o' - output
-	Value         uint8 // 0x01 true or 0x00 false
-}
-
-// NewConfigurationRaw creates a ConfigurationRaw object from a Configuration.
-func NewConfigurationRaw(c *Configuration) *ConfigurationRaw {
-	if c == nil {
-		return nil
-	}
-	cr := new(ConfigurationRaw)
-	cr.Port = c.Port
-	cr.SelectionMask = c.SelectionMask
-	cr.Direction = c.Direction
-	cr.Value = misc.BoolToUint8(c.Value)
-	return cr
-}
-
 // Configurations is the return type for the state of the pins.
 type Configurations struct {
 	DirectionMask uint8
 	ValueMask     uint8
 }
 
-// FromPacket creates a Configurations from a packet.
+// FromPacket converts the packet payload to the Configurations type.
 func (c *Configurations) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(c, p); err != nil {
 		return err
@@ -127,9 +111,7 @@ func (c *Configurations) String() string {
 	if c == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Direction Mask: %d (%s), Value Mask: %d (%s)]",
-			c.DirectionMask, misc.MaskToString(c.DirectionMask, 8, false),
-			c.ValueMask, misc.MaskToString(c.ValueMask, 8, false))
+		txt += fmt.Sprintf("[Direction Mask: %d (%s), Value Mask: %d (%s)]", c.DirectionMask, misc.MaskToString(c.DirectionMask, 8, false), c.ValueMask, misc.MaskToString(c.ValueMask, 8, false))
 	}
 	return txt
 }
@@ -141,5 +123,6 @@ func (c *Configurations) Copy() device.Resulter {
 	}
 	return &Configurations{
 		DirectionMask: c.DirectionMask,
-		ValueMask:     c.ValueMask}
+		ValueMask:     c.ValueMask,
+	}
 }
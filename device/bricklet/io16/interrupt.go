@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io16
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -20,23 +23,24 @@ func SetPortInterrupt(id string, uid uint32, pi *PortInterrupt, handler func(dev
 		Uid:        uid,
 		Data:       pi,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetPortInterruptFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetPortInterruptFuture(brick *bricker.Bricker, connectorname string, uid uint32, pi *PortInterrupt) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetPortInterrupt("setportinterruptfuture"+device.GenId(), uid, pi,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetPortInterruptFutureContext(context.Background(), brick, connectorname, uid, pi) == nil
+}
+
+// SetPortInterruptFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetPortInterruptFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pi *PortInterrupt) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetPortInterrupt("setportinterruptfuture"+device.GenId(), uid, pi, nil))
+	return err
 }
 
 // GetPortInterrupt creates the subscriber to get the interrupt bitmask for a port.
@@ -48,29 +52,31 @@ func GetPortInterrupt(id string, uid uint32, po *Port, handler func(device.Resul
 		Result:     &Interrupt{},
 		Data:       po,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetPortInterruptFuture is a future pattern version for a synchronized all of the subscriber.
+// GetPortInterruptFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetPortInterruptFuture(brick *bricker.Bricker, connectorname string, uid uint32, po *Port) *Interrupt {
-	future := make(chan *Interrupt)
-	defer close(future)
-	sub := GetPortInterrupt("getinterruptfuture"+device.GenId(), uid, po,
-		func(r device.Resulter, err error) {
-			var v *Interrupt = nil
-			if err == nil {
-				if value, ok := r.(*Interrupt); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetPortInterruptFutureContext(context.Background(), brick, connectorname, uid, po)
+	return v
+}
+
+// GetPortInterruptFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetPortInterruptFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, po *Port) (*Interrupt, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetPortInterrupt("getportinterruptfuture"+device.GenId(), uid, po, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Interrupt); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // InterruptTrigger creates a subscriber for the interrupt callback.
@@ -93,16 +99,14 @@ type PortInterrupt struct {
 	InterruptMask uint8
 }
 
-/*
- Interrupt bitmask type.
- Interrupts are triggered on changes of the voltage level of the pin,
- i.e. changes from high to low and low to high.
-*/
+// Interrupt bitmask type.
+// Interrupts are triggered on changes of the voltage level of the pin,
+// i.e. changes from high to low and low to high.
 type Interrupt struct {
 	Mask uint8 // bitmask 8bit
 }
 
-// FromPacket creates a Interrupt from a packet.
+// FromPacket converts the packet payload to the Interrupt type.
 func (i *Interrupt) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(i, p); err != nil {
 		return err
@@ -116,8 +120,7 @@ func (i *Interrupt) String() string {
 	if i == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Mask: %d (%s)]",
-			i.Mask, misc.MaskToString(i.Mask, 8, false))
+		txt += fmt.Sprintf("[Mask: %d (%s)]", i.Mask, misc.MaskToString(i.Mask, 8, false))
 	}
 	return txt
 }
@@ -127,7 +130,9 @@ func (i *Interrupt) Copy() device.Resulter {
 	if i == nil {
 		return nil
 	}
-	return &Interrupt{Mask: i.Mask}
+	return &Interrupt{
+		Mask: i.Mask,
+	}
 }
 
 // Interrupts is the result type of the interrupt callback.
@@ -137,7 +142,7 @@ type Interrupts struct {
 	ValueMask     uint8 // bitmap 8bit
 }
 
-// FromPacket creates a Interrupts object from a packet.
+// FromPacket converts the packet payload to the Interrupts type.
 func (i *Interrupts) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(i, p); err != nil {
 		return err
@@ -151,10 +156,7 @@ func (i *Interrupts) String() string {
 	if i == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Port: %s, Interrupt Mask: %d (%s), Value Mask: %d (%s)]",
-			i.Port,
-			i.InterruptMask, misc.MaskToString(i.InterruptMask, 8, false),
-			i.ValueMask, misc.MaskToString(i.ValueMask, 8, false))
+		txt += fmt.Sprintf("[Port: %c, Interrupt Mask: %d (%s), Value Mask: %d (%s)]", i.Port, i.InterruptMask, misc.MaskToString(i.InterruptMask, 8, false), i.ValueMask, misc.MaskToString(i.ValueMask, 8, false))
 	}
 	return txt
 }
@@ -167,5 +169,6 @@ func (i *Interrupts) Copy() device.Resulter {
 	return &Interrupts{
 		Port:          i.Port,
 		InterruptMask: i.InterruptMask,
-		ValueMask:     i.ValueMask}
+		ValueMask:     i.ValueMask,
+	}
 }
@@ -2,9 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the IO-16 Bricklet.
 package io16
 
+//go:generate go run ../gen spec.json
+
 import (
 	"fmt"
 	"github.com/dirkjabl/bricker/device"
@@ -12,6 +16,9 @@ import (
 	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
+// DeviceIdentifier identifies the IO-16 Bricklet (see device/name).
+const DeviceIdentifier = uint16(28)
+
 const (
 	function_set_port               = uint8(1)
 	function_get_port               = uint8(2)
@@ -68,7 +75,7 @@ type Values struct {
 	ValueMask     uint8
 }
 
-// FromPacket creates the values bitmasks from a packet.
+// FromPacket converts the packet payload to the Values type.
 func (v *Values) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(v, p); err != nil {
 		return err
@@ -82,10 +89,7 @@ func (v *Values) String() string {
 	if v == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Port: %s, Selection Mask: %d (%s), Value Mask: %d (%s)]",
-			v.Port,
-			v.SelectionMask, misc.MaskToString(v.SelectionMask, 8, false),
-			v.ValueMask, misc.MaskToString(v.ValueMask, 8, false))
+		txt += fmt.Sprintf("[Port: %c, Selection Mask: %d (%s), Value Mask: %d (%s)]", v.Port, v.SelectionMask, misc.MaskToString(v.SelectionMask, 8, false), v.ValueMask, misc.MaskToString(v.ValueMask, 8, false))
 	}
 	return txt
 }
@@ -98,5 +102,6 @@ func (v *Values) Copy() device.Resulter {
 	return &Values{
 		Port:          v.Port,
 		SelectionMask: v.SelectionMask,
-		ValueMask:     v.ValueMask}
+		ValueMask:     v.ValueMask,
+	}
 }
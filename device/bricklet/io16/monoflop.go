@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io16
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -26,17 +29,17 @@ func SetPortMonoflop(id string, uid uint32, m *Monoflops, handler func(device.Re
 // SetPortMonoflopFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetPortMonoflopFuture(brick *bricker.Bricker, connectorname string, uid uint32, m *Monoflops) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetPortMonoflop("setportmonoflopfuture"+device.GenId(), uid, m,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetPortMonoflopFutureContext(context.Background(), brick, connectorname, uid, m) == nil
+}
+
+// SetPortMonoflopFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetPortMonoflopFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, m *Monoflops) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetPortMonoflop("setportmonoflopfuture"+device.GenId(), uid, m, nil))
+	return err
 }
 
 // GetMonoflop creates a subscriber for getting the actual monoflop value.
@@ -49,37 +52,37 @@ func GetPortMonoflop(id string, uid uint32, pp *PortPin, handler func(device.Res
 		Result:     &Monoflop{},
 		Data:       pp,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetPortMonoflopFuture is a future pattern version for a synchronized all of the subscriber.
+// GetPortMonoflopFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetPortMonoflopFuture(brick *bricker.Bricker, connectorname string, uid uint32, pp *PortPin) *Monoflop {
-	future := make(chan *Monoflop)
-	defer close(future)
-	sub := GetPortMonoflop("getportmonoflopfuture"+device.GenId(), uid, pp,
-		func(r device.Resulter, err error) {
-			var v *Monoflop = nil
-			if err == nil {
-				if value, ok := r.(*Monoflop); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetPortMonoflopFutureContext(context.Background(), brick, connectorname, uid, pp)
+	return v
+}
+
+// GetPortMonoflopFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetPortMonoflopFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pp *PortPin) (*Monoflop, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetPortMonoflop("getportmonoflopfuture"+device.GenId(), uid, pp, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Monoflop); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-MonoflopDone creates a subscriber for the monoflop done callback.
-This callback is triggered whenever a monoflop timer reaches 0.
-The response values contain the involved pins and the current value of the pins
-(the value after the monoflop).
-*/
+// MonoflopDone creates a subscriber for the monoflop done callback.
+// This callback is triggered whenever a monoflop timer reaches 0.
+// The response values contain the involved pins and the current value of the pins
+// (the value after the monoflop).
 func MonoflopDone(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "MonoflopDone"),
@@ -109,7 +112,7 @@ type Monoflop struct {
 	TimeRemaining uint32 // in ms
 }
 
-// FromPacket creates a Monoflop from a packet.
+// FromPacket converts the packet payload to the Monoflop type.
 func (m *Monoflop) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -123,8 +126,7 @@ func (m *Monoflop) String() string {
 	if m == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Value: %d, Time: %d ms, Time Remaining: %d ms]",
-			m.Value, m.Time, m.TimeRemaining)
+		txt += fmt.Sprintf("[Value: %d, Time: %d ms, Time Remaining: %d ms]", m.Value, m.Time, m.TimeRemaining)
 	}
 	return txt
 }
@@ -137,7 +139,8 @@ func (m *Monoflop) Copy() device.Resulter {
 	return &Monoflop{
 		Value:         m.Value,
 		Time:          m.Time,
-		TimeRemaining: m.TimeRemaining}
+		TimeRemaining: m.TimeRemaining,
+	}
 }
 
 // PortPin is a type to select port and pin.
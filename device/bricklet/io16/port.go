@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io16
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -12,12 +15,10 @@ import (
 	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
-/*
-SetPort creates a subscriber to set a value mask (bitmap 8bit) to a port.
-
-This function does nothing for pins that are configured as input.
-Pull-up resistors can be switched on with SetPortConfiguration.
-*/
+// SetPort creates a subscriber to set a value mask (bitmap 8bit) to a port.
+//
+// This function does nothing for pins that are configured as input.
+// Pull-up resistors can be switched on with SetPortConfiguration.
 func SetPort(id string, uid uint32, pv *PortValue, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetPort"),
@@ -31,17 +32,17 @@ func SetPort(id string, uid uint32, pv *PortValue, handler func(device.Resulter,
 // SetPortFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetPortFuture(brick *bricker.Bricker, connectorname string, uid uint32, pv *PortValue) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetPort("setportinterruptfuture"+device.GenId(), uid, pv,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetPortFutureContext(context.Background(), brick, connectorname, uid, pv) == nil
+}
+
+// SetPortFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetPortFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pv *PortValue) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetPort("setportfuture"+device.GenId(), uid, pv, nil))
+	return err
 }
 
 // GetPort creates a subscriber to get the value bitmask (8bit) for a port.
@@ -54,38 +55,37 @@ func GetPort(id string, uid uint32, po *Port, handler func(device.Resulter, erro
 		Result:     &Value{},
 		Data:       po,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetPortFuture is a future pattern version for a synchronized all of the subscriber.
+// GetPortFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetPortFuture(brick *bricker.Bricker, connectorname string, uid uint32, po *Port) *Value {
-	future := make(chan *Value)
-	defer close(future)
-	sub := GetPort("getportfuture"+device.GenId(), uid, po,
-		func(r device.Resulter, err error) {
-			var v *Value = nil
-			if err == nil {
-				if value, ok := r.(*Value); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetPortFutureContext(context.Background(), brick, connectorname, uid, po)
+	return v
+}
+
+// GetPortFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetPortFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, po *Port) (*Value, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetPort("getportfuture"+device.GenId(), uid, po, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Value); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-PortValue is for setting a value mask for a specific port.
-Ports could only be 'a' or 'b'.
-The bitmask has a size of 8bit.
-A value of 1 in the bitmask means 'hight' and a 0 is 'low'.
-
-*/
+// PortValue is for setting a value mask for a specific port.
+// Ports could only be 'a' or 'b'.
+// The bitmask has a size of 8bit.
+// A value of 1 in the bitmask means 'hight' and a 0 is 'low'.
 type PortValue struct {
 	Port      byte
 	ValueMask uint8
@@ -96,7 +96,7 @@ type Value struct {
 	Mask uint8
 }
 
-// FromPacket creates a Value from a packet.
+// FromPacket converts the packet payload to the Value type.
 func (v *Value) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(v, p); err != nil {
 		return err
@@ -120,5 +120,7 @@ func (v *Value) Copy() device.Resulter {
 	if v == nil {
 		return nil
 	}
-	return &Value{Mask: v.Mask}
+	return &Value{
+		Mask: v.Mask,
+	}
 }
@@ -0,0 +1,33 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package io16
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the IO-16 Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_set_port_configuration: {Name: "SetPortConfiguration", Request: func() interface{} { return new(Configuration) }},
+		function_get_port_configuration: {Name: "GetPortConfiguration", Request: func() interface{} { return new(Port) }, Response: func() interface{} { return new(Configurations) }},
+		function_set_debounce_period:    {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:    {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_get_edge_count:         {Name: "GetEdgeCount", Request: func() interface{} { return new(EdgeCount) }, Response: func() interface{} { return new(EdgeCounts) }},
+		function_set_edge_count_config:  {Name: "SetEdgeCountConfig", Request: func() interface{} { return new(EdgeCountConfigs) }},
+		function_get_edge_count_config:  {Name: "GetEdgeCountConfig", Request: func() interface{} { return new(Pin) }, Response: func() interface{} { return new(EdgeCountConfig) }},
+		function_set_port_interrupt:     {Name: "SetPortInterrupt", Request: func() interface{} { return new(PortInterrupt) }},
+		function_get_port_interrupt:     {Name: "GetPortInterrupt", Request: func() interface{} { return new(Port) }, Response: func() interface{} { return new(Interrupt) }},
+		callback_interrupt:              {Name: "InterruptTrigger", Response: func() interface{} { return new(Interrupts) }},
+		function_set_port_monoflop:      {Name: "SetPortMonoflop", Request: func() interface{} { return new(Monoflops) }},
+		function_get_port_monoflop:      {Name: "GetPortMonoflop", Request: func() interface{} { return new(PortPin) }, Response: func() interface{} { return new(Monoflop) }},
+		callback_monoflop_done:          {Name: "MonoflopDone", Response: func() interface{} { return new(Values) }},
+		function_set_port:               {Name: "SetPort", Request: func() interface{} { return new(PortValue) }},
+		function_get_port:               {Name: "GetPort", Request: func() interface{} { return new(Port) }, Response: func() interface{} { return new(Value) }},
+	})
+}
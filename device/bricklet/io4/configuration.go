@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -20,7 +23,7 @@ func SetConfiguration(id string, uid uint32, c *Configuration, handler func(devi
 		Id:         device.FallbackId(id, "SetConfiguration"),
 		Fid:        function_set_configuration,
 		Uid:        uid,
-		Data:       NewConfigurationRaw(c),
+		Data:       c,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
@@ -28,17 +31,17 @@ func SetConfiguration(id string, uid uint32, c *Configuration, handler func(devi
 // SetConfigurationFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetConfigurationFuture(brick *bricker.Bricker, connectorname string, uid uint32, c *Configuration) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetConfiguration("setconfigurationfuture"+device.GenId(), uid, c,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetConfigurationFutureContext(context.Background(), brick, connectorname, uid, c) == nil
+}
+
+// SetConfigurationFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetConfigurationFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, c *Configuration) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetConfiguration("setconfigurationfuture"+device.GenId(), uid, c, nil))
+	return err
 }
 
 // GetConfiguration creates the subscriber to get the configuration of all pins.
@@ -51,29 +54,31 @@ func GetConfiguration(id string, uid uint32, handler func(device.Resulter, error
 		Uid:        uid,
 		Result:     &Configurations{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetConfigurationFuture is a future pattern version for a synchronized all of the subscriber.
+// GetConfigurationFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetConfigurationFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Configurations {
-	future := make(chan *Configurations)
-	defer close(future)
-	sub := GetConfiguration("getconfigurationfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Configurations = nil
-			if err == nil {
-				if value, ok := r.(*Configurations); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetConfigurationFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetConfigurationFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetConfigurationFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Configurations, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetConfiguration("getconfigurationfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Configurations); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Configuration is a type to set the direction and the value of the specified pin(s).
@@ -83,32 +88,13 @@ type Configuration struct {
 	Value         bool  // true - hight, pull-up; false - low, default
 }
 
-// ConfigurationRaw is the raw type of the Configuration (for de/encoding).
-type ConfigurationRaw struct {
-	SelectionMask uint8 // bitmask (4bit)
-	Direction     byte  // 'i' - input, 'o' - output
-	Value         uint8 // 0x01 true or 0x00 false
-}
-
-// NewConfigurationRaw creates a ConfigurationRaw object from a Configuration.
-func NewConfigurationRaw(c *Configuration) *ConfigurationRaw {
-	if c == nil {
-		return nil
-	}
-	cr := new(ConfigurationRaw)
-	cr.SelectionMask = c.SelectionMask
-	cr.Direction = c.Direction
-	cr.Value = misc.BoolToUint8(c.Value)
-	return cr
-}
-
 // Configurations is the return type for the state of the pins.
 type Configurations struct {
 	DirectionMask uint8
 	ValueMask     uint8
 }
 
-// FromPacket creates a Configurations from a packet.
+// FromPacket converts the packet payload to the Configurations type.
 func (c *Configurations) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(c, p); err != nil {
 		return err
@@ -122,9 +108,7 @@ func (c *Configurations) String() string {
 	if c == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Direction Mask: %d (%s), Value Mask: %d (%s)]",
-			c.DirectionMask, misc.MaskToString(c.DirectionMask, 4, true),
-			c.ValueMask, misc.MaskToString(c.ValueMask, 4, true))
+		txt += fmt.Sprintf("[Direction Mask: %d (%s), Value Mask: %d (%s)]", c.DirectionMask, misc.MaskToString(c.DirectionMask, 4, true), c.ValueMask, misc.MaskToString(c.ValueMask, 4, true))
 	}
 	return txt
 }
@@ -136,5 +120,6 @@ func (c *Configurations) Copy() device.Resulter {
 	}
 	return &Configurations{
 		DirectionMask: c.DirectionMask,
-		ValueMask:     c.ValueMask}
+		ValueMask:     c.ValueMask,
+	}
 }
@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 func GetEdgeCount(id string, uid uint32, ec *EdgeCount, handler func(device.Resulter, error)) *device.Device {
@@ -18,31 +20,32 @@ func GetEdgeCount(id string, uid uint32, ec *EdgeCount, handler func(device.Resu
 		Fid:        function_get_edge_count,
 		Uid:        uid,
 		Result:     &EdgeCounts{},
-		Data:       NewEdgeCountRaw(ec),
+		Data:       ec,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetEdgeCountFuture is a future pattern version for a synchronized all of the subscriber.
+// GetEdgeCountFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetEdgeCountFuture(brick *bricker.Bricker, connectorname string, uid uint32, ec *EdgeCount) *EdgeCounts {
-	future := make(chan *EdgeCounts)
-	defer close(future)
-	sub := GetEdgeCount("getedgecountfuture"+device.GenId(), uid, ec,
-		func(r device.Resulter, err error) {
-			var v *EdgeCounts = nil
-			if err == nil {
-				if value, ok := r.(*EdgeCounts); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetEdgeCountFutureContext(context.Background(), brick, connectorname, uid, ec)
+	return v
+}
+
+// GetEdgeCountFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetEdgeCountFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, ec *EdgeCount) (*EdgeCounts, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetEdgeCount("getedgecountfuture"+device.GenId(), uid, ec, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*EdgeCounts); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetEdgeCountConfig creates the subscriber to configure the edge counter for the selected pins.
@@ -62,17 +65,17 @@ func SetEdgeCountConfig(id string, uid uint32, e *SelectedEdgeCountConfig, handl
 // SetEdgeCountConfigFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetEdgeCountConfigFuture(brick *bricker.Bricker, connectorname string, uid uint32, e *SelectedEdgeCountConfig) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetEdgeCountConfig("setedgecountconfigfuture"+device.GenId(), uid, e,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetEdgeCountConfigFutureContext(context.Background(), brick, connectorname, uid, e) == nil
+}
+
+// SetEdgeCountConfigFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetEdgeCountConfigFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, e *SelectedEdgeCountConfig) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetEdgeCountConfig("setedgecountconfigfuture"+device.GenId(), uid, e, nil))
+	return err
 }
 
 // GetEdgeCountConfig creates a subscriber for getting the actual edge count configurations.
@@ -84,29 +87,31 @@ func GetEdgeCountConfig(id string, uid uint32, pin *Pin, handler func(device.Res
 		Result:     &EdgeCountConfig{},
 		Data:       pin,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetEdgeCountConfigFuture is a future pattern version for a synchronized all of the subscriber.
+// GetEdgeCountConfigFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetEdgeCountConfigFuture(brick *bricker.Bricker, connectorname string, uid uint32, pin *Pin) *EdgeCountConfig {
-	future := make(chan *EdgeCountConfig)
-	defer close(future)
-	sub := GetEdgeCountConfig("getedgecountconfigfuture"+device.GenId(), uid, pin,
-		func(r device.Resulter, err error) {
-			var v *EdgeCountConfig = nil
-			if err == nil {
-				if value, ok := r.(*EdgeCountConfig); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetEdgeCountConfigFutureContext(context.Background(), brick, connectorname, uid, pin)
+	return v
+}
+
+// GetEdgeCountConfigFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetEdgeCountConfigFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pin *Pin) (*EdgeCountConfig, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetEdgeCountConfig("getedgecountconfigfuture"+device.GenId(), uid, pin, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*EdgeCountConfig); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // EdgeCount is the type for GetEdgeCount.
@@ -115,29 +120,12 @@ type EdgeCount struct {
 	ResetCounter bool  // reset the counter directly after call
 }
 
-// EdgeCountRaw is a de/encoding type for EdgeCount.
-type EdgeCountRaw struct {
-	Pin          uint8
-	ResetCounter uint8
-}
-
-// NewEdgeCountRaw creates a EdgeCountRaw from a EdgeCount.
-func NewEdgeCountRaw(ec *EdgeCount) *EdgeCountRaw {
-	if ec == nil {
-		return nil
-	}
-	ecr := new(EdgeCountRaw)
-	ecr.Pin = ec.Pin
-	ecr.ResetCounter = misc.BoolToUint8(ec.ResetCounter)
-	return ecr
-}
-
 // The value of the EdgeCount
 type EdgeCounts struct {
 	Value uint32
 }
 
-// Converts a packet to a EdgeCounts type.
+// FromPacket converts the packet payload to the EdgeCounts type.
 func (e *EdgeCounts) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(e, p); err != nil {
 		return err
@@ -161,7 +149,9 @@ func (e *EdgeCounts) Copy() device.Resulter {
 	if e == nil {
 		return nil
 	}
-	return &EdgeCounts{Value: e.Value}
+	return &EdgeCounts{
+		Value: e.Value,
+	}
 }
 
 // EdgeCountConfig type for configurate the edge count.
@@ -170,7 +160,7 @@ type EdgeCountConfig struct {
 	Debounce uint8 // in ms
 }
 
-// FromPacket creates a edge count configurations from a packet.
+// FromPacket converts the packet payload to the EdgeCountConfig type.
 func (ecc *EdgeCountConfig) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(ecc, p); err != nil {
 		return err
@@ -184,26 +174,11 @@ func (ecc *EdgeCountConfig) String() string {
 	if ecc == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Edge Type: %d (%s), Debounce: %d ms]",
-			ecc.Type, EdgeTypeName(ecc.Type), ecc.Debounce)
+		txt += fmt.Sprintf("[Edge Type: %d (%s), Debounce: %d ms]", ecc.Type, EdgeTypeName(ecc.Type), ecc.Debounce)
 	}
 	return txt
 }
 
-// EdgeTypeName converts the numeric edge type to a string reprensentation.
-func EdgeTypeName(t uint8) string {
-	switch t {
-	case EdgeCountType_Rising:
-		return "Rising"
-	case EdgeCountType_Falling:
-		return "Falling"
-	case EdgeCountType_Both:
-		return "Both"
-	default:
-		return "Unknown"
-	}
-}
-
 // Copy creates a copy of the content.
 func (ecc *EdgeCountConfig) Copy() device.Resulter {
 	if ecc == nil {
@@ -211,7 +186,8 @@ func (ecc *EdgeCountConfig) Copy() device.Resulter {
 	}
 	return &EdgeCountConfig{
 		Type:     ecc.Type,
-		Debounce: ecc.Debounce}
+		Debounce: ecc.Debounce,
+	}
 }
 
 // SelectedEdgeCountConfig type for select a edge count configuration.
@@ -0,0 +1,19 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package io4
+
+// EdgeTypeName converts the numeric edge type to a string reprensentation.
+func EdgeTypeName(t uint8) string {
+	switch t {
+	case EdgeCountType_Rising:
+		return "Rising"
+	case EdgeCountType_Falling:
+		return "Falling"
+	case EdgeCountType_Both:
+		return "Both"
+	default:
+		return "Unknown"
+	}
+}
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -20,23 +23,25 @@ func SetInterrupt(id string, uid uint32, i *Interrupt, handler func(device.Resul
 		Uid:        uid,
 		Data:       i,
 		Handler:    handler,
+		Restore:    true,
+		Disable:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetInterruptFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetInterruptFuture(brick *bricker.Bricker, connectorname string, uid uint32, i *Interrupt) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetInterrupt("setinterruptfuture"+device.GenId(), uid, i,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetInterruptFutureContext(context.Background(), brick, connectorname, uid, i) == nil
+}
+
+// SetInterruptFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetInterruptFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, i *Interrupt) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetInterrupt("setinterruptfuture"+device.GenId(), uid, i, nil))
+	return err
 }
 
 // GetInterrupt creates the subscriber to get the interrupt bitmask.
@@ -47,29 +52,31 @@ func GetInterrupt(id string, uid uint32, handler func(device.Resulter, error)) *
 		Uid:        uid,
 		Result:     &Interrupt{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetInterruptFuture is a future pattern version for a synchronized all of the subscriber.
+// GetInterruptFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetInterruptFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Interrupt {
-	future := make(chan *Interrupt)
-	defer close(future)
-	sub := GetInterrupt("getinterruptfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Interrupt = nil
-			if err == nil {
-				if value, ok := r.(*Interrupt); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetInterruptFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetInterruptFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetInterruptFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Interrupt, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetInterrupt("getinterruptfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Interrupt); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // InterruptTrigger creates a subscriber for the interrupt callback.
@@ -93,7 +100,7 @@ type Interrupt struct {
 	Mask uint8 // bitmask 4bit
 }
 
-// FromPacket creates a Interrupt from a packet.
+// FromPacket converts the packet payload to the Interrupt type.
 func (i *Interrupt) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(i, p); err != nil {
 		return err
@@ -107,8 +114,7 @@ func (i *Interrupt) String() string {
 	if i == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Mask: %d (%s)]",
-			i.Mask, misc.MaskToString(i.Mask, 4, true))
+		txt += fmt.Sprintf("[Mask: %d (%s)]", i.Mask, misc.MaskToString(i.Mask, 4, true))
 	}
 	return txt
 }
@@ -118,7 +124,9 @@ func (i *Interrupt) Copy() device.Resulter {
 	if i == nil {
 		return nil
 	}
-	return &Interrupt{Mask: i.Mask}
+	return &Interrupt{
+		Mask: i.Mask,
+	}
 }
 
 // Interrupts is the result type of the interrupt callback.
@@ -127,7 +135,7 @@ type Interrupts struct {
 	ValueMask     uint8 // bitmap 4nit
 }
 
-// FromPacket creates a Interrupts object from a packet.
+// FromPacket converts the packet payload to the Interrupts type.
 func (i *Interrupts) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(i, p); err != nil {
 		return err
@@ -141,9 +149,7 @@ func (i *Interrupts) String() string {
 	if i == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Interrupt Mask: %d (%s), Value Mask: %d (%s)]",
-			i.InterruptMask, misc.MaskToString(i.InterruptMask, 4, true),
-			i.ValueMask, misc.MaskToString(i.ValueMask, 4, true))
+		txt += fmt.Sprintf("[Interrupt Mask: %d (%s), Value Mask: %d (%s)]", i.InterruptMask, misc.MaskToString(i.InterruptMask, 4, true), i.ValueMask, misc.MaskToString(i.ValueMask, 4, true))
 	}
 	return txt
 }
@@ -155,5 +161,6 @@ func (i *Interrupts) Copy() device.Resulter {
 	}
 	return &Interrupts{
 		InterruptMask: i.InterruptMask,
-		ValueMask:     i.ValueMask}
+		ValueMask:     i.ValueMask,
+	}
 }
@@ -2,9 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the IO-4 Bricklet.
 package io4
 
+//go:generate go run ../gen spec.json
+
 import (
 	"fmt"
 	"github.com/dirkjabl/bricker/device"
@@ -12,6 +16,9 @@ import (
 	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
+// DeviceIdentifier identifies the IO-4 Bricklet (see device/name).
+const DeviceIdentifier = uint16(29)
+
 const (
 	function_set_value             = uint8(1)
 	function_get_value             = uint8(2)
@@ -55,7 +62,7 @@ type Values struct {
 	ValueMask     uint8
 }
 
-// FromPacket creates the values bitmasks from a packet.
+// FromPacket converts the packet payload to the Values type.
 func (v *Values) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(v, p); err != nil {
 		return err
@@ -69,9 +76,7 @@ func (v *Values) String() string {
 	if v == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Selection Mask: %d (%s), Value Mask: %d (%s)]",
-			v.SelectionMask, misc.MaskToString(v.SelectionMask, 4, true),
-			v.ValueMask, misc.MaskToString(v.ValueMask, 4, true))
+		txt += fmt.Sprintf("[Selection Mask: %d (%s), Value Mask: %d (%s)]", v.SelectionMask, misc.MaskToString(v.SelectionMask, 4, true), v.ValueMask, misc.MaskToString(v.ValueMask, 4, true))
 	}
 	return txt
 }
@@ -83,5 +88,6 @@ func (v *Values) Copy() device.Resulter {
 	}
 	return &Values{
 		SelectionMask: v.SelectionMask,
-		ValueMask:     v.ValueMask}
+		ValueMask:     v.ValueMask,
+	}
 }
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -25,17 +28,17 @@ func SetMonoflop(id string, uid uint32, m *Monoflops, handler func(device.Result
 // SetMonoflopFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetMonoflopFuture(brick *bricker.Bricker, connectorname string, uid uint32, m *Monoflops) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetMonoflop("setmonoflopfuture"+device.GenId(), uid, m,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetMonoflopFutureContext(context.Background(), brick, connectorname, uid, m) == nil
+}
+
+// SetMonoflopFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetMonoflopFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, m *Monoflops) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetMonoflop("setmonoflopfuture"+device.GenId(), uid, m, nil))
+	return err
 }
 
 // GetMonoflop creates a subscriber for getting the actual monoflop value.
@@ -47,37 +50,37 @@ func GetMonoflop(id string, uid uint32, pin *Pin, handler func(device.Resulter,
 		Result:     &Monoflop{},
 		Data:       pin,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetMonoflopFuture is a future pattern version for a synchronized all of the subscriber.
+// GetMonoflopFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetMonoflopFuture(brick *bricker.Bricker, connectorname string, uid uint32, pin *Pin) *Monoflop {
-	future := make(chan *Monoflop)
-	defer close(future)
-	sub := GetMonoflop("getmonoflopfuture"+device.GenId(), uid, pin,
-		func(r device.Resulter, err error) {
-			var v *Monoflop = nil
-			if err == nil {
-				if value, ok := r.(*Monoflop); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetMonoflopFutureContext(context.Background(), brick, connectorname, uid, pin)
+	return v
+}
+
+// GetMonoflopFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMonoflopFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pin *Pin) (*Monoflop, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMonoflop("getmonoflopfuture"+device.GenId(), uid, pin, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Monoflop); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-MonoflopDone creates a subscriber for the monoflop done callback.
-This callback is triggered whenever a monoflop timer reaches 0.
-The response values contain the involved pins and the current value of the pins
-(the value after the monoflop).
-*/
+// MonoflopDone creates a subscriber for the monoflop done callback.
+// This callback is triggered whenever a monoflop timer reaches 0.
+// The response values contain the involved pins and the current value of the pins
+// (the value after the monoflop).
 func MonoflopDone(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "MonoflopDone"),
@@ -106,7 +109,7 @@ type Monoflop struct {
 	TimeRemaining uint32 // in ms
 }
 
-// FromPacket creates a Monoflop from a packet.
+// FromPacket converts the packet payload to the Monoflop type.
 func (m *Monoflop) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -120,8 +123,7 @@ func (m *Monoflop) String() string {
 	if m == nil {
 		txt += "[nil]"
 	} else {
-		txt += fmt.Sprintf("[Value: %d, Time: %d ms, Time Remaining: %d ms]",
-			m.Value, m.Time, m.TimeRemaining)
+		txt += fmt.Sprintf("[Value: %d, Time: %d ms, Time Remaining: %d ms]", m.Value, m.Time, m.TimeRemaining)
 	}
 	return txt
 }
@@ -134,5 +136,6 @@ func (m *Monoflop) Copy() device.Resulter {
 	return &Monoflop{
 		Value:         m.Value,
 		Time:          m.Time,
-		TimeRemaining: m.TimeRemaining}
+		TimeRemaining: m.TimeRemaining,
+	}
 }
@@ -0,0 +1,55 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The raw types are kept for compatibility, the bool fields of the types are encoded directly.
+
+package io4
+
+import (
+	misc "github.com/dirkjabl/bricker/util/miscellaneous"
+)
+
+// ConfigurationRaw is the raw type of the Configuration (for de/encoding).
+//
+// Deprecated: Configuration is encoded directly.
+type ConfigurationRaw struct {
+	SelectionMask uint8 // bitmask (4bit)
+	Direction     byte  // 'i' - input, 'o' - output
+	Value         uint8 // 0x01 true or 0x00 false
+}
+
+// NewConfigurationRaw creates a ConfigurationRaw object from a Configuration.
+//
+// Deprecated: Configuration is encoded directly.
+func NewConfigurationRaw(c *Configuration) *ConfigurationRaw {
+	if c == nil {
+		return nil
+	}
+	cr := new(ConfigurationRaw)
+	cr.SelectionMask = c.SelectionMask
+	cr.Direction = c.Direction
+	cr.Value = misc.BoolToUint8(c.Value)
+	return cr
+}
+
+// EdgeCountRaw is a de/encoding type for EdgeCount.
+//
+// Deprecated: EdgeCount is encoded directly.
+type EdgeCountRaw struct {
+	Pin          uint8
+	ResetCounter uint8
+}
+
+// NewEdgeCountRaw creates a EdgeCountRaw from a EdgeCount.
+//
+// Deprecated: EdgeCount is encoded directly.
+func NewEdgeCountRaw(ec *EdgeCount) *EdgeCountRaw {
+	if ec == nil {
+		return nil
+	}
+	ecr := new(EdgeCountRaw)
+	ecr.Pin = ec.Pin
+	ecr.ResetCounter = misc.BoolToUint8(ec.ResetCounter)
+	return ecr
+}
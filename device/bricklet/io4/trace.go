@@ -0,0 +1,34 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package io4
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the IO-4 Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_set_configuration:     {Name: "SetConfiguration", Request: func() interface{} { return new(Configuration) }},
+		function_get_configuration:     {Name: "GetConfiguration", Response: func() interface{} { return new(Configurations) }},
+		function_set_debounce_period:   {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:   {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_get_edge_count:        {Name: "GetEdgeCount", Request: func() interface{} { return new(EdgeCount) }, Response: func() interface{} { return new(EdgeCounts) }},
+		function_set_edge_count_config: {Name: "SetEdgeCountConfig", Request: func() interface{} { return new(SelectedEdgeCountConfig) }},
+		function_get_edge_count_config: {Name: "GetEdgeCountConfig", Request: func() interface{} { return new(Pin) }, Response: func() interface{} { return new(EdgeCountConfig) }},
+		function_set_interrupt:         {Name: "SetInterrupt", Request: func() interface{} { return new(Interrupt) }},
+		function_get_interrupt:         {Name: "GetInterrupt", Response: func() interface{} { return new(Interrupt) }},
+		callback_interrupt:             {Name: "InterruptTrigger", Response: func() interface{} { return new(Interrupts) }},
+		function_set_monoflop:          {Name: "SetMonoflop", Request: func() interface{} { return new(Monoflops) }},
+		function_get_monoflop:          {Name: "GetMonoflop", Request: func() interface{} { return new(Pin) }, Response: func() interface{} { return new(Monoflop) }},
+		callback_monoflop_done:         {Name: "MonoflopDone", Response: func() interface{} { return new(Values) }},
+		function_set_value:             {Name: "SetValue", Request: func() interface{} { return new(Value) }},
+		function_get_value:             {Name: "GetValue", Response: func() interface{} { return new(Value) }},
+		function_set_selected_values:   {Name: "SetSelectedValues", Request: func() interface{} { return new(Values) }},
+	})
+}
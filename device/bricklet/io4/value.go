@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package io4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -31,17 +34,17 @@ func SetValue(id string, uid uint32, v *Value, handler func(device.Resulter, err
 // SetValueFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetValueFuture(brick *bricker.Bricker, connectorname string, uid uint32, v *Value) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetValue("setvaluefuture"+device.GenId(), uid, v,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetValueFutureContext(context.Background(), brick, connectorname, uid, v) == nil
+}
+
+// SetValueFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetValueFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, v *Value) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetValue("setvaluefuture"+device.GenId(), uid, v, nil))
+	return err
 }
 
 // GetValue creates the subscriber to get the output value.
@@ -52,29 +55,31 @@ func GetValue(id string, uid uint32, handler func(device.Resulter, error)) *devi
 		Uid:        uid,
 		Result:     &Value{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetValueFuture is a future pattern version for a synchronized all of the subscriber.
+// GetValueFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetValueFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Value {
-	future := make(chan *Value)
-	defer close(future)
-	sub := GetValue("getvaluefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Value = nil
-			if err == nil {
-				if value, ok := r.(*Value); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetValueFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetValueFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetValueFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Value, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetValue("getvaluefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Value); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetSelectedValues creates a subscriber for setting values per bitmap (4bit).
@@ -93,17 +98,17 @@ func SetSelectedValues(id string, uid uint32, v *Values, handler func(device.Res
 // SetSelectedValuesFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetSelectedValuesFuture(brick *bricker.Bricker, connectorname string, uid uint32, v *Values) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetSelectedValues("setselectedvalues"+device.GenId(), uid, v,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetSelectedValuesFutureContext(context.Background(), brick, connectorname, uid, v) == nil
+}
+
+// SetSelectedValuesFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetSelectedValuesFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, v *Values) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetSelectedValues("setselectedvaluesfuture"+device.GenId(), uid, v, nil))
+	return err
 }
 
 // Value is the type for the output bitmap mask (4bit).
@@ -111,7 +116,7 @@ type Value struct {
 	Mask uint8
 }
 
-// FromPacket creates a Value from a packet.
+// FromPacket converts the packet payload to the Value type.
 func (v *Value) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(v, p); err != nil {
 		return err
@@ -135,5 +140,7 @@ func (v *Value) Copy() device.Resulter {
 	if v == nil {
 		return nil
 	}
-	return &Value{Mask: v.Mask}
+	return &Value{
+		Mask: v.Mask,
+	}
 }
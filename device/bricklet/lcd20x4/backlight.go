@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 func BacklightOn(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
@@ -21,19 +23,20 @@ func BacklightOn(id string, uid uint32, handler func(device.Resulter, error)) *d
 		WithPacket: true}.CreateDevice()
 }
 
+// BacklightOnFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is false.
 func BacklightOnFuture(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	future := make(chan bool)
-	sub := BacklightOn("backlightonfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	b := <-future
-	close(future)
-	return b
+	return BacklightOnFutureContext(context.Background(), brick, connectorname, uid) == nil
+}
+
+// BacklightOnFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func BacklightOnFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) error {
+	_, err := device.Future(ctx, brick, dest,
+		BacklightOn("backlightonfuture"+device.GenId(), uid, nil))
+	return err
 }
 
 func BacklightOff(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
@@ -45,19 +48,20 @@ func BacklightOff(id string, uid uint32, handler func(device.Resulter, error)) *
 		WithPacket: true}.CreateDevice()
 }
 
+// BacklightOffFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is false.
 func BacklightOffFuture(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	future := make(chan bool)
-	sub := BacklightOff("backlightofffuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	b := <-future
-	close(future)
-	return b
+	return BacklightOffFutureContext(context.Background(), brick, connectorname, uid) == nil
+}
+
+// BacklightOffFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func BacklightOffFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) error {
+	_, err := device.Future(ctx, brick, dest,
+		BacklightOff("backlightofffuture"+device.GenId(), uid, nil))
+	return err
 }
 
 func IsBacklightOn(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
@@ -67,36 +71,31 @@ func IsBacklightOn(id string, uid uint32, handler func(device.Resulter, error))
 		Uid:        uid,
 		Result:     &Backlight{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
+// IsBacklightOnFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is nil.
 func IsBacklightOnFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Backlight {
-	future := make(chan *Backlight)
-	sub := IsBacklightOn("isbacklightonfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Backlight = nil
-			if err == nil {
-				if value, ok := r.(*Backlight); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return nil
-	}
-	v := <-future
-	close(future)
+	v, _ := IsBacklightOnFutureContext(context.Background(), brick, connectorname, uid)
 	return v
 }
 
-func IsBacklightOnFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	bl := IsBacklightOnFuture(brick, connectorname, uid)
-	if bl != nil && bl.IsOn {
-		return true
+// IsBacklightOnFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func IsBacklightOnFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Backlight, error) {
+	res, err := device.Future(ctx, brick, dest,
+		IsBacklightOn("isbacklightonfuture"+device.GenId(), uid, nil))
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := res.(*Backlight); ok {
+		return v, nil
 	}
-	return false
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Backlight is a type for the return of the IsBacklightOn subscriber.
@@ -109,21 +108,16 @@ func (bl *Backlight) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(bl, p); err != nil {
 		return err
 	}
-	blr := new(BacklightRaw)
-	err := p.Payload.Decode(blr)
-	if err == nil {
-		bl.FromBacklightRaw(blr)
-	}
-	return err
+	return p.Payload.Decode(bl)
 }
 
 // String fullfill the stringer interface.
 func (bl *Backlight) String() string {
 	txt := "Backlight "
-	if bl != nil {
-		txt += fmt.Sprintf("[IsOn: %t]", bl.IsOn)
-	} else {
+	if bl == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[IsOn: %t]", bl.IsOn)
 	}
 	return txt
 }
@@ -133,18 +127,7 @@ func (bl *Backlight) Copy() device.Resulter {
 	if bl == nil {
 		return nil
 	}
-	return &Backlight{IsOn: bl.IsOn}
-}
-
-// FromBacklightRaw converts a BacklightRaw into a Backlight.
-func (bl *Backlight) FromBacklightRaw(br *BacklightRaw) {
-	if bl == nil || br == nil {
-		return
+	return &Backlight{
+		IsOn: bl.IsOn,
 	}
-	bl.IsOn = misc.Uint8ToBool(br.IsOn)
-}
-
-// BacklightRaw is a type for raw coding of the backlight.
-type BacklightRaw struct {
-	IsOn uint8
 }
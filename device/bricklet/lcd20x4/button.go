@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 // IsButtonPressed creates a subscriber to get the information, if a specific button is pressed.
@@ -21,40 +23,31 @@ func IsButtonPressed(id string, uid uint32, button *Button, handler func(device.
 		Result:     &Pressed{},
 		Data:       button,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // IsButtonPressedFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func IsButtonPressedFuture(brick *bricker.Bricker, connectorname string, uid uint32, button *Button) *Pressed {
-	future := make(chan *Pressed)
-	defer close(future)
-	sub := IsButtonPressed("isbuttonpressedfuture"+device.GenId(), uid, button,
-		func(r device.Resulter, err error) {
-			var v *Pressed = nil
-			if err == nil {
-				if value, ok := r.(*Pressed); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return nil
-	}
-	v := <-future
+	v, _ := IsButtonPressedFutureContext(context.Background(), brick, connectorname, uid, button)
 	return v
 }
 
-// IsButtonPressedFutureSimple calls the IsButtonPressedFuture method with a simple boolean result.
-// If it fails, the result is false.
-func IsButtonPressedFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32, button *Button) bool {
-	p := IsButtonPressedFuture(brick, connectorname, uid, button)
-	if p == nil {
-		return false
+// IsButtonPressedFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func IsButtonPressedFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, button *Button) (*Pressed, error) {
+	res, err := device.Future(ctx, brick, dest,
+		IsButtonPressed("isbuttonpressedfuture"+device.GenId(), uid, button, nil))
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := res.(*Pressed); ok {
+		return v, nil
 	}
-	return p.IsPressed
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // ButtonPressed creates a subscriber for the button pressed callback.
@@ -98,10 +91,10 @@ func (b *Button) FromPacket(p *packet.Packet) error {
 // String fullfill the stringer interface.
 func (b *Button) String() string {
 	txt := "Button "
-	if b != nil {
-		txt += fmt.Sprintf("[Number: %d]", b.Number)
-	} else {
+	if b == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Number: %d]", b.Number)
 	}
 	return txt
 }
@@ -111,7 +104,9 @@ func (b *Button) Copy() device.Resulter {
 	if b == nil {
 		return nil
 	}
-	return &Button{Number: b.Number}
+	return &Button{
+		Number: b.Number,
+	}
 }
 
 // Pressed is a type for the return of the IsButtonPressed subscriber.
@@ -124,21 +119,16 @@ func (pr *Pressed) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(pr, p); err != nil {
 		return err
 	}
-	prr := new(PressedRaw)
-	err := p.Payload.Decode(prr)
-	if err == nil {
-		pr.FromPressedRaw(prr)
-	}
-	return err
+	return p.Payload.Decode(pr)
 }
 
 // String fullfill the stringer interface.
 func (pr *Pressed) String() string {
 	txt := "Pressed "
-	if pr != nil {
-		txt += fmt.Sprintf("[IsPressed: %t]", pr.IsPressed)
-	} else {
+	if pr == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[IsPressed: %t]", pr.IsPressed)
 	}
 	return txt
 }
@@ -148,25 +138,7 @@ func (pr *Pressed) Copy() device.Resulter {
 	if pr == nil {
 		return nil
 	}
-	return &Pressed{IsPressed: pr.IsPressed}
-}
-
-// FromPressedRaw converts a PressedRaw type to a Pressed type.
-func (pr *Pressed) FromPressedRaw(prr *PressedRaw) {
-	if pr == nil || prr == nil {
-		return
+	return &Pressed{
+		IsPressed: pr.IsPressed,
 	}
-	pr.IsPressed = misc.Uint8ToBool(prr.IsPressed)
-}
-
-// PressedRaw is a type for raw coding of the pressed state.
-type PressedRaw struct {
-	IsPressed uint8
-}
-
-// NewPressedRawFromPressed is a simple constructor for a PressedRaw from a Pressed type.
-func NewPressedRawFromPressed(pr *Pressed) *PressedRaw {
-	prr := new(PressedRaw)
-	prr.IsPressed = misc.BoolToUint8(pr.IsPressed)
-	return prr
 }
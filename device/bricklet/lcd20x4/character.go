@@ -2,13 +2,14 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
-	"fmt"
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
-	"github.com/dirkjabl/bricker/net/packet"
 )
 
 // SetCustomCharacter creates a subsriber to set a custom character.
@@ -25,18 +26,17 @@ func SetCustomCharacter(id string, uid uint32, c *CustomCharacter, handler func(
 // SetCustomCharacterFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetCustomCharacterFuture(brick *bricker.Bricker, connectorname string, uid uint32, c *CustomCharacter) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetCustomCharacter("setcustomcharacterfuture"+device.GenId(), uid, c,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	b := <-future
-	return b
+	return SetCustomCharacterFutureContext(context.Background(), brick, connectorname, uid, c) == nil
+}
+
+// SetCustomCharacterFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetCustomCharacterFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, c *CustomCharacter) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetCustomCharacter("setcustomcharacterfuture"+device.GenId(), uid, c, nil))
+	return err
 }
 
 // GetCustomCharacter creates a subscriber to get a stored custom character at the given index.
@@ -48,9 +48,33 @@ func GetCustomCharacter(id string, uid uint32, index uint8, handler func(device.
 		Result:     &Character{},
 		Data:       index,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
+// GetCustomCharacterFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is nil.
+func GetCustomCharacterFuture(brick *bricker.Bricker, connectorname string, uid uint32, index uint8) *Character {
+	v, _ := GetCustomCharacterFutureContext(context.Background(), brick, connectorname, uid, index)
+	return v
+}
+
+// GetCustomCharacterFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetCustomCharacterFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, index uint8) (*Character, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetCustomCharacter("getcustomcharacterfuture"+device.GenId(), uid, index, nil))
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := res.(*Character); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
+}
+
 // CustomCharacter is the type for a custom character.
 // There could store up to 8 custom character.
 //
@@ -62,50 +86,3 @@ type CustomCharacter struct {
 	Index uint8
 	Char  Character
 }
-
-func (c *CustomCharacter) String() string {
-	txt := "LCD 20x4 Custom Character "
-	if c != nil {
-		txt += "[" + c.Char.String() + "]"
-	} else {
-		txt += "[nil]"
-	}
-	return txt
-}
-
-// Character stores the pixel data for a single custom character.
-// It is a array with 8 lines of 5 pixel (5x8).
-// Element 1 (index 0) is the first line, element 8 (index 7) the final line.
-type Character [8]uint8
-
-// FromPacket converts from a packet payload to type Character.
-func (c *Character) FromPacket(p *packet.Packet) error {
-	if err := device.CheckForFromPacket(c, p); err != nil {
-		return err
-	}
-	return p.Payload.Decode(c)
-}
-
-// String fullfill the stringer interface.
-func (c *Character) String() string {
-	txt := "LCD 20x4 Character "
-	if c != nil {
-		txt += "["
-		for i, v := range c {
-			txt += fmt.Sprintf(" %d:0x%x", i, v)
-		}
-		txt += "]"
-	} else {
-		txt += "[nil]"
-	}
-	return txt
-}
-
-// Copy creates a copy of the content.
-func (c *Character) Copy() device.Resulter {
-	if c == nil {
-		return nil
-	}
-	ch := *c
-	return &ch
-}
@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 func SetConfig(id string, uid uint32, cursor *Cursor, handler func(device.Resulter, error)) *device.Device {
@@ -17,24 +19,25 @@ func SetConfig(id string, uid uint32, cursor *Cursor, handler func(device.Result
 		Id:         device.FallbackId(id, "SetConfig"),
 		Fid:        function_set_config,
 		Uid:        uid,
-		Data:       NewCursorRaw(cursor),
+		Data:       cursor,
 		Handler:    handler,
 		WithPacket: true}.CreateDevice()
 }
 
+// SetConfigFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is false.
 func SetConfigFuture(brick *bricker.Bricker, connectorname string, uid uint32, cursor *Cursor) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetConfig("setconfigfuture"+device.GenId(), uid, cursor,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	b := <-future
-	return b
+	return SetConfigFutureContext(context.Background(), brick, connectorname, uid, cursor) == nil
+}
+
+// SetConfigFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetConfigFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, cursor *Cursor) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetConfig("setconfigfuture"+device.GenId(), uid, cursor, nil))
+	return err
 }
 
 func GetConfig(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
@@ -44,28 +47,31 @@ func GetConfig(id string, uid uint32, handler func(device.Resulter, error)) *dev
 		Uid:        uid,
 		Result:     &Cursor{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
+// GetConfigFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is nil.
 func GetConfigFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Cursor {
-	future := make(chan *Cursor)
-	sub := GetConfig("getconfigfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Cursor = nil
-			if err == nil {
-				if value, ok := r.(*Cursor); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetConfigFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetConfigFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetConfigFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Cursor, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetConfig("getconfigfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	v := <-future
-	close(future)
-	return v
+	if v, ok := res.(*Cursor); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Cursor config type. For setting or getting the cursor state.
@@ -79,21 +85,16 @@ func (c *Cursor) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(c, p); err != nil {
 		return err
 	}
-	rc := new(CursorRaw)
-	err := p.Payload.Decode(rc)
-	if err == nil && rc != nil {
-		c.FromCursorRaw(rc)
-	}
-	return err
+	return p.Payload.Decode(c)
 }
 
 // String fullfill the stringer interface.
 func (c *Cursor) String() string {
 	txt := "Cursor "
-	if c != nil {
-		txt += fmt.Sprintf("[Show: %t, Blinking: %t]", c.Show, c.Blinking)
-	} else {
+	if c == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Show: %t, Blinking: %t]", c.Show, c.Blinking)
 	}
 	return txt
 }
@@ -105,31 +106,6 @@ func (c *Cursor) Copy() device.Resulter {
 	}
 	return &Cursor{
 		Show:     c.Show,
-		Blinking: c.Blinking}
-}
-
-// FromCursorRaw converts a CursorRaw type into a Cursor type.
-func (c *Cursor) FromCursorRaw(cr *CursorRaw) {
-	if c == nil || cr == nil {
-		return
-	}
-	c.Show = misc.Uint8ToBool(cr.Show)
-	c.Blinking = misc.Uint8ToBool(cr.Blinking)
-}
-
-// CursorRaw is the real de/encoding type for a cursor.
-type CursorRaw struct {
-	Show     uint8
-	Blinking uint8
-}
-
-// NewFromCursor creates a CursorRaw object from a Cursor.
-func NewCursorRaw(c *Cursor) *CursorRaw {
-	if c == nil {
-		return nil
+		Blinking: c.Blinking,
 	}
-	cr := new(CursorRaw)
-	cr.Show = misc.BoolToUint8(c.Show)
-	cr.Blinking = misc.BoolToUint8(c.Blinking)
-	return cr
 }
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -14,7 +17,7 @@ import (
 // SetDefaultText creates a new subscriber to set the default text line (for one line 0-3).
 // Here the line starts on 0 up to 19 characters (20 bytes).
 // The default text will be showed, if the default text counter timed out (look at SetDefaultTextCounter).
-func SetDefaultText(id string, uid uint32, dtl *DefaultTextLine, handler func(r device.Resulter, e error)) *device.Device {
+func SetDefaultText(id string, uid uint32, dtl *DefaultTextLine, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetDefaultText"),
 		Fid:        function_set_default_text,
@@ -27,22 +30,21 @@ func SetDefaultText(id string, uid uint32, dtl *DefaultTextLine, handler func(r
 // SetDefaultTextFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetDefaultTextFuture(brick *bricker.Bricker, connectorname string, uid uint32, dtl *DefaultTextLine) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetDefaultText("setdefaulttextfuture"+device.GenId(), uid, dtl,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	v := <-future
-	return v
+	return SetDefaultTextFutureContext(context.Background(), brick, connectorname, uid, dtl) == nil
+}
+
+// SetDefaultTextFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetDefaultTextFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, dtl *DefaultTextLine) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetDefaultText("setdefaulttextfuture"+device.GenId(), uid, dtl, nil))
+	return err
 }
 
 // GetDefaultText creates a new subscriber to get the default text on the given line.
-func GetDefaultText(id string, uid uint32, l *Line, handler func(r device.Resulter, e error)) *device.Device {
+func GetDefaultText(id string, uid uint32, l *Line, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "GetDefaultText"),
 		Fid:        function_get_default_text,
@@ -50,37 +52,38 @@ func GetDefaultText(id string, uid uint32, l *Line, handler func(r device.Result
 		Result:     &Text{},
 		Data:       l,
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetDefaultTextFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetDefaultTextFuture(brick *bricker.Bricker, connectorname string, uid uint32, l *Line) *Text {
-	future := make(chan *Text)
-	defer close(future)
-	sub := GetDefaultText("getdefaulttextfuture"+device.GenId(), uid, l,
-		func(r device.Resulter, err error) {
-			var v *Text = nil
-			if err != nil {
-				if value, ok := r.(*Text); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetDefaultTextFutureContext(context.Background(), brick, connectorname, uid, l)
+	return v
+}
+
+// GetDefaultTextFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetDefaultTextFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, l *Line) (*Text, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetDefaultText("getdefaulttextfuture"+device.GenId(), uid, l, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	v := <-future
-	return v
+	if v, ok := res.(*Text); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // SetDefaultTextCounter creates a subscribte to set the default text output counter (timeout).
 // The counter is in mili seconds (ms). If the counter larger than 0 it decrement every ms.
 // If the counter timed out (reaches 0) than the default text will be shown.
 // A negative value for the counter stops the timer. Default value is -1.
-func SetDefaultTextCounter(id string, uid uint32, c *Counter, handler func(r device.Resulter, e error)) *device.Device {
+func SetDefaultTextCounter(id string, uid uint32, c *Counter, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetDefaultTextCounter"),
 		Fid:        function_set_default_text_counter,
@@ -93,53 +96,53 @@ func SetDefaultTextCounter(id string, uid uint32, c *Counter, handler func(r dev
 // SetDefaultTextCounterFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetDefaultTextCounterFuture(brick *bricker.Bricker, connectorname string, uid uint32, c *Counter) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetDefaultTextCounter("setdefaulttextcounterfuture"+device.GenId(), uid, c,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	v := <-future
-	return v
+	return SetDefaultTextCounterFutureContext(context.Background(), brick, connectorname, uid, c) == nil
+}
+
+// SetDefaultTextCounterFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetDefaultTextCounterFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, c *Counter) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetDefaultTextCounter("setdefaulttextcounterfuture"+device.GenId(), uid, c, nil))
+	return err
 }
 
 // GetDefaultTextCounter creates a subscriber to get the value from the counter.
 // Default value is -1.
-func GetDefaultTextCounter(id string, uid uint32, handler func(r device.Resulter, e error)) *device.Device {
+func GetDefaultTextCounter(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "GetDefaultTextCounter"),
 		Fid:        function_get_default_text,
 		Uid:        uid,
 		Result:     &Counter{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetDefaultTextCounterFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetDefaultTextCounterFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Counter {
-	future := make(chan *Counter)
-	defer close(future)
-	sub := GetDefaultTextCounter("getdefaulttextcounterfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Counter = nil
-			if err != nil {
-				if value, ok := r.(*Counter); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetDefaultTextCounterFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetDefaultTextCounterFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetDefaultTextCounterFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Counter, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetDefaultTextCounter("getdefaulttextcounterfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	v := <-future
-	return v
+	if v, ok := res.(*Counter); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // DefaultTextLine is the type for a full text line to display.
@@ -149,7 +152,7 @@ type DefaultTextLine struct {
 	Text [20]byte
 }
 
-// FromPacket creates from a packet a DefaultTextLine.
+// FromPacket converts the packet payload to the DefaultTextLine type.
 func (dtl *DefaultTextLine) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(dtl, p); err != nil {
 		return err
@@ -160,10 +163,10 @@ func (dtl *DefaultTextLine) FromPacket(p *packet.Packet) error {
 // String fullfill the stringer interface.
 func (dtl *DefaultTextLine) String() string {
 	txt := "LCD 20x4 Default Text Line "
-	if dtl != nil {
-		txt += fmt.Sprintf("[Line: %d Text: %s]", dtl.Line, dtl.Text)
-	} else {
+	if dtl == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Line: %d Text: %s]", dtl.Line, dtl.Text)
 	}
 	return txt
 }
@@ -175,7 +178,8 @@ func (dtl *DefaultTextLine) Copy() device.Resulter {
 	}
 	return &DefaultTextLine{
 		Line: dtl.Line,
-		Text: dtl.Text}
+		Text: dtl.Text,
+	}
 }
 
 // Line type for the line number of the default text.
@@ -183,7 +187,7 @@ type Line struct {
 	Number uint8
 }
 
-// FromPacket creates from a packet a Line type.
+// FromPacket converts the packet payload to the Line type.
 func (l *Line) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(l, p); err != nil {
 		return err
@@ -194,10 +198,10 @@ func (l *Line) FromPacket(p *packet.Packet) error {
 // String fullfill the stringer interface.
 func (l *Line) String() string {
 	txt := "LCD 20x4 Line "
-	if l != nil {
-		txt += fmt.Sprintf("[Number: %d]", l.Number)
-	} else {
+	if l == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Number: %d]", l.Number)
 	}
 	return txt
 }
@@ -207,7 +211,9 @@ func (l *Line) Copy() device.Resulter {
 	if l == nil {
 		return nil
 	}
-	return &Line{Number: l.Number}
+	return &Line{
+		Number: l.Number,
+	}
 }
 
 // Text is the type for a text line (the characters).
@@ -216,7 +222,7 @@ type Text struct {
 	Text [20]byte
 }
 
-// FromPacket creates from a packet a Text type.
+// FromPacket converts the packet payload to the Text type.
 func (t *Text) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(t, p); err != nil {
 		return err
@@ -227,10 +233,10 @@ func (t *Text) FromPacket(p *packet.Packet) error {
 // String fullfill the stringer interface.
 func (t *Text) String() string {
 	txt := "LCD 20x4 Text "
-	if t != nil {
-		txt += fmt.Sprintf("[Text: %s]", t.Text)
-	} else {
+	if t == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Text: %s]", t.Text)
 	}
 	return txt
 }
@@ -240,7 +246,9 @@ func (t *Text) Copy() device.Resulter {
 	if t == nil {
 		return nil
 	}
-	return &Text{Text: t.Text}
+	return &Text{
+		Text: t.Text,
+	}
 }
 
 // Counter is the value type for the default text time out timer.
@@ -248,7 +256,7 @@ type Counter struct {
 	Value int32 // every not negative value starts the timer
 }
 
-// FromPacket creates from a packet a Text type.
+// FromPacket converts the packet payload to the Counter type.
 func (c *Counter) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(c, p); err != nil {
 		return err
@@ -259,10 +267,10 @@ func (c *Counter) FromPacket(p *packet.Packet) error {
 // String fullfill the stringer interface.
 func (c *Counter) String() string {
 	txt := "LCD 20x4 Counter "
-	if c != nil {
-		txt += fmt.Sprintf("[Value: %d]", c.Value)
-	} else {
+	if c == nil {
 		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Value: %d]", c.Value)
 	}
 	return txt
 }
@@ -272,5 +280,7 @@ func (c *Counter) Copy() device.Resulter {
 	if c == nil {
 		return nil
 	}
-	return &Counter{Value: c.Value}
+	return &Counter{
+		Value: c.Value,
+	}
 }
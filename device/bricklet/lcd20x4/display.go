@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -19,18 +22,18 @@ func ClearDisplay(id string, uid uint32, handler func(device.Resulter, error)) *
 		WithPacket: true}.CreateDevice()
 }
 
-// ClearDisplayFuture is the future version of the ClearDisplay subscriber.
+// ClearDisplayFuture is a future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is false.
 func ClearDisplayFuture(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	future := make(chan bool)
-	sub := ClearDisplay("cleardisplayfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	b := <-future
-	close(future)
-	return b
+	return ClearDisplayFutureContext(context.Background(), brick, connectorname, uid) == nil
+}
+
+// ClearDisplayFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func ClearDisplayFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) error {
+	_, err := device.Future(ctx, brick, dest,
+		ClearDisplay("cleardisplayfuture"+device.GenId(), uid, nil))
+	return err
 }
@@ -0,0 +1,80 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lcd20x4
+
+import (
+	"fmt"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/net/packet"
+)
+
+// IsBacklightOnFutureSimple calls the IsBacklightOnFuture method with a simple boolean result.
+// If it fails, the result is false.
+func IsBacklightOnFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32) bool {
+	bl := IsBacklightOnFuture(brick, connectorname, uid)
+	if bl != nil && bl.IsOn {
+		return true
+	}
+	return false
+}
+
+// IsButtonPressedFutureSimple calls the IsButtonPressedFuture method with a simple boolean result.
+// If it fails, the result is false.
+func IsButtonPressedFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32, button *Button) bool {
+	p := IsButtonPressedFuture(brick, connectorname, uid, button)
+	if p == nil {
+		return false
+	}
+	return p.IsPressed
+}
+
+// String fullfill the stringer interface.
+func (c *CustomCharacter) String() string {
+	txt := "LCD 20x4 Custom Character "
+	if c != nil {
+		txt += "[" + c.Char.String() + "]"
+	} else {
+		txt += "[nil]"
+	}
+	return txt
+}
+
+// Character stores the pixel data for a single custom character.
+// It is a array with 8 lines of 5 pixel (5x8).
+// Element 1 (index 0) is the first line, element 8 (index 7) the final line.
+type Character [8]uint8
+
+// FromPacket converts from a packet payload to type Character.
+func (c *Character) FromPacket(p *packet.Packet) error {
+	if err := device.CheckForFromPacket(c, p); err != nil {
+		return err
+	}
+	return p.Payload.Decode(c)
+}
+
+// String fullfill the stringer interface.
+func (c *Character) String() string {
+	txt := "LCD 20x4 Character "
+	if c != nil {
+		txt += "["
+		for i, v := range c {
+			txt += fmt.Sprintf(" %d:0x%x", i, v)
+		}
+		txt += "]"
+	} else {
+		txt += "[nil]"
+	}
+	return txt
+}
+
+// Copy creates a copy of the content.
+func (c *Character) Copy() device.Resulter {
+	if c == nil {
+		return nil
+	}
+	ch := *c
+	return &ch
+}
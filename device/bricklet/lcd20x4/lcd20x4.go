@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the LCD 20x4 Bricklet.
 package lcd20x4
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the LCD 20x4 Bricklet (see device/name).
+const DeviceIdentifier = uint16(212)
+
 // Function and callback identifer
 const (
 	function_write_line               = uint8(1)
@@ -0,0 +1,86 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The raw types are kept for compatibility, the bool fields of the types are encoded directly.
+
+package lcd20x4
+
+import (
+	misc "github.com/dirkjabl/bricker/util/miscellaneous"
+)
+
+// BacklightRaw is a type for raw coding of the backlight.
+//
+// Deprecated: Backlight is decoded directly.
+type BacklightRaw struct {
+	IsOn uint8
+}
+
+// FromBacklightRaw converts a BacklightRaw into a Backlight.
+//
+// Deprecated: Backlight is decoded directly.
+func (bl *Backlight) FromBacklightRaw(br *BacklightRaw) {
+	if bl == nil || br == nil {
+		return
+	}
+	bl.IsOn = misc.Uint8ToBool(br.IsOn)
+}
+
+// PressedRaw is a type for raw coding of the pressed state.
+//
+// Deprecated: Pressed is encoded directly.
+type PressedRaw struct {
+	IsPressed uint8
+}
+
+// NewPressedRawFromPressed is a simple constructor for a PressedRaw from a Pressed type.
+//
+// Deprecated: Pressed is encoded directly.
+func NewPressedRawFromPressed(pr *Pressed) *PressedRaw {
+	prr := new(PressedRaw)
+	prr.IsPressed = misc.BoolToUint8(pr.IsPressed)
+	return prr
+}
+
+// FromPressedRaw converts a PressedRaw type to a Pressed type.
+//
+// Deprecated: Pressed is decoded directly.
+func (pr *Pressed) FromPressedRaw(prr *PressedRaw) {
+	if pr == nil || prr == nil {
+		return
+	}
+	pr.IsPressed = misc.Uint8ToBool(prr.IsPressed)
+}
+
+// CursorRaw is the real de/encoding type for a cursor.
+//
+// Deprecated: Cursor is encoded directly.
+type CursorRaw struct {
+	Show     uint8
+	Blinking uint8
+}
+
+// NewCursorRaw creates a CursorRaw object from a Cursor.
+//
+// Deprecated: Cursor is encoded directly.
+func NewCursorRaw(c *Cursor) *CursorRaw {
+	if c == nil {
+		return nil
+	}
+	cr := new(CursorRaw)
+	cr.Show = misc.BoolToUint8(c.Show)
+	cr.Blinking = misc.BoolToUint8(c.Blinking)
+	return cr
+}
+
+// FromCursorRaw converts a CursorRaw type into a Cursor type.
+//
+// Deprecated: Cursor is decoded directly.
+func (c *Cursor) FromCursorRaw(cr *CursorRaw) {
+	if c == nil || cr == nil {
+		return
+	}
+	c.Show = misc.Uint8ToBool(cr.Show)
+	c.Blinking = misc.Uint8ToBool(cr.Blinking)
+}
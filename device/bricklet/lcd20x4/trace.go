@@ -0,0 +1,32 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package lcd20x4
+
+import (
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the LCD 20x4 Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_backlight_on:             {Name: "BacklightOn"},
+		function_backlight_off:            {Name: "BacklightOff"},
+		function_is_backlight_on:          {Name: "IsBacklightOn", Response: func() interface{} { return new(Backlight) }},
+		function_is_button_pressed:        {Name: "IsButtonPressed", Request: func() interface{} { return new(Button) }, Response: func() interface{} { return new(Pressed) }},
+		callback_button_pressed:           {Name: "ButtonPressed", Response: func() interface{} { return new(Button) }},
+		callback_button_released:          {Name: "ButtonReleased", Response: func() interface{} { return new(Button) }},
+		function_set_custom_character:     {Name: "SetCustomCharacter", Request: func() interface{} { return new(CustomCharacter) }},
+		function_get_custom_character:     {Name: "GetCustomCharacter", Request: func() interface{} { return new(uint8) }, Response: func() interface{} { return new(Character) }},
+		function_set_config:               {Name: "SetConfig", Request: func() interface{} { return new(Cursor) }},
+		function_get_config:               {Name: "GetConfig", Response: func() interface{} { return new(Cursor) }},
+		function_set_default_text:         {Name: "SetDefaultText", Request: func() interface{} { return new(DefaultTextLine) }},
+		function_get_default_text:         {Name: "GetDefaultText", Request: func() interface{} { return new(Line) }, Response: func() interface{} { return new(Text) }},
+		function_set_default_text_counter: {Name: "SetDefaultTextCounter", Request: func() interface{} { return new(Counter) }},
+		function_clear_display:            {Name: "ClearDisplay"},
+		function_write_line:               {Name: "WriteLine", Request: func() interface{} { return new(LcdTextLine) }},
+	})
+}
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package lcd20x4
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -12,7 +15,7 @@ import (
 )
 
 // WriteLine creates a new subscriber to write a text to the LCD (one line).
-func WriteLine(id string, uid uint32, ltl *LcdTextLine, handler func(r device.Resulter, e error)) *device.Device {
+func WriteLine(id string, uid uint32, ltl *LcdTextLine, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "WriteLine"),
 		Fid:        function_write_line,
@@ -25,17 +28,17 @@ func WriteLine(id string, uid uint32, ltl *LcdTextLine, handler func(r device.Re
 // WriteLineFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func WriteLineFuture(brick *bricker.Bricker, connectorname string, uid uint32, ltl *LcdTextLine) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := WriteLine("writelinefuture"+device.GenId(), uid, ltl, func(r device.Resulter, err error) {
-		future <- device.IsEmptyResultOk(r, err)
-	})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	v := <-future
-	return v
+	return WriteLineFutureContext(context.Background(), brick, connectorname, uid, ltl) == nil
+}
+
+// WriteLineFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func WriteLineFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, ltl *LcdTextLine) error {
+	_, err := device.Future(ctx, brick, dest,
+		WriteLine("writelinefuture"+device.GenId(), uid, ltl, nil))
+	return err
 }
 
 // LcdTextLine is the type for a text line to display.
@@ -46,7 +49,7 @@ type LcdTextLine struct {
 	Text [20]byte
 }
 
-// FromPacket creates from a packet a LcdTextLine.
+// FromPacket converts the packet payload to the LcdTextLine type.
 func (ltl *LcdTextLine) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(ltl, p); err != nil {
 		return err
@@ -56,7 +59,13 @@ func (ltl *LcdTextLine) FromPacket(p *packet.Packet) error {
 
 // String fullfill the stringer interface.
 func (ltl *LcdTextLine) String() string {
-	return fmt.Sprintf("LCD 20x4 Text Line [Line: %d Position: %d Text: %s]", ltl.Line, ltl.Pos, ltl.Text)
+	txt := "LCD 20x4 Text Line "
+	if ltl == nil {
+		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Line: %d Position: %d Text: %s]", ltl.Line, ltl.Pos, ltl.Text)
+	}
+	return txt
 }
 
 // Copy creates a copy of the content.
@@ -67,5 +76,6 @@ func (ltl *LcdTextLine) Copy() device.Resulter {
 	return &LcdTextLine{
 		Line: ltl.Line,
 		Pos:  ltl.Pos,
-		Text: ltl.Text}
+		Text: ltl.Text,
+	}
 }
@@ -2,16 +2,24 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Moisture Bricklet.
 package moisture
 
+//go:generate go run ../gen spec.json
+
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
+// DeviceIdentifier identifies the Moisture Bricklet (see device/name).
+const DeviceIdentifier = uint16(232)
+
 const (
 	function_get_moisture_value              = uint8(1)
 	function_set_moving_average              = uint8(10)
@@ -34,29 +42,31 @@ func GetMoistureValue(id string, uid uint32, handler func(device.Resulter, error
 		Uid:        uid,
 		Result:     &Moisture{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetMoistureValueFuture is a future pattern version for a synchronized calll of the subscriber.
+// GetMoistureValueFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetMoistureValueFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Moisture {
-	future := make(chan *Moisture)
-	defer close(future)
-	sub := GetMoistureValue("getmoisturevaluefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Moisture = nil
-			if err == nil {
-				if value, ok := r.(*Moisture); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetMoistureValueFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetMoistureValueFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMoistureValueFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Moisture, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMoistureValue("getmoisturevaluefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Moisture); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Moisture is the type of the moisture value.
@@ -66,7 +76,7 @@ type Moisture struct {
 	Value uint16
 }
 
-// FromPacket create from a packet a moisture value.
+// FromPacket converts the packet payload to the Moisture type.
 func (m *Moisture) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -90,5 +100,7 @@ func (m *Moisture) Copy() device.Resulter {
 	if m == nil {
 		return nil
 	}
-	return &Moisture{Value: m.Value}
+	return &Moisture{
+		Value: m.Value,
+	}
 }
@@ -2,19 +2,20 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package moisture
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-SetMovingAverage creates a subscriber to set a length of the moving average.
-The default value is 100.
-*/
+// SetMovingAverage creates a subscriber to set a length of the moving average.
+// The default value is 100.
 func SetMovingAverage(id string, uid uint32, a *Average, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "SetMovingAverage"),
@@ -28,17 +29,17 @@ func SetMovingAverage(id string, uid uint32, a *Average, handler func(device.Res
 // SetMovingAverageFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetMovingAverageFuture(brick *bricker.Bricker, connectorname string, uid uint32, a *Average) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetMovingAverage("setmovingaveragefuture"+device.GenId(), uid, a,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetMovingAverageFutureContext(context.Background(), brick, connectorname, uid, a) == nil
+}
+
+// SetMovingAverageFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetMovingAverageFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, a *Average) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetMovingAverage("setmovingaveragefuture"+device.GenId(), uid, a, nil))
+	return err
 }
 
 // GetMovingAverage creates a subscriber to get the length of the moving average.
@@ -49,44 +50,44 @@ func GetMovingAverage(id string, uid uint32, handler func(device.Resulter, error
 		Uid:        uid,
 		Result:     &Average{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetMovingAverageFuture is a future pattern version for a synchronized calll of the subscriber.
+// GetMovingAverageFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetMovingAverageFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Average {
-	future := make(chan *Average)
-	defer close(future)
-	sub := GetMovingAverage("getmovingaveragefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Average = nil
-			if err == nil {
-				if value, ok := r.(*Average); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetMovingAverageFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetMovingAverageFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMovingAverageFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Average, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMovingAverage("getmovingaveragefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Average); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
-/*
-Average is the type for the averaging length.
-A value 0 is turn the averaging completely off.
-With less averaging the value of the moisture value contains more noise.
-The range for the averaging is 0-100.
-More information in the Tinkerforge documentation:
-http://www.tinkerforge.com/en/doc/Software/Bricklets/Moisture_Bricklet_TCPIP.html#BrickletMoisture.get_moisture_value
-*/
+// Average is the type for the averaging length.
+// A value 0 is turn the averaging completely off.
+// With less averaging the value of the moisture value contains more noise.
+// The range for the averaging is 0-100.
+// More information in the Tinkerforge documentation:
+// http://www.tinkerforge.com/en/doc/Software/Bricklets/Moisture_Bricklet_TCPIP.html#BrickletMoisture.get_moisture_value
 type Average struct {
 	Value uint8
 }
 
-// FromPacket create from a packet a Average.
+// FromPacket converts the packet payload to the Average type.
 func (a *Average) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(a, p); err != nil {
 		return err
@@ -94,7 +95,7 @@ func (a *Average) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(a)
 }
 
-// String fullfill stringer interface.
+// String fullfill the stringer interface.
 func (a *Average) String() string {
 	txt := "Average "
 	if a == nil {
@@ -110,5 +111,7 @@ func (a *Average) Copy() device.Resulter {
 	if a == nil {
 		return nil
 	}
-	return &Average{Value: a.Value}
+	return &Average{
+		Value: a.Value,
+	}
 }
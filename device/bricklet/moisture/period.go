@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package moisture
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -18,23 +21,25 @@ func SetMoistureCallbackPeriod(id string, uid uint32, pe *device.Period, handler
 		Uid:        uid,
 		Data:       pe,
 		Handler:    handler,
+		Restore:    true,
+		Disable:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // SetMoistureCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetMoistureCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32, pe *device.Period) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetMoistureCallbackPeriod("setmoisturecallbackperiodfuture"+device.GenId(), uid, pe,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetMoistureCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid, pe) == nil
+}
+
+// SetMoistureCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetMoistureCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, pe *device.Period) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetMoistureCallbackPeriod("setmoisturecallbackperiodfuture"+device.GenId(), uid, pe, nil))
+	return err
 }
 
 // GetMoistureCallbackPeriod creates a subsctiber to get the callback period value.
@@ -45,29 +50,31 @@ func GetMoistureCallbackPeriod(id string, uid uint32, handler func(device.Result
 		Uid:        uid,
 		Result:     &device.Period{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetMoistureCallbackPeriodFuture is a future pattern version for a synchronized call of the subsctiber.
+// GetMoistureCallbackPeriodFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetMoistureCallbackPeriodFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Period {
-	future := make(chan *device.Period)
-	defer close(future)
-	sub := GetMoistureCallbackPeriod("getmoisturecallbackperiodfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Period = nil
-			if err == nil {
-				if value, ok := r.(*device.Period); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetMoistureCallbackPeriodFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetMoistureCallbackPeriodFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMoistureCallbackPeriodFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Period, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMoistureCallbackPeriod("getmoisturecallbackperiodfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Period); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // MoisturePeriod creates a subscriber for the periodical moisture callback.
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package moisture
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -18,23 +21,24 @@ func SetMoistureCallbackThreshold(id string, uid uint32, t *device.Threshold16,
 		Uid:        uid,
 		Data:       t,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
-// SetTemperatureCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
+// SetMoistureCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetMoistureCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32, t *device.Threshold16) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetMoistureCallbackThreshold("setmoisturecallbackthresholdfuture"+device.GenId(), uid, t,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetMoistureCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid, t) == nil
+}
+
+// SetMoistureCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetMoistureCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, t *device.Threshold16) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetMoistureCallbackThreshold("setmoisturecallbackthresholdfuture"+device.GenId(), uid, t, nil))
+	return err
 }
 
 // GetMoistureCallbackThreshold creates the subscriber to get the callback thresold.
@@ -45,29 +49,31 @@ func GetMoistureCallbackThreshold(id string, uid uint32, handler func(device.Res
 		Uid:        uid,
 		Result:     &device.Threshold16{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetMoistureCallbackThresholdFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetMoistureCallbackThresholdFuture(brick *bricker.Bricker, connectorname string, uid uint32) *device.Threshold16 {
-	future := make(chan *device.Threshold16)
-	defer close(future)
-	sub := GetMoistureCallbackThreshold("getmoisturecallbackthresholdfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *device.Threshold16 = nil
-			if err == nil {
-				if value, ok := r.(*device.Threshold16); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetMoistureCallbackThresholdFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetMoistureCallbackThresholdFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMoistureCallbackThresholdFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*device.Threshold16, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMoistureCallbackThreshold("getmoisturecallbackthresholdfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*device.Threshold16); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // MoistureReached creates a subscriber for the theshold triggered temperature callback.
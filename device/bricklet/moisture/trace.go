@@ -0,0 +1,29 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package moisture
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Moisture Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_set_debounce_period:             {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:             {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_get_moisture_value:              {Name: "GetMoistureValue", Response: func() interface{} { return new(Moisture) }},
+		function_set_moving_average:              {Name: "SetMovingAverage", Request: func() interface{} { return new(Average) }},
+		function_get_moving_average:              {Name: "GetMovingAverage", Response: func() interface{} { return new(Average) }},
+		function_set_moisture_callback_period:    {Name: "SetMoistureCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_moisture_callback_period:    {Name: "GetMoistureCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		callback_moisture:                        {Name: "MoisturePeriod", Response: func() interface{} { return new(Moisture) }},
+		function_set_moisture_callback_threshold: {Name: "SetMoistureCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_moisture_callback_threshold: {Name: "GetMoistureCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		callback_moisture_reached:                {Name: "MoistureReached", Response: func() interface{} { return new(Moisture) }},
+	})
+}
@@ -0,0 +1,20 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motiondetector
+
+import (
+	"github.com/dirkjabl/bricker"
+)
+
+// GetMotionDetectedFutureSimple is a easy to use verion of GetMotionDetectedFuture.
+// It returns only true if a motion is detected.
+func GetMotionDetectedFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32) bool {
+	m := GetMotionDetectedFuture(brick, connectorname, uid)
+	result := false
+	if m != nil {
+		result = (m.Value == 1)
+	}
+	return result
+}
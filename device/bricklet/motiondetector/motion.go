@@ -2,23 +2,24 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package motiondetector
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
-/*
-GetMotionDetected creates the subscriber for getting a detected motion.
-
-More information about detection an time outs:
-http://www.tinkerforge.com/en/doc/Hardware/Bricklets/Motion_Detector.html#motion-detector-bricklet-sensitivity-delay-block-time
-
-There is a blue LED on the bricklet, this LED is on, if the bricklet is in the "motion detected" state.
-*/
+// GetMotionDetected creates the subscriber for getting a detected motion.
+//
+// More information about detection an time outs:
+// http://www.tinkerforge.com/en/doc/Hardware/Bricklets/Motion_Detector.html#motion-detector-bricklet-sensitivity-delay-block-time
+//
+// There is a blue LED on the bricklet, this LED is on, if the bricklet is in the "motion detected" state.
 func GetMotionDetected(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "GetMotionDetected"),
@@ -26,41 +27,31 @@ func GetMotionDetected(id string, uid uint32, handler func(device.Resulter, erro
 		Uid:        uid,
 		Result:     &Motion{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetMotionDetectedFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetMotionDetectedFuture(brick bricker.Bricker, connectorname string, uid uint32) *Motion {
-	future := make(chan *Motion)
-	defer close(future)
-	sub := GetMotionDetected("getmotiondetectedfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Motion = nil
-			if err == nil {
-				if value, ok := r.(*Motion); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return nil
-	}
-	v := <-future
+func GetMotionDetectedFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Motion {
+	v, _ := GetMotionDetectedFutureContext(context.Background(), brick, connectorname, uid)
 	return v
 }
 
-// GetMotionDetectedFutureSimple is a easy to use verion of GetMotionDetectedFuture.
-// It returns only true if a motion is detected.
-func GetMotionDetectedFutureSimple(brick bricker.Bricker, connectorname string, uid uint32) bool {
-	m := GetMotionDetectedFuture(brick, connectorname, uid)
-	result := false
-	if m != nil {
-		result = (m.Value == 1)
+// GetMotionDetectedFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetMotionDetectedFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Motion, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetMotionDetected("getmotiondetectedfuture"+device.GenId(), uid, nil))
+	if err != nil {
+		return nil, err
 	}
-	return result
+	if v, ok := res.(*Motion); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // MotionDetected create the subscriber for the motion detected callback.
@@ -80,7 +71,7 @@ type Motion struct {
 	Value uint8
 }
 
-// FromPacket creates from a packet a Motion value.
+// FromPacket converts the packet payload to the Motion type.
 func (m *Motion) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -104,5 +95,7 @@ func (m *Motion) Copy() device.Resulter {
 	if m == nil {
 		return nil
 	}
-	return &Motion{Value: m.Value}
+	return &Motion{
+		Value: m.Value,
+	}
 }
@@ -2,25 +2,30 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Motion Detector Bricklet.
 package motiondetector
 
+//go:generate go run ../gen spec.json
+
 import (
 	"github.com/dirkjabl/bricker/device"
 )
 
+// DeviceIdentifier identifies the Motion Detector Bricklet (see device/name).
+const DeviceIdentifier = uint16(233)
+
 const (
 	function_get_motion_detected   = uint8(1)
 	callback_motion_detected       = uint8(2)
 	callback_detection_cycle_ended = uint8(3)
 )
 
-/*
-DetectionCycleEnded creates a subscriber for the detection cyle ended callback.
-
-The handler is called when after a detection motion the detection cycle ended.
-After the detection cycle a new motion can be detected after approximately 2 seconds.
-*/
+// DetectionCycleEnded creates a subscriber for the detection cyle ended callback.
+//
+// The handler is called when after a detection motion the detection cycle ended.
+// After the detection cycle a new motion can be detected after approximately 2 seconds.
 func DetectionCycleEnded(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "DetectionCycleEnded"),
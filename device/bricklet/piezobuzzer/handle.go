@@ -0,0 +1,54 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package piezobuzzer
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/device"
+)
+
+// Bricklet is the handle for a Piezo Buzzer Bricklet.
+// Every method is a synchronized call (see the FutureContext functions) or a callback subscription.
+// The uid is the destination of every call, the bricker resolves the connector (or the member
+// of a connector group) for every subscription (see bricker.ConnectorFor).
+type Bricklet struct {
+	brick  *bricker.Bricker
+	Uid    uint32
+	Buffer int // buffer size of the callback channels
+}
+
+// New creates the handle for the bricklet with the given uid.
+func New(brick *bricker.Bricker, uid uint32) *Bricklet {
+	return &Bricklet{brick: brick, Uid: uid}
+}
+
+// Beep is the handle version of BeepFutureContext.
+func (bl *Bricklet) Beep(ctx context.Context, b *Beeps) error {
+	return BeepFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, b)
+}
+
+// BeepFinished subscribes the BeepFinished callback, until the context is done.
+// The results come in the returned channel (with a buffer of Buffer results),
+// the channel is closed after the context is done or the connector goes away.
+func (bl *Bricklet) BeepFinished(ctx context.Context) (<-chan *device.EmptyResult, error) {
+	return device.Channel[*device.EmptyResult](ctx, bl.brick, bl.Uid,
+		BeepFinished("beepfinished"+device.GenId(), bl.Uid, nil), bl.Buffer)
+}
+
+// MorseCode is the handle version of MorseCodeFutureContext.
+func (bl *Bricklet) MorseCode(ctx context.Context, m *Morse) error {
+	return MorseCodeFutureContext(ctx, bl.brick, bl.Uid, bl.Uid, m)
+}
+
+// MorseCodeFinished subscribes the MorseCodeFinished callback, until the context is done.
+// The results come in the returned channel (with a buffer of Buffer results),
+// the channel is closed after the context is done or the connector goes away.
+func (bl *Bricklet) MorseCodeFinished(ctx context.Context) (<-chan *device.EmptyResult, error) {
+	return device.Channel[*device.EmptyResult](ctx, bl.brick, bl.Uid,
+		MorseCodeFinished("morsecodefinished"+device.GenId(), bl.Uid, nil), bl.Buffer)
+}
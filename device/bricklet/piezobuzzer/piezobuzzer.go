@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Piezo Buzzer Bricklet.
 package piezobuzzer
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Piezo Buzzer Bricklet (see device/name).
+const DeviceIdentifier = uint16(214)
+
 const (
 	function_beep                = uint8(1)
 	function_morse_code          = uint8(2)
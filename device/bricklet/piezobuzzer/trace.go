@@ -0,0 +1,21 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package piezobuzzer
+
+import (
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Piezo Buzzer Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_beep:                {Name: "Beep", Request: func() interface{} { return new(Beeps) }},
+		callback_beep_finished:       {Name: "BeepFinished"},
+		function_morse_code:          {Name: "MorseCode", Request: func() interface{} { return new(Morse) }},
+		callback_morse_code_finished: {Name: "MorseCodeFinished"},
+	})
+}
@@ -2,19 +2,20 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package piezospeaker
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
 
-/*
-Beep creates a subscriber for an output of a beep.
-
-The speaker can only approximate the frequency,
-it will play the best possible match by applying the calibration.
-*/
+// Beep creates a subscriber for an output of a beep.
+//
+// The speaker can only approximate the frequency,
+// it will play the best possible match by applying the calibration.
 func Beep(id string, uid uint32, b *Beeps, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "Beep"),
@@ -27,18 +28,18 @@ func Beep(id string, uid uint32, b *Beeps, handler func(device.Resulter, error))
 
 // BeepFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func BeepFuture(brick bricker.Bricker, connectorname string, uid uint32, b *Beeps) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := Beep("beepfuture"+device.GenId(), uid, b,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func BeepFuture(brick *bricker.Bricker, connectorname string, uid uint32, b *Beeps) bool {
+	return BeepFutureContext(context.Background(), brick, connectorname, uid, b) == nil
+}
+
+// BeepFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func BeepFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, b *Beeps) error {
+	_, err := device.Future(ctx, brick, dest,
+		Beep("beepfuture"+device.GenId(), uid, b, nil))
+	return err
 }
 
 // BeepFinished creates a subscriber which is triggered if a Beep subscriber is finished.
@@ -53,11 +54,9 @@ func BeepFinished(id string, uid uint32, handler func(device.Resulter, error)) *
 		WithPacket: false}.CreateDevice()
 }
 
-/*
-Beeps is the type for the Beep subscriber.
-The duration is set in ms.
-Frequency can be set between 585 and 7100 (~ Hz).
-*/
+// Beeps is the type for the Beep subscriber.
+// The duration is set in ms.
+// Frequency can be set between 585 and 7100 (~ Hz).
 type Beeps struct {
 	Duration  uint32
 	Frequency uint16
@@ -2,26 +2,26 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package piezospeaker
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
-/*
-Calibrate creates a subscriber to start a calibration.
-This is only needed after reflashing the bricklet plugin.
-
-The calibration plays each tone and measures the exact frequency back.
-The result of the calibration is a mapping setting value and frequency.
-This mapping is stored in the EEPROM and loaded on startup.
-
-The callback result is true after calibration.
-*/
+// Calibrate creates a subscriber to start a calibration.
+// This is only needed after reflashing the bricklet plugin.
+//
+// The calibration plays each tone and measures the exact frequency back.
+// The result of the calibration is a mapping setting value and frequency.
+// This mapping is stored in the EEPROM and loaded on startup.
+//
+// The callback result is true after calibration.
 func Calibrate(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "Calibrate"),
@@ -35,33 +35,24 @@ func Calibrate(id string, uid uint32, handler func(device.Resulter, error)) *dev
 // CalibrateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func CalibrateFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Calibration {
-	future := make(chan *Calibration)
-	defer close(future)
-	sub := Calibrate("calibratefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Calibration = nil
-			if err == nil {
-				if value, ok := r.(*Calibration); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return nil
-	}
-	return <-future
+	v, _ := CalibrateFutureContext(context.Background(), brick, connectorname, uid)
+	return v
 }
 
-// CalibrateFutureSimple is a simple future pattern version for a synchronized call of the subscriber.
-// If an error occur, the result is false.
-func CalibrateFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	c := CalibrateFuture(brick, connectorname, uid)
-	if c == nil {
-		return false
+// CalibrateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func CalibrateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Calibration, error) {
+	res, err := device.Future(ctx, brick, dest,
+		Calibrate("calibratefuture"+device.GenId(), uid, nil))
+	if err != nil {
+		return nil, err
 	}
-	return c.Done
+	if v, ok := res.(*Calibration); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Calibration is the type for the Calibrate result.
@@ -69,25 +60,12 @@ type Calibration struct {
 	Done bool // is calibration done
 }
 
-// FromPacket converts the packet payload to the Cursor type.
+// FromPacket converts the packet payload to the Calibration type.
 func (c *Calibration) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(c, p); err != nil {
 		return err
 	}
-	rc := new(CalibrationRaw)
-	err := p.Payload.Decode(rc)
-	if err == nil && rc != nil {
-		c.FromCalibrationRaw(rc)
-	}
-	return err
-}
-
-// FromCalibrationRaw converts from a CalibrationRaw to a Calibration type.
-func (c *Calibration) FromCalibrationRaw(rc *CalibrationRaw) {
-	if rc == nil || c == nil {
-		return
-	}
-	c.Done = misc.Uint8ToBool(rc.Done)
+	return p.Payload.Decode(c)
 }
 
 // String fullfill the stringer interface.
@@ -106,10 +84,7 @@ func (c *Calibration) Copy() device.Resulter {
 	if c == nil {
 		return nil
 	}
-	return &Calibration{Done: c.Done}
-}
-
-// CalibrationRaw is the real de/encoding type for a Calibration type.
-type CalibrationRaw struct {
-	Done uint8
+	return &Calibration{
+		Done: c.Done,
+	}
 }
@@ -0,0 +1,19 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package piezospeaker
+
+import (
+	"github.com/dirkjabl/bricker"
+)
+
+// CalibrateFutureSimple is a simple future pattern version for a synchronized call of the subscriber.
+// If an error occur, the result is false.
+func CalibrateFutureSimple(brick *bricker.Bricker, connectorname string, uid uint32) bool {
+	c := CalibrateFuture(brick, connectorname, uid)
+	if c == nil {
+		return false
+	}
+	return c.Done
+}
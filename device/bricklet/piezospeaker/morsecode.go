@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package piezospeaker
 
 import (
+	"context"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 )
@@ -15,10 +18,8 @@ const (
 	MorseShort = byte('.') // Short
 )
 
-/*
-MorseCode creates a subscriber for output an morse code signal.
-The maximum string size is 60.
-*/
+// MorseCode creates a subscriber for output an morse code signal.
+// The maximum string size is 60.
 func MorseCode(id string, uid uint32, m *Morse, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
 		Id:         device.FallbackId(id, "MorseCode"),
@@ -31,18 +32,18 @@ func MorseCode(id string, uid uint32, m *Morse, handler func(device.Resulter, er
 
 // MorseCodeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
-func MorseCodeFuture(brick bricker.Bricker, connectorname string, uid uint32, m *Morse) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := MorseCode("morsecodefuture"+device.GenId(), uid, m,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+func MorseCodeFuture(brick *bricker.Bricker, connectorname string, uid uint32, m *Morse) bool {
+	return MorseCodeFutureContext(context.Background(), brick, connectorname, uid, m) == nil
+}
+
+// MorseCodeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func MorseCodeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, m *Morse) error {
+	_, err := device.Future(ctx, brick, dest,
+		MorseCode("morsecodefuture"+device.GenId(), uid, m, nil))
+	return err
 }
 
 // BeepFinished creates a subscriber which is triggered if a Beep subscriber is finished.
@@ -57,13 +58,11 @@ func MorseCodeFinished(id string, uid uint32, handler func(device.Resulter, erro
 		WithPacket: false}.CreateDevice()
 }
 
-/*
-Morse is the representation of the morse code for the MorseCode subscriber.
-
-As characters are only " "(space/pause), "."(dot/short) and "-"(minus/long) allowed.
-All other characters are ignored.
-It could handle up to 60 character.
-*/
+// Morse is the representation of the morse code for the MorseCode subscriber.
+//
+// As characters are only " "(space/pause), "."(dot/short) and "-"(minus/long) allowed.
+// All other characters are ignored.
+// It could handle up to 60 character.
 type Morse struct {
 	Code      [60]byte
 	Frequency uint16
@@ -0,0 +1,28 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The raw type is kept for compatibility, the bool field of Calibration is decoded directly.
+
+package piezospeaker
+
+import (
+	misc "github.com/dirkjabl/bricker/util/miscellaneous"
+)
+
+// CalibrationRaw is the real de/encoding type for a Calibration type.
+//
+// Deprecated: Calibration is decoded directly.
+type CalibrationRaw struct {
+	Done uint8
+}
+
+// FromCalibrationRaw converts from a CalibrationRaw to a Calibration type.
+//
+// Deprecated: Calibration is decoded directly.
+func (c *Calibration) FromCalibrationRaw(rc *CalibrationRaw) {
+	if rc == nil || c == nil {
+		return
+	}
+	c.Done = misc.Uint8ToBool(rc.Done)
+}
@@ -0,0 +1,29 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package temperature
+
+// Name convert the Mode to a readable string.
+func (m *I2CMode) Name() string {
+	switch m.Value {
+	case I2CModeFast:
+		return "Fast (400kHz, Standard)"
+	case I2CModeSlow:
+		return "Slow (100kHz)"
+	default:
+		return "Unknown"
+	}
+}
+
+// Float64 convert the temperature from int16 to float64.
+func (t *Temperature) Float64() float64 {
+	f := float64(t.Value) / 100.00
+	return f
+}
+
+// Float32 convert the temperature from int16 to float32.
+func (t *Temperature) Float32() float32 {
+	f := float32(t.Value) / 100.0
+	return f
+}
@@ -2,9 +2,12 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package temperature
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -30,17 +33,17 @@ func SetI2CMode(id string, uid uint32, m *I2CMode, handler func(device.Resulter,
 // SetI2CModeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func SetI2CModeFuture(brick *bricker.Bricker, connectorname string, uid uint32, m *I2CMode) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := SetI2CMode("seti2cmodefuture"+device.GenId(), uid, m,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return SetI2CModeFutureContext(context.Background(), brick, connectorname, uid, m) == nil
+}
+
+// SetI2CModeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func SetI2CModeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32, m *I2CMode) error {
+	_, err := device.Future(ctx, brick, dest,
+		SetI2CMode("seti2cmodefuture"+device.GenId(), uid, m, nil))
+	return err
 }
 
 // GetI2CMode creates the subscriber to get the I2C mode.
@@ -51,29 +54,31 @@ func GetI2CMode(id string, uid uint32, handler func(device.Resulter, error)) *de
 		Uid:        uid,
 		Result:     &I2CMode{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // GetI2CModeFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetI2CModeFuture(brick *bricker.Bricker, connectorname string, uid uint32) *I2CMode {
-	future := make(chan *I2CMode)
-	defer close(future)
-	sub := GetI2CMode("geti2cmodefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *I2CMode = nil
-			if err == nil {
-				if value, ok := r.(*I2CMode); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetI2CModeFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetI2CModeFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetI2CModeFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*I2CMode, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetI2CMode("geti2cmodefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*I2CMode); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // I2C mode type.
@@ -81,7 +86,7 @@ type I2CMode struct {
 	Value uint8
 }
 
-// FromPacket converts from packet to Mode.
+// FromPacket converts the packet payload to the I2CMode type.
 func (m *I2CMode) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(m, p); err != nil {
 		return err
@@ -89,21 +94,15 @@ func (m *I2CMode) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(m)
 }
 
-// Name convert the Mode to a readable string.
-func (m *I2CMode) Name() string {
-	switch m.Value {
-	case I2CModeFast:
-		return "Fast (400kHz, Standard)"
-	case I2CModeSlow:
-		return "Slow (100kHz)"
-	default:
-		return "Unknown"
-	}
-}
-
 // String fullfill the stringer interface.
 func (m *I2CMode) String() string {
-	return fmt.Sprintf("I2C Mode [Value: %d Name: %s]", m.Value, m.Name())
+	txt := "I2C Mode "
+	if m == nil {
+		txt += "[nil]"
+	} else {
+		txt += fmt.Sprintf("[Value: %d Name: %s]", m.Value, m.Name())
+	}
+	return txt
 }
 
 // Copy creates a copy of the content.
@@ -111,5 +110,7 @@ func (m *I2CMode) Copy() device.Resulter {
 	if m == nil {
 		return nil
 	}
-	return &I2CMode{Value: m.Value}
+	return &I2CMode{
+		Value: m.Value,
+	}
 }
@@ -2,16 +2,24 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Temperature Bricklet.
 package temperature
 
+//go:generate go run ../gen spec.json
+
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
 )
 
+// DeviceIdentifier identifies the Temperature Bricklet (see device/name).
+const DeviceIdentifier = uint16(216)
+
 const (
 	function_get_temperature                    = uint8(1)
 	function_set_i2c_mode                       = uint8(10)
@@ -34,29 +42,31 @@ func GetTemperature(id string, uid uint32, handler func(device.Resulter, error))
 		Uid:        uid,
 		Result:     &Temperature{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetTemperatureFuture is a future pattern version for a synchronized calll of the subscriber.
+// GetTemperatureFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetTemperatureFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Temperature {
-	future := make(chan *Temperature)
-	defer close(future)
-	sub := GetTemperature("gettemperaturefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Temperature = nil
-			if err == nil {
-				if value, ok := r.(*Temperature); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetTemperatureFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetTemperatureFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetTemperatureFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Temperature, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetTemperature("gettemperaturefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	return <-future
+	if v, ok := res.(*Temperature); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Temperature type for a single temperature.
@@ -65,7 +75,7 @@ type Temperature struct {
 	Value int16
 }
 
-// FromPacket create from a packet a Temperature.
+// FromPacket converts the packet payload to the Temperature type.
 func (t *Temperature) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(t, p); err != nil {
 		return err
@@ -73,18 +83,6 @@ func (t *Temperature) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(t)
 }
 
-// Float64 convert the temperature from int16 to float64.
-func (t *Temperature) Float64() float64 {
-	f := float64(t.Value) / 100.00
-	return f
-}
-
-// Float32 convert the temperature from int16 to float32.
-func (t *Temperature) Float32() float32 {
-	f := float32(t.Value) / 100.0
-	return f
-}
-
 // String fullfill the stringer interface.
 func (t *Temperature) String() string {
 	txt := "Temperature "
@@ -101,5 +99,7 @@ func (t *Temperature) Copy() device.Resulter {
 	if t == nil {
 		return nil
 	}
-	return &Temperature{Value: t.Value}
+	return &Temperature{
+		Value: t.Value,
+	}
 }
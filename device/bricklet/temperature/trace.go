@@ -0,0 +1,29 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package temperature
+
+import (
+	"github.com/dirkjabl/bricker/device"
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Temperature Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_set_debounce_period:                {Name: "SetDebouncePeriod", Request: func() interface{} { return new(device.Debounce) }},
+		function_get_debounce_period:                {Name: "GetDebouncePeriod", Response: func() interface{} { return new(device.Debounce) }},
+		function_set_i2c_mode:                       {Name: "SetI2CMode", Request: func() interface{} { return new(I2CMode) }},
+		function_get_i2c_mode:                       {Name: "GetI2CMode", Response: func() interface{} { return new(I2CMode) }},
+		function_set_temperature_callback_period:    {Name: "SetTemperatureCallbackPeriod", Request: func() interface{} { return new(device.Period) }},
+		function_get_temperature_callback_period:    {Name: "GetTemperatureCallbackPeriod", Response: func() interface{} { return new(device.Period) }},
+		callback_temperature:                        {Name: "TemperaturePeriod", Response: func() interface{} { return new(Temperature) }},
+		function_get_temperature:                    {Name: "GetTemperature", Response: func() interface{} { return new(Temperature) }},
+		function_set_temperature_callback_threshold: {Name: "SetTemperatureCallbackThreshold", Request: func() interface{} { return new(device.Threshold16) }},
+		function_get_temperature_callback_threshold: {Name: "GetTemperatureCallbackThreshold", Response: func() interface{} { return new(device.Threshold16) }},
+		callback_temperature_reached:                {Name: "TemperatureReached", Response: func() interface{} { return new(Temperature) }},
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package tilt
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/device"
+)
+
+// Bricklet is the handle for a Tilt Bricklet.
+// Every method is a synchronized call (see the FutureContext functions) or a callback subscription.
+// The uid is the destination of every call, the bricker resolves the connector (or the member
+// of a connector group) for every subscription (see bricker.ConnectorFor).
+type Bricklet struct {
+	brick  *bricker.Bricker
+	Uid    uint32
+	Buffer int // buffer size of the callback channels
+}
+
+// New creates the handle for the bricklet with the given uid.
+func New(brick *bricker.Bricker, uid uint32) *Bricklet {
+	return &Bricklet{brick: brick, Uid: uid}
+}
+
+// GetTiltState is the handle version of GetTiltStateFutureContext.
+func (bl *Bricklet) GetTiltState(ctx context.Context) (*TiltState, error) {
+	return GetTiltStateFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// EnableTiltStateCallback is the handle version of EnableTiltStateCallbackFutureContext.
+func (bl *Bricklet) EnableTiltStateCallback(ctx context.Context) error {
+	return EnableTiltStateCallbackFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// DisableTiltStateCallback is the handle version of DisableTiltStateCallbackFutureContext.
+func (bl *Bricklet) DisableTiltStateCallback(ctx context.Context) error {
+	return DisableTiltStateCallbackFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// IsTiltStateCallbackEnabled is the handle version of IsTiltStateCallbackEnabledFutureContext.
+func (bl *Bricklet) IsTiltStateCallbackEnabled(ctx context.Context) (*Enabled, error) {
+	return IsTiltStateCallbackEnabledFutureContext(ctx, bl.brick, bl.Uid, bl.Uid)
+}
+
+// TiltStateChanged subscribes the TiltStateChanged callback, until the context is done.
+// The results come in the returned channel (with a buffer of Buffer results),
+// the channel is closed after the context is done or the connector goes away.
+func (bl *Bricklet) TiltStateChanged(ctx context.Context) (<-chan *TiltState, error) {
+	return device.Channel[*TiltState](ctx, bl.brick, bl.Uid,
+		TiltStateChanged("tiltstatechanged"+device.GenId(), bl.Uid, nil), bl.Buffer)
+}
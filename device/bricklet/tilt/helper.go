@@ -0,0 +1,22 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tilt
+
+// Name gives a readable representation of the tilt state as string.
+func (t *TiltState) Name() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Value {
+	case TiltClosed:
+		return "Closed: The ball in the tilt switch closes the circuit."
+	case TiltOpen:
+		return "Open: The ball in the tilt switch does not close the circuit."
+	case TiltClosedVibrating:
+		return "Closed Vibrating: The tilt switch is in motion (rapid change between open and close)."
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The raw type is kept for compatibility, the bool field of Enabled is decoded directly.
+
+package tilt
+
+import (
+	misc "github.com/dirkjabl/bricker/util/miscellaneous"
+)
+
+// EnabledRaw is the real de/encoding type for a Enabled.
+//
+// Deprecated: Enabled is decoded directly.
+type EnabledRaw struct {
+	Value uint8
+}
+
+// FromEnabledRaw converts the EnabledRaw into a Enabled.
+//
+// Deprecated: Enabled is decoded directly.
+func (e *Enabled) FromEnabledRaw(er *EnabledRaw) {
+	if e == nil || er == nil {
+		return
+	}
+	e.Value = misc.Uint8ToBool(er.Value)
+}
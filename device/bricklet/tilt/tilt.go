@@ -2,9 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 // Collection of subscriber for the Tilt Bricklet.
 package tilt
 
+//go:generate go run ../gen spec.json
+
+// DeviceIdentifier identifies the Tilt Bricklet (see device/name).
+const DeviceIdentifier = uint16(239)
+
 const (
 	function_get_tilt_state                 = uint8(1)
 	function_enable_tilt_state_callback     = uint8(2)
@@ -2,14 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
 package tilt
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
 	"github.com/dirkjabl/bricker/net/packet"
-	misc "github.com/dirkjabl/bricker/util/miscellaneous"
 )
 
 // GetTiltState creates a subscriber to get the current tilt state.
@@ -20,29 +22,31 @@ func GetTiltState(id string, uid uint32, handler func(device.Resulter, error)) *
 		Uid:        uid,
 		Result:     &TiltState{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// GetTiltStateFuture is a future pattern version for a synchronized calll of the subscriber.
+// GetTiltStateFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func GetTiltStateFuture(brick *bricker.Bricker, connectorname string, uid uint32) *TiltState {
-	future := make(chan *TiltState)
-	defer close(future)
-	sub := GetTiltState("gettiltstatefuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *TiltState = nil
-			if err == nil {
-				if value, ok := r.(*TiltState); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := GetTiltStateFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetTiltStateFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func GetTiltStateFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*TiltState, error) {
+	res, err := device.Future(ctx, brick, dest,
+		GetTiltState("gettiltstatefuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*TiltState); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // EnableTiltStateCallback creates a subscriber to enable the TiltStateChanged callback.
@@ -52,49 +56,51 @@ func EnableTiltStateCallback(id string, uid uint32, handler func(device.Resulter
 		Fid:        function_enable_tilt_state_callback,
 		Uid:        uid,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // EnableTiltStateCallbackFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func EnableTiltStateCallbackFuture(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := EnableTiltStateCallback("enabletiltstatecallbackfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return EnableTiltStateCallbackFutureContext(context.Background(), brick, connectorname, uid) == nil
+}
+
+// EnableTiltStateCallbackFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func EnableTiltStateCallbackFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) error {
+	_, err := device.Future(ctx, brick, dest,
+		EnableTiltStateCallback("enabletiltstatecallbackfuture"+device.GenId(), uid, nil))
+	return err
 }
 
 // DisableTiltStateCallback creates a subscriber to disable the TiltStateChanged callback.
 func DisableTiltStateCallback(id string, uid uint32, handler func(device.Resulter, error)) *device.Device {
 	return device.Generator{
-		Id:         device.FallbackId(id, "EnableTiltStateCallback"),
+		Id:         device.FallbackId(id, "DisableTiltStateCallback"),
 		Fid:        function_disable_tilt_state_callback,
 		Uid:        uid,
 		Handler:    handler,
+		Restore:    true,
 		WithPacket: true}.CreateDevice()
 }
 
 // DisableTiltStateCallbackFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is false.
 func DisableTiltStateCallbackFuture(brick *bricker.Bricker, connectorname string, uid uint32) bool {
-	future := make(chan bool)
-	defer close(future)
-	sub := DisableTiltStateCallback("disabletiltstatecallbackfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			future <- device.IsEmptyResultOk(r, err)
-		})
-	err := brick.Subscribe(sub, connectorname)
-	if err != nil {
-		return false
-	}
-	return <-future
+	return DisableTiltStateCallbackFutureContext(context.Background(), brick, connectorname, uid) == nil
+}
+
+// DisableTiltStateCallbackFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is the error.
+func DisableTiltStateCallbackFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) error {
+	_, err := device.Future(ctx, brick, dest,
+		DisableTiltStateCallback("disabletiltstatecallbackfuture"+device.GenId(), uid, nil))
+	return err
 }
 
 // IsTiltStateCallbackEnabled creates a subscriber for calling, if the TiltStateChanged callback is enabled.
@@ -105,29 +111,31 @@ func IsTiltStateCallbackEnabled(id string, uid uint32, handler func(device.Resul
 		Uid:        uid,
 		Result:     &Enabled{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
-// IsTiltStateCallbackEnabledFuture is a future pattern version for a synchronized calll of the subscriber.
+// IsTiltStateCallbackEnabledFuture is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
 func IsTiltStateCallbackEnabledFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Enabled {
-	future := make(chan *Enabled)
-	defer close(future)
-	sub := IsTiltStateCallbackEnabled("istiltstatecallbackenabledfuture"+device.GenId(), uid,
-		func(r device.Resulter, err error) {
-			var v *Enabled = nil
-			if err == nil {
-				if value, ok := r.(*Enabled); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+	v, _ := IsTiltStateCallbackEnabledFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// IsTiltStateCallbackEnabledFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// The destination is a connector or group name or the uid of the device (see bricker.Subscribe).
+// If an error occur, the result is nil and the error.
+func IsTiltStateCallbackEnabledFutureContext(ctx context.Context, brick *bricker.Bricker, dest interface{}, uid uint32) (*Enabled, error) {
+	res, err := device.Future(ctx, brick, dest,
+		IsTiltStateCallbackEnabled("istiltstatecallbackenabledfuture"+device.GenId(), uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if v, ok := res.(*Enabled); ok {
+		return v, nil
 	}
-	return <-future
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // TiltStateChanged creates a subscriber which is called every time the tilt state changed.
@@ -142,20 +150,18 @@ func TiltStateChanged(id string, uid uint32, handler func(device.Resulter, error
 		WithPacket: false}.CreateDevice()
 }
 
-/*
-TiltState is a type for the tilt state.
-
-The state can either be
-
-    0 = Closed: The ball in the tilt switch closes the circuit.
-    1 = Open: The ball in the tilt switch does not close the circuit.
-    2 = Closed Vibrating: The tilt switch is in motion (rapid change between open and close).
-*/
+// TiltState is a type for the tilt state.
+//
+// The state can either be
+//
+//	0 = Closed: The ball in the tilt switch closes the circuit.
+//	1 = Open: The ball in the tilt switch does not close the circuit.
+//	2 = Closed Vibrating: The tilt switch is in motion (rapid change between open and close).
 type TiltState struct {
 	Value uint8
 }
 
-// FromPacket create from a packet a TiltState.
+// FromPacket converts the packet payload to the TiltState type.
 func (t *TiltState) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(t, p); err != nil {
 		return err
@@ -163,23 +169,6 @@ func (t *TiltState) FromPacket(p *packet.Packet) error {
 	return p.Payload.Decode(t)
 }
 
-// Name gives a readable representation of the tilt state as string.
-func (t *TiltState) Name() string {
-	if t == nil {
-		return ""
-	}
-	switch t.Value {
-	case TiltClosed:
-		return "Closed: The ball in the tilt switch closes the circuit."
-	case TiltOpen:
-		return "Open: The ball in the tilt switch does not close the circuit."
-	case TiltClosedVibrating:
-		return "Closed Vibrating: The tilt switch is in motion (rapid change between open and close)."
-	default:
-		return "Unknown"
-	}
-}
-
 // String fullfill the stringer interface.
 func (t *TiltState) String() string {
 	txt := "Tilt state "
@@ -196,7 +185,9 @@ func (t *TiltState) Copy() device.Resulter {
 	if t == nil {
 		return nil
 	}
-	return &TiltState{Value: t.Value}
+	return &TiltState{
+		Value: t.Value,
+	}
 }
 
 // Enabled is a type for showing if the TiltStateChanged callback is enabled or disabled.
@@ -209,12 +200,7 @@ func (e *Enabled) FromPacket(p *packet.Packet) error {
 	if err := device.CheckForFromPacket(e, p); err != nil {
 		return err
 	}
-	er := new(EnabledRaw)
-	err := p.Payload.Decode(er)
-	if err == nil && er != nil {
-		e.FromEnabledRaw(er)
-	}
-	return err
+	return p.Payload.Decode(e)
 }
 
 // String fullfill the stringer interface.
@@ -233,18 +219,7 @@ func (e *Enabled) Copy() device.Resulter {
 	if e == nil {
 		return nil
 	}
-	return &Enabled{Value: e.Value}
-}
-
-// FromEnabledRaw converts the EnabledRaw into a Enabled.
-func (e *Enabled) FromEnabledRaw(er *EnabledRaw) {
-	if e == nil || er == nil {
-		return
+	return &Enabled{
+		Value: e.Value,
 	}
-	e.Value = misc.Uint8ToBool(er.Value)
-}
-
-// EnabledRaw is the real de/encoding type for a Enabled.
-type EnabledRaw struct {
-	Value uint8
 }
@@ -0,0 +1,22 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by device/bricklet/gen from spec.json; DO NOT EDIT.
+
+package tilt
+
+import (
+	"github.com/dirkjabl/bricker/trace"
+)
+
+// Internal function: init registers the functions and callbacks of the Tilt Bricklet for the tracing.
+func init() {
+	trace.Register(DeviceIdentifier, map[uint8]trace.Function{
+		function_get_tilt_state:                 {Name: "GetTiltState", Response: func() interface{} { return new(TiltState) }},
+		function_enable_tilt_state_callback:     {Name: "EnableTiltStateCallback"},
+		function_disable_tilt_state_callback:    {Name: "DisableTiltStateCallback"},
+		function_is_tilt_state_callback_enabled: {Name: "IsTiltStateCallbackEnabled", Response: func() interface{} { return new(Enabled) }},
+		callback_tilt_state:                     {Name: "TiltStateChanged", Response: func() interface{} { return new(TiltState) }},
+	})
+}
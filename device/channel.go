@@ -0,0 +1,45 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+)
+
+/*
+Channel subscribes the given callback device to the bricker and delivers the typed results
+into the returned channel (see bricker.Channel), e.g. for the humidity callback:
+
+	c, err := device.Channel[*humidity.Humidity](ctx, brick, "local", humidity.HumidityPeriod("", uid, nil), 10)
+
+The handler of the device is not used, the device should be created with a nil handler.
+The channel has a buffer for size results, results with an error or another type are dropped.
+After the context is done, the device is unsubscribed and the channel is closed.
+The channel is also closed, when the connector goes away.
+*/
+func Channel[T Resulter](ctx context.Context, brick *bricker.Bricker, dest interface{}, d *Device, size int) (<-chan T, error) {
+	events, err := brick.Channel(ctx, d.Id(), d.Subscription(), dest, size)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan T)
+	go func() {
+		defer close(c)
+		for e := range events {
+			r, err := d.decode(e)
+			if err != nil {
+				continue
+			}
+			if v, ok := r.(T); ok {
+				select {
+				case c <- v:
+				case <-ctx.Done():
+				}
+			}
+		}
+	}()
+	return c, nil
+}
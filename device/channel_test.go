@@ -0,0 +1,59 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"testing"
+	"time"
+)
+
+func TestChannel(t *testing.T) {
+	brick, v := newFutureBricker(t)
+	defer v.Done()
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 1, 200), func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderPayload(1, 3, false, &Period{Value: 42}))
+	})
+	d := Generator{
+		Id:         "channel" + GenId(),
+		Fid:        3,
+		Uid:        1,
+		Result:     &Period{},
+		IsCallback: true,
+		WithPacket: true}.CreateDevice()
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := Channel[*Period](ctx, brick, "virtual", d, 2)
+	if err != nil {
+		t.Fatalf("Error TestChannel: unexpected error (%s).", err.Error())
+	}
+	for i := 0; i < 2; i++ {
+		v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(1, 200, false)))
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case pe := <-c:
+			if pe.Value != 42 {
+				t.Fatalf("Error TestChannel: wrong result (%v).", pe)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestChannel: no result.")
+		}
+	}
+	cancel()
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Fatalf("Error TestChannel: result after cancel.")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestChannel: channel not closed after cancel.")
+	}
+	if brick.Unsubscribe(d) == nil {
+		t.Fatalf("Error TestChannel: device is still subscribed after cancel.")
+	}
+}
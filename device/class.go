@@ -0,0 +1,23 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"github.com/dirkjabl/bricker/subscription"
+)
+
+/*
+Class changes the device into a subscriber for the callback of all devices with the device identifier
+(e.g. all Temperature Bricklets), also for the devices, which are connected later.
+The new subscription matches only the function id of the device and has no request,
+so the callback period or threshold has to be set for every device.
+Every bricklet package has the device identifier as constant (DeviceIdentifier).
+*/
+func Class(d *Device, identifier uint16) *Device {
+	if s := d.Subscription(); s != nil {
+		d.SetSubscription(subscription.NewClass(identifier, s.FunctionID))
+	}
+	return d
+}
@@ -0,0 +1,71 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"sync"
+	"testing"
+)
+
+func TestClass(t *testing.T) {
+	d := Class(Generator{
+		Id:         "class",
+		Fid:        8,
+		Uid:        1,
+		Result:     &Period{},
+		IsCallback: true,
+		WithPacket: true}.CreateDevice(), 216)
+	s := d.Subscription()
+	if s.Hash() != hash.New(hash.ChoosenFunctionID, 0, 8) || s.Request != nil || !s.Callback {
+		t.Fatalf("Error TestClass: wrong subscription (%s).", s)
+	}
+	if s.Filter == nil || s.Filter.DeviceIdentifier != 216 {
+		t.Fatalf("Error TestClass: wrong filter (%s).", s.Filter)
+	}
+	if d.Id() != "class" || d.Result() == nil {
+		t.Fatalf("Error TestClass: device changed (%s).", d)
+	}
+}
+
+// The callbacks of two devices of a class are delivered in parallel (run with -race).
+func TestClassParallel(t *testing.T) {
+	const count = 200
+	values := make(chan uint32, 2*count)
+	d := Class(Generator{
+		Id:         "class",
+		Fid:        8,
+		Result:     &Period{},
+		IsCallback: true,
+		Handler: func(r Resulter, err error) { // takes no lock
+			if err == nil {
+				values <- r.(*Period).Value
+			}
+		}}.CreateDevice(), 216)
+	var wg sync.WaitGroup
+	for _, uid := range []uint32{1, 2} {
+		wg.Add(1)
+		go func(uid uint32) { // like the queue of a device
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				d.Notify(event.NewPacket(packet.NewSimpleHeaderPayload(uid, 8, false, &Period{Value: uid})))
+			}
+		}(uid)
+	}
+	wg.Wait()
+	close(values)
+	received := make(map[uint32]int)
+	for v := range values {
+		received[v]++
+	}
+	if received[1] != count || received[2] != count {
+		t.Fatalf("Error TestClassParallel: wrong results (%v).", received)
+	}
+	if d.Result().(*Period).Value != 0 {
+		t.Fatalf("Error TestClassParallel: result of the device changed (%s).", d.Result())
+	}
+}
@@ -9,6 +9,7 @@ package device
 import (
 	"fmt"
 	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/errors"
 	"github.com/dirkjabl/bricker/subscription"
 	"github.com/dirkjabl/bricker/util/generator"
 )
@@ -111,26 +112,37 @@ func FallbackId(id, fb string) string {
 }
 
 // Notify process about the result event.
-// The handler routine (callback or event listner) gets only an error value.
-// The result of the event is in the resulter value of the device.
+// The handler routine (callback or event listner) gets a decoded copy of the resulter value
+// of the device, so events of more than one device (see Class) could be notified in parallel.
 func (d *Device) Notify(e *event.Event) {
 	if d == nil {
 		return
 	}
+	d.Handler()(d.decode(e))
+}
+
+// Internal method: decode converts the event into a copy of the result of the device.
+// The result of the device is not changed, every event gets its own copy.
+// The result is nil, if the event has an error or does not match the subscription.
+func (d *Device) decode(e *event.Event) (Resulter, error) {
 	if e == nil {
-		d.Handler()(nil, NewDeviceError(ErrorNoEvent))
-		return
+		return nil, NewDeviceError(ErrorNoEvent)
 	}
 	var err error = e.Err
 	if e.Packet != nil && e.Err == nil && e.Packet.Head.FunctionID == d.Subscription().FunctionID {
-		err = d.Result().FromPacket(e.Packet)
-		d.Handler()(d.Result().Copy(), err)
-	} else {
-		if err == nil {
-			err = NewDeviceError(ErrorNotMatchingSubscription)
+		if ec := e.Packet.Head.ErrorCode(); ec.Type != errors.ErrorOK { // brickd answers with an error code
+			return nil, ec
 		}
-		d.Handler()(nil, err)
+		r := d.Result().Copy()
+		if r == nil {
+			return nil, NewDeviceError(ErrorNoMemoryForResult)
+		}
+		return r, r.FromPacket(e.Packet)
+	}
+	if err == nil {
+		err = NewDeviceError(ErrorNotMatchingSubscription)
 	}
+	return nil, err
 }
 
 // String fullfill the stringer interface.
@@ -11,6 +11,7 @@ const (
 	ErrorNoMemoryForResult
 	ErrorNoPacketToConvert
 	ErrorNoEvent
+	ErrorUnexpectedResult
 )
 
 // Error type for encoding or decoding packets for devices like bricks or bricklets.
@@ -36,6 +37,8 @@ func (e DeviceError) Error() string {
 		return "No packet for converting or notify."
 	case ErrorNoEvent:
 		return "No event for converting or notify."
+	case ErrorUnexpectedResult:
+		return "Result has not the expected type."
 	case ErrorUnknown:
 		fallthrough
 	default:
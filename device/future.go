@@ -0,0 +1,45 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+)
+
+/*
+Future subscribes the given device to the bricker and waits for the first result.
+The handler of the device will be replaced, so the device should be created with a nil handler.
+The destination is the same as for the Subscribe method of the bricker (connector name or uid).
+
+Future returns, when the result comes in or the context is done.
+If the context is done first, the device will be unsubscribed and the error of the context is returned
+(context.DeadlineExceeded for a timeout, context.Canceled for a cancelation).
+An error code of the brick daemon is returned as *errors.Error (package net/errors) and
+a missing or closed connector is returned as bricker.Error.
+*/
+func Future(ctx context.Context, brick *bricker.Bricker, dest interface{}, d *Device) (Resulter, error) {
+	type result struct {
+		r   Resulter
+		err error
+	}
+	future := make(chan result, 1)
+	d.SetHandler(func(r Resulter, err error) {
+		select {
+		case future <- result{r, err}:
+		default: // only the first result counts
+		}
+	})
+	if err := brick.Subscribe(d, dest); err != nil {
+		return nil, err
+	}
+	select {
+	case res := <-future:
+		return res.r, res.err
+	case <-ctx.Done():
+		brick.Unsubscribe(d)
+		return nil, ctx.Err()
+	}
+}
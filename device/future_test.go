@@ -0,0 +1,108 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/errors"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"testing"
+	"time"
+)
+
+func newFutureBricker(t *testing.T) (*bricker.Bricker, *virtual.Virtual) {
+	brick := bricker.New()
+	v := virtual.New()
+	if err := brick.Attach(v, "virtual"); err != nil {
+		t.Fatalf("Error %s: could not attach virtual connector (%s).", t.Name(), err.Error())
+	}
+	return brick, v
+}
+
+func newFutureDevice() *Device {
+	return Generator{
+		Id:         "future" + GenId(),
+		Fid:        2,
+		Uid:        1,
+		Result:     &Period{},
+		WithPacket: true}.CreateDevice()
+}
+
+func TestFuture(t *testing.T) {
+	brick, v := newFutureBricker(t)
+	defer v.Done()
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 1, 2), func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderPayload(1, 2, false, &Period{Value: 100}))
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	r, err := Future(ctx, brick, "virtual", newFutureDevice())
+	if err != nil {
+		t.Fatalf("Error TestFuture: unexpected error (%s).", err.Error())
+	}
+	if pe, ok := r.(*Period); !ok || pe.Value != 100 {
+		t.Fatalf("Error TestFuture: wrong result (%v).", r)
+	}
+}
+
+func TestFutureTimeout(t *testing.T) {
+	brick, v := newFutureBricker(t)
+	defer v.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	d := newFutureDevice()
+	_, err := Future(ctx, brick, "virtual", d)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Error TestFutureTimeout: expected deadline exceeded, get (%v).", err)
+	}
+	if brick.Unsubscribe(d) == nil {
+		t.Fatalf("Error TestFutureTimeout: device is still subscribed after timeout.")
+	}
+}
+
+func TestFutureErrorCode(t *testing.T) {
+	brick, v := newFutureBricker(t)
+	defer v.Done()
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 1, 2), func(e *event.Event) *event.Event {
+		p := packet.NewSimpleHeaderOnly(1, 2, false)
+		p.Head.ErrorCodeAndFutureUse = errors.ErrorFUNCTIONNOTSUPPORTED << 6
+		return event.NewPacket(p)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := Future(ctx, brick, "virtual", newFutureDevice())
+	if e, ok := err.(*errors.Error); !ok || e.Type != errors.ErrorFUNCTIONNOTSUPPORTED {
+		t.Fatalf("Error TestFutureErrorCode: expected error code, get (%v).", err)
+	}
+}
+
+func TestFutureConnectorClosed(t *testing.T) {
+	brick, v := newFutureBricker(t)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		v.Done()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := Future(ctx, brick, "virtual", newFutureDevice())
+	if e, ok := err.(bricker.Error); !ok || e.Code != bricker.ErrorConnectorClosed {
+		t.Fatalf("Error TestFutureConnectorClosed: expected closed connector error, get (%v).", err)
+	}
+}
+
+func TestFutureConnectorNotExists(t *testing.T) {
+	brick, v := newFutureBricker(t)
+	defer v.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := Future(ctx, brick, "unknown", newFutureDevice())
+	if e, ok := err.(bricker.Error); !ok || e.Code != bricker.ErrorConnectorNameNotExists {
+		t.Fatalf("Error TestFutureConnectorNotExists: expected missing connector error, get (%v).", err)
+	}
+}
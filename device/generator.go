@@ -23,6 +23,9 @@ type Generator struct {
 	Handler    func(Resulter, error) // The callback/event handler function to call on an event.
 	IsCallback bool                  // This is a callback and comes often, not only once.
 	WithPacket bool                  // This subscriber should create a calling (to send) packet.
+	Restore    bool                  // The calling packet configures the device and should be sent again after a reconnect.
+	Idempotent bool                  // The calling packet could be sent again without a side effect (see bricker.RetryPolicy).
+	Disable    bool                  // The calling packet enables callbacks, with a zero payload it disables them (see bricker.Shutdown).
 }
 
 /*
@@ -51,6 +54,9 @@ func (g Generator) CreateDevice() *Device {
 		r = &EmptyResult{}
 	}
 	sub := subscription.New(hash.ChoosenFunctionIDUid, g.Uid, g.Fid, p, g.IsCallback)
+	sub.Restore = g.Restore
+	sub.Idempotent = g.Idempotent
+	sub.Disable = g.Disable
 	return NewSubscriptionResulterHandler(id, sub, r, g.Handler)
 }
 
@@ -60,7 +66,8 @@ func (g Generator) String() string {
 	txt += fmt.Sprintf("Id: %s, UID: %d, Function ID: %d, ", g.Id, g.Uid, g.Fid)
 	txt += fmt.Sprintf("Has Data: %t, ", (g.Data != nil))
 	txt += fmt.Sprintf("Has Resulter: %t, ", (g.Result != nil))
-	txt += fmt.Sprintf("Is Callback: %t, With Packet: %t", g.IsCallback, g.WithPacket)
+	txt += fmt.Sprintf("Is Callback: %t, With Packet: %t, Restore: %t, Idempotent: %t, Disable: %t",
+		g.IsCallback, g.WithPacket, g.Restore, g.Idempotent, g.Disable)
 	txt += "]"
 	return txt
 }
@@ -6,6 +6,7 @@
 package identity
 
 import (
+	"context"
 	"fmt"
 	"github.com/dirkjabl/bricker"
 	"github.com/dirkjabl/bricker/device"
@@ -26,30 +27,30 @@ func GetIdentity(id string, uid uint32, handler func(device.Resulter, error)) *d
 		Uid:        uid,
 		Result:     &Identity{},
 		Handler:    handler,
+		Idempotent: true,
 		WithPacket: true}.CreateDevice()
 }
 
 // Future is a future pattern version for a synchronized call of the subscriber.
 // If an error occur, the result is nil.
-func GetIdentityFuture(brick bricker.Bricker, connectorname string, uid uint32) *Identity {
-	future := make(chan *Identity)
-	defer close(future)
-	sub := GetIdentity("getidentityfuture", uid,
-		func(r device.Resulter, err error) {
-			var v *Identity = nil
-			if err == nil {
-				if value, ok := r.(*Identity); ok {
-					v = value
-				}
-			}
-			future <- v
-		})
-	err := brick.Subscribe(sub, connectorname)
+func GetIdentityFuture(brick *bricker.Bricker, connectorname string, uid uint32) *Identity {
+	v, _ := GetIdentityFutureContext(context.Background(), brick, connectorname, uid)
+	return v
+}
+
+// GetIdentityFutureContext is a context aware future pattern version for a synchronized call of the subscriber.
+// It waits for the result until the context is done, then the subscriber will be unsubscribed.
+// If an error occur, the result is nil and the error.
+func GetIdentityFutureContext(ctx context.Context, brick *bricker.Bricker, connectorname string, uid uint32) (*Identity, error) {
+	res, err := device.Future(ctx, brick, connectorname,
+		GetIdentity("getidentityfuture", uid, nil))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	v := <-future
-	return v
+	if v, ok := res.(*Identity); ok {
+		return v, nil
+	}
+	return nil, device.NewDeviceError(device.ErrorUnexpectedResult)
 }
 
 // Result type for a getidentity subscriber.
@@ -0,0 +1,238 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/event"
+	"sort"
+	"sync"
+)
+
+// DefaultQueueDepth is the default number of events, which could wait in the queue of a device.
+const DefaultQueueDepth = 64
+
+// Overflow is the policy for a full device queue.
+type Overflow uint8
+
+// All overflow policies.
+const (
+	OverflowBlock      Overflow = iota // the reader of the connector waits, until the queue has space
+	OverflowDropOldest                 // the oldest waiting event is dropped
+	OverflowDropNewest                 // the new event is dropped
+)
+
+// String fullfill the stringer interface.
+func (o Overflow) String() string {
+	switch o {
+	case OverflowBlock:
+		return "Block"
+	case OverflowDropOldest:
+		return "DropOldest"
+	case OverflowDropNewest:
+		return "DropNewest"
+	default:
+		return "Unknown"
+	}
+}
+
+// DispatchStats are the counters of the event delivery of a connector.
+type DispatchStats struct {
+	Connector string // name of the connector
+	Queues    int    // device queues with waiting or running events
+	Queued    int    // events, which wait in the queues
+	Delivered uint64 // events delivered to the subscriber
+	Dropped   uint64 // events dropped by the overflow policy
+	Blocked   uint64 // how often the reader of the connector waits for space in a queue
+}
+
+// Internal type: queueKey identifies the queue of a device.
+type queueKey struct {
+	connector string
+	uid       uint32
+}
+
+// Internal type: queue holds the waiting events of a device.
+// A queue has at most one worker, so the events of a device are delivered in order.
+type queue struct {
+	events []*event.Event
+}
+
+// Internal type: dispatcher delivers the incoming events over a queue per device (connector and uid).
+// Events of the same device are delivered in order, events of different devices in parallel.
+type dispatcher struct {
+	depth    int
+	overflow Overflow
+	handler  func(*event.Event)
+	lock     sync.Mutex // guards all following fields
+	space    *sync.Cond // signals free space in a queue
+	queues   map[queueKey]*queue
+	stats    map[string]*DispatchStats
+	active   int           // running deliveries (workers and direct deliveries)
+	idle     chan struct{} // closed, when no delivery runs (nil without a waiter)
+}
+
+// Internal function: newDispatcher creates a dispatcher, which delivers the events to the handler.
+func newDispatcher(handler func(*event.Event)) *dispatcher {
+	d := &dispatcher{
+		depth:    DefaultQueueDepth,
+		overflow: OverflowBlock,
+		handler:  handler,
+		queues:   make(map[queueKey]*queue),
+		stats:    make(map[string]*DispatchStats)}
+	d.space = sync.NewCond(&d.lock)
+	return d
+}
+
+// Internal method: enqueue puts the event into the queue of its device.
+// Events without a packet and responses (sequence number is set) have no order,
+// they are delivered directly.
+func (d *dispatcher) enqueue(e *event.Event) {
+	if e.Packet == nil || e.Packet.Head == nil || e.Packet.Head.Sequence() != 0 {
+		d.lock.Lock()
+		d.active++
+		d.lock.Unlock()
+		go d.direct(e)
+		return
+	}
+	k := queueKey{e.ConnectorName, e.Packet.Head.Uid}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	st := d.statsOf(k.connector)
+	q, ok := d.queues[k]
+	for ok && len(q.events) >= d.depth {
+		switch d.overflow {
+		case OverflowDropOldest:
+			q.events = q.events[1:]
+			st.Dropped++
+		case OverflowDropNewest:
+			st.Dropped++
+			return
+		default:
+			st.Blocked++
+			d.space.Wait()
+			q, ok = d.queues[k] // the worker could be done or the queue released
+		}
+	}
+	if !ok {
+		q = &queue{events: make([]*event.Event, 0, 1)}
+		d.queues[k] = q
+		d.active++
+		go d.work(k, q)
+	}
+	q.events = append(q.events, e)
+}
+
+// Internal method: work delivers the events of a queue in order, until the queue is empty.
+func (d *dispatcher) work(k queueKey, q *queue) {
+	for {
+		d.lock.Lock()
+		if len(q.events) == 0 || d.queues[k] != q {
+			if d.queues[k] == q {
+				delete(d.queues, k)
+			}
+			d.finish()
+			d.lock.Unlock()
+			return
+		}
+		e := q.events[0]
+		q.events[0] = nil
+		q.events = q.events[1:]
+		d.statsOf(k.connector).Delivered++
+		d.space.Broadcast()
+		d.lock.Unlock()
+		d.handler(e)
+	}
+}
+
+// Internal method: direct delivers a event without a queue.
+func (d *dispatcher) direct(e *event.Event) {
+	d.handler(e)
+	d.lock.Lock()
+	d.finish()
+	d.lock.Unlock()
+}
+
+// Internal method: finish counts a finished delivery and wakes up the waiters, if no delivery runs.
+// The caller has to hold the lock.
+func (d *dispatcher) finish() {
+	d.active--
+	if d.active == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// Internal method: wait waits, until no delivery runs or the context is done.
+// The result is the number of the still running deliveries.
+func (d *dispatcher) wait(ctx context.Context) int {
+	for {
+		d.lock.Lock()
+		if d.active == 0 {
+			d.lock.Unlock()
+			return 0
+		}
+		if d.idle == nil {
+			d.idle = make(chan struct{})
+		}
+		idle := d.idle
+		d.lock.Unlock()
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			d.lock.Lock()
+			defer d.lock.Unlock()
+			return d.active
+		}
+	}
+}
+
+// Internal method: release drops all waiting events and the counters of the connector.
+func (d *dispatcher) release(n string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for k := range d.queues {
+		if k.connector == n {
+			delete(d.queues, k)
+		}
+	}
+	delete(d.stats, n)
+	d.space.Broadcast()
+}
+
+// Internal method: statsOf gives the counters of the connector.
+// The caller has to hold the lock.
+func (d *dispatcher) statsOf(n string) *DispatchStats {
+	st, ok := d.stats[n]
+	if !ok {
+		st = &DispatchStats{Connector: n}
+		d.stats[n] = st
+	}
+	return st
+}
+
+// Internal method: snapshot returns the counters of all connectors, sorted by the connector name.
+func (d *dispatcher) snapshot() []DispatchStats {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	result := make([]DispatchStats, 0, len(d.stats))
+	for _, st := range d.stats {
+		s := *st
+		for k, q := range d.queues {
+			if k.connector == s.Connector {
+				s.Queues++
+				s.Queued += len(q.events)
+			}
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Connector < result[j].Connector })
+	return result
+}
+
+// DispatchStats returns the counters of the event delivery for all connectors, which have delivered events.
+func (b *Bricker) DispatchStats() []DispatchStats {
+	return b.dispatcher.snapshot()
+}
@@ -0,0 +1,329 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"crypto/md5"
+	"fmt"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/subscription"
+	"github.com/dirkjabl/bricker/util/hash"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testValue is the payload of the test callbacks.
+type testValue struct {
+	Value uint32
+}
+
+// orderSubscriber is a callback subscriber, it records the values of the callbacks.
+type orderSubscriber struct {
+	id     string
+	sub    *subscription.Subscription
+	lock   sync.Mutex
+	values []uint32
+	gate   chan struct{} // if not nil, a notify waits for the gate
+	done   chan struct{}
+	count  int
+}
+
+func newOrderSubscriber(id string, uid uint32, fid uint8, count int) *orderSubscriber {
+	return &orderSubscriber{
+		id:    id,
+		sub:   subscription.New(hash.ChoosenFunctionIDUid, uid, fid, nil, true),
+		done:  make(chan struct{}),
+		count: count}
+}
+
+func (s *orderSubscriber) Id() string {
+	return s.id
+}
+
+func (s *orderSubscriber) Subscription() *subscription.Subscription {
+	return s.sub
+}
+
+func (s *orderSubscriber) Notify(e *event.Event) {
+	if s.gate != nil {
+		<-s.gate
+	}
+	runtime.Gosched()
+	v := new(testValue)
+	if e.Packet.Payload.Decode(v) != nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values = append(s.values, v.Value)
+	if len(s.values) == s.count {
+		close(s.done)
+	}
+}
+
+// Internal function: testCallback creates a callback event with the value as payload.
+func testCallback(n string, uid uint32, fid uint8, value uint32) *event.Event {
+	ev := event.NewPacket(packet.NewSimpleHeaderPayload(uid, fid, false, &testValue{Value: value}))
+	ev.ConnectorName = n
+	return ev
+}
+
+func TestOrderedDelivery(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer b.Done()
+	const count = 500
+	s := newOrderSubscriber("order", 7, 9, count)
+	if err := b.Subscribe(s, "virtual"); err != nil {
+		t.Fatalf("Error TestOrderedDelivery: subscribe failed (%s).", err.Error())
+	}
+	value := uint32(0)
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 7, 200), func(e *event.Event) *event.Event {
+		value++
+		return testCallback("", 7, 9, value)
+	})
+	for i := 0; i < count; i++ {
+		v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(7, 200, false)))
+	}
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Error TestOrderedDelivery: not all callbacks delivered.")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, value := range s.values {
+		if value != uint32(i+1) {
+			t.Fatalf("Error TestOrderedDelivery: callback %d delivered at position %d.", value, i+1)
+		}
+	}
+	st := b.DispatchStats()
+	if len(st) != 1 || st[0].Connector != "virtual" || st[0].Delivered < count || st[0].Dropped != 0 {
+		t.Fatalf("Error TestOrderedDelivery: wrong stats (%v).", st)
+	}
+}
+
+func TestParallelDevices(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer b.Done()
+	slow := newOrderSubscriber("slow", 1, 9, 1)
+	slow.gate = make(chan struct{})
+	fast := newOrderSubscriber("fast", 2, 9, 1)
+	b.Subscribe(slow, "virtual")
+	b.Subscribe(fast, "virtual")
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 1, 200), func(e *event.Event) *event.Event {
+		return testCallback("", 1, 9, 1)
+	})
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 2, 200), func(e *event.Event) *event.Event {
+		return testCallback("", 2, 9, 2)
+	})
+	v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(1, 200, false)))
+	v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(2, 200, false)))
+	select {
+	case <-fast.done:
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestParallelDevices: device is blocked by another device.")
+	}
+	close(slow.gate)
+	select {
+	case <-slow.done:
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestParallelDevices: slow device not delivered.")
+	}
+}
+
+// Internal function: testOverflow enqueues 4 events with a queue depth of 2, while the first event is delivered.
+func testOverflow(t *testing.T, o Overflow) ([]uint32, DispatchStats) {
+	gate := make(chan struct{})
+	started := make(chan struct{}, 4)
+	var lock sync.Mutex
+	values := make([]uint32, 0)
+	d := newDispatcher(func(e *event.Event) {
+		started <- struct{}{}
+		<-gate
+		v := new(testValue)
+		e.Packet.Payload.Decode(v)
+		lock.Lock()
+		values = append(values, v.Value)
+		lock.Unlock()
+	})
+	d.depth = 2
+	d.overflow = o
+	d.enqueue(testCallback("test", 1, 9, 1))
+	<-started // first event is delivered, the queue is empty
+	enqueued := make(chan struct{})
+	go func() {
+		for i := uint32(2); i <= 4; i++ {
+			d.enqueue(testCallback("test", 1, 9, i))
+		}
+		close(enqueued)
+	}()
+	if o == OverflowBlock {
+		for d.snapshot()[0].Blocked == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	} else {
+		<-enqueued
+	}
+	st := d.snapshot()[0]
+	close(gate)
+	<-enqueued
+	for {
+		s := d.snapshot()[0]
+		if s.Queues == 0 {
+			st.Delivered = s.Delivered
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	return values, st
+}
+
+func TestOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		o       Overflow
+		values  []uint32
+		dropped uint64
+	}{
+		{OverflowDropNewest, []uint32{1, 2, 3}, 1},
+		{OverflowDropOldest, []uint32{1, 3, 4}, 1},
+		{OverflowBlock, []uint32{1, 2, 3, 4}, 0},
+	} {
+		values, st := testOverflow(t, tc.o)
+		if st.Dropped != tc.dropped || st.Delivered != uint64(len(tc.values)) {
+			t.Fatalf("Error TestOverflow: wrong stats for %s (%v).", tc.o, st)
+		}
+		if tc.o == OverflowBlock && st.Blocked == 0 {
+			t.Fatalf("Error TestOverflow: reader not blocked for %s.", tc.o)
+		}
+		if len(values) != len(tc.values) {
+			t.Fatalf("Error TestOverflow: wrong values for %s (%v).", tc.o, values)
+		}
+		for i := range values {
+			if values[i] != tc.values[i] {
+				t.Fatalf("Error TestOverflow: wrong values for %s (%v).", tc.o, values)
+			}
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	b := New(QueueDepth(8), OverflowPolicy(OverflowDropOldest), QueueDepth(0))
+	if b.dispatcher.depth != 8 || b.dispatcher.overflow != OverflowDropOldest {
+		t.Fatalf("Error TestOptions: options not set (%d, %s).", b.dispatcher.depth, b.dispatcher.overflow)
+	}
+}
+
+// noopSubscriber is a callback subscriber, which does nothing.
+type noopSubscriber struct {
+	id  string
+	sub *subscription.Subscription
+}
+
+func (s *noopSubscriber) Id() string {
+	return s.id
+}
+
+func (s *noopSubscriber) Subscription() *subscription.Subscription {
+	return s.sub
+}
+
+func (s *noopSubscriber) Notify(e *event.Event) {}
+
+// Internal function: routingSubscriber creates callback subscriber for 64 devices with 16 functions
+// and one for the function id 8 of all devices.
+func routingSubscriber() []*noopSubscriber {
+	subs := make([]*noopSubscriber, 0, 64*16+1)
+	for uid := uint32(1); uid <= 64; uid++ {
+		for fid := uint8(1); fid <= 16; fid++ {
+			subs = append(subs, &noopSubscriber{
+				id:  fmt.Sprintf("noop-%d-%d", uid, fid),
+				sub: subscription.New(hash.ChoosenFunctionIDUid, uid, fid, nil, true)})
+		}
+	}
+	return append(subs, &noopSubscriber{id: "noop-fid", sub: subscription.NewFid(8, nil, true)})
+}
+
+// Internal function: subscribeRouting subscribes the routing subscriber at the bricker.
+func subscribeRouting(tb testing.TB, b *Bricker) {
+	for _, s := range routingSubscriber() {
+		if err := b.Subscribe(s, nil); err != nil {
+			tb.Fatalf("Error %s: subscribe failed (%s).", tb.Name(), err.Error())
+		}
+	}
+}
+
+func TestDispatchAllocs(t *testing.T) {
+	b, v := newTestBricker(t, nil)
+	defer v.Done()
+	defer b.Done()
+	subscribeRouting(t, b)
+	e := testCallback("virtual", 42, 8, 1)
+	allocs := testing.AllocsPerRun(100, func() {
+		b.dispatch(e)
+	})
+	if allocs != 0 {
+		t.Fatalf("Error TestDispatchAllocs: dispatch allocates (%f).", allocs)
+	}
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	br, v := newTestBricker(b, nil)
+	defer v.Done()
+	defer br.Done()
+	subscribeRouting(b, br)
+	events := make([]*event.Event, 0, 64)
+	for uid := uint32(1); uid <= 64; uid++ {
+		events = append(events, testCallback("virtual", uid, uint8(uid%16)+1, uid))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		br.dispatch(events[i%len(events)])
+	}
+}
+
+// legacyNew is the former md5 based hash of the subscription routing, only for the benchmarks.
+func legacyNew(choosen uint8, uid uint32, functionID uint8) [md5.Size]byte {
+	t := ""
+	if (choosen & hash.ChoosenFunctionID) == hash.ChoosenFunctionID {
+		t += fmt.Sprintf("|Function-ID=%d", functionID)
+	}
+	if (choosen & hash.ChoosenUid) == hash.ChoosenUid {
+		t += fmt.Sprintf("|Uid=%d", uid)
+	}
+	t += "|"
+	return md5.Sum([]byte(t))
+}
+
+// BenchmarkLegacyDispatch routes the events of BenchmarkDispatch like the former md5 based routing.
+func BenchmarkLegacyDispatch(b *testing.B) {
+	routing := make(map[[md5.Size]byte]map[string]Subscriber)
+	for _, s := range routingSubscriber() {
+		h := legacyNew(s.sub.Choosen, s.sub.Uid, s.sub.FunctionID)
+		if _, ok := routing[h]; !ok {
+			routing[h] = make(map[string]Subscriber)
+		}
+		routing[h][s.Id()] = s
+	}
+	events := make([]*event.Event, 0, 64)
+	for uid := uint32(1); uid <= 64; uid++ {
+		events = append(events, testCallback("virtual", uid, uint8(uid%16)+1, uid))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e := events[i%len(events)]
+		for _, chooser := range hash.All() {
+			for _, s := range routing[legacyNew(chooser, e.Packet.Head.Uid, e.Packet.Head.FunctionID)] {
+				s.Notify(e)
+			}
+		}
+	}
+}
@@ -12,6 +12,18 @@ const (
 	ErrorConnectorNameExists
 	ErrorConnectorNameNotExists
 	ErrorNoConnectorToRelease
+	ErrorConnectorClosed
+	ErrorNoFreeSequence
+	ErrorUnknownDevice
+	ErrorInterceptorExists
+	ErrorNoInterceptorToRemove
+	ErrorEventDropped
+	ErrorNoResponse
+	ErrorShutdown
+	ErrorGroupExists
+	ErrorNoGroupToRelease
+	ErrorNoGroupMembers
+	ErrorGroupMember
 )
 
 // Error type for bricker.
@@ -35,6 +47,30 @@ func (e Error) Error() string {
 		return "Connector with this name does not exists."
 	case ErrorNoConnectorToRelease:
 		return "No connector with this name could be released."
+	case ErrorConnectorClosed:
+		return "Connector is closed, no response will come."
+	case ErrorNoFreeSequence:
+		return "No free sequence number for the request."
+	case ErrorUnknownDevice:
+		return "Device with this uid is unknown."
+	case ErrorInterceptorExists:
+		return "Interceptor with this id exists already."
+	case ErrorNoInterceptorToRemove:
+		return "No interceptor with this id could be removed."
+	case ErrorEventDropped:
+		return "Event is dropped by an interceptor."
+	case ErrorNoResponse:
+		return "No response for the request, after all attempts."
+	case ErrorShutdown:
+		return "Bricker is shut down."
+	case ErrorGroupExists:
+		return "Group with this name exists already."
+	case ErrorNoGroupToRelease:
+		return "No group with this name could be released."
+	case ErrorNoGroupMembers:
+		return "Group without members."
+	case ErrorGroupMember:
+		return "Connector is member of another group."
 	case ErrorNoSubscriberToRelease:
 		return "No subscriber with this subscription could be released."
 	case ErrorUnknown:
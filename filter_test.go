@@ -0,0 +1,144 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/subscription"
+	"sync"
+	"testing"
+)
+
+// recordSubscriber records the uids of the events.
+type recordSubscriber struct {
+	id   string
+	sub  *subscription.Subscription
+	lock sync.Mutex
+	uids []uint32
+}
+
+func (s *recordSubscriber) Id() string {
+	return s.id
+}
+
+func (s *recordSubscriber) Subscription() *subscription.Subscription {
+	return s.sub
+}
+
+func (s *recordSubscriber) Notify(e *event.Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.uids = append(s.uids, e.Packet.Head.Uid)
+}
+
+// Internal method: received checks the recorded uids.
+func (s *recordSubscriber) received(uids ...uint32) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.uids) != len(uids) {
+		return false
+	}
+	for i := range uids {
+		if s.uids[i] != uids[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestClassSubscription(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "second", nil)
+	defer b.Done()
+	s := &recordSubscriber{id: "temperature", sub: subscription.NewClass(216, 8)}
+	if err := b.Subscribe(s, nil); err != nil {
+		t.Fatalf("Error TestClassSubscription: subscribe failed (%s).", err.Error())
+	}
+	b.dispatch(enumerateEvent("virtual", 200, 100, 'a', 216, 0))
+	b.dispatch(enumerateEvent("virtual", 201, 100, 'b', 21, 0))
+	b.dispatch(testCallback("virtual", 200, 8, 1))
+	b.dispatch(testCallback("virtual", 201, 8, 1)) // ambient light bricklet
+	b.dispatch(testCallback("virtual", 202, 8, 1)) // unknown device
+	b.dispatch(testCallback("second", 300, 8, 1))
+	b.dispatch(enumerateEvent("second", 300, 0, 'c', 216, 1)) // connected later
+	b.dispatch(testCallback("second", 300, 8, 2))
+	b.dispatch(testCallback("virtual", 200, 9, 3)) // other callback
+	if !s.received(200, 300) {
+		t.Fatalf("Error TestClassSubscription: wrong events (%v).", s.uids)
+	}
+	b.dispatch(enumerateEvent("second", 300, 0, 'c', 216, 2)) // disconnected
+	b.dispatch(testCallback("second", 300, 8, 4))
+	if !s.received(200, 300) {
+		t.Fatalf("Error TestClassSubscription: event of a disconnected device (%v).", s.uids)
+	}
+}
+
+func TestEnumerateClassSubscription(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "second", nil)
+	defer b.Done()
+	s := &recordSubscriber{id: "enumerate", sub: subscription.NewClass(216, callback_enumerate)}
+	b.Subscribe(s, nil)
+	b.dispatch(enumerateEvent("virtual", 200, 100, 'a', 216, 1))
+	b.dispatch(enumerateEvent("virtual", 201, 100, 'b', 21, 1))
+	b.dispatch(enumerateEvent("virtual", 200, 100, 'a', 216, 2))
+	if !s.received(200, 200) {
+		t.Fatalf("Error TestEnumerateClassSubscription: wrong events (%v).", s.uids)
+	}
+}
+
+func TestFilterSubscription(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "second", nil)
+	defer b.Done()
+	sub := subscription.NewPredicate(func(e *event.Event) bool {
+		_, err := b.Device(e.Packet.Head.Uid) // the bricker could be used inside
+		return err == nil && e.Packet.Head.FunctionID > 5
+	})
+	sub.Filter.Connector = "second"
+	s := &recordSubscriber{id: "predicate", sub: sub}
+	b.dispatch(enumerateEvent("virtual", 100, 0, '0', 13, 0))
+	b.dispatch(enumerateEvent("second", 300, 0, '0', 13, 0))
+	b.Subscribe(s, nil)
+	b.dispatch(testCallback("virtual", 100, 8, 1)) // other connector
+	b.dispatch(testCallback("second", 300, 8, 1))  // match
+	b.dispatch(testCallback("second", 300, 4, 1))  // predicate is false
+	b.dispatch(testCallback("second", 301, 8, 1))  // unknown device
+	if !s.received(300) {
+		t.Fatalf("Error TestFilterSubscription: wrong events (%v).", s.uids)
+	}
+}
+
+func TestFilterFallback(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "second", nil)
+	defer b.Done()
+	fallback := &recordSubscriber{id: "fallback", sub: subscription.NewFid(0, nil, true)}
+	b.SubscribeDefaultFallback(fallback)
+	b.Subscribe(&recordSubscriber{id: "class", sub: subscription.NewClass(216, 8)}, nil)
+	b.dispatch(testCallback("virtual", 200, 8, 1))
+	if !fallback.received(200) {
+		t.Fatalf("Error TestFilterFallback: event not delivered to the fallback subscriber (%v).", fallback.uids)
+	}
+}
+
+func TestFilterChannel(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "second", nil)
+	defer b.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := b.Channel(ctx, "class", subscription.NewClass(216, 8), "virtual", 1)
+	if err != nil {
+		t.Fatalf("Error TestFilterChannel: channel failed (%s).", err.Error())
+	}
+	b.Release("virtual") // a class subscription follows all connectors
+	b.dispatch(enumerateEvent("second", 300, 0, 'c', 216, 1))
+	b.dispatch(testCallback("second", 300, 8, 1))
+	if e, ok := <-c; !ok || e.Packet.Head.Uid != 300 {
+		t.Fatalf("Error TestFilterChannel: channel is closed with a connector.")
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+FlowControl limits the outgoing packets per connector.
+The brick daemon and the Master Bricks have only small buffers, a burst of requests
+(e.g. the configuration of all pins and callback periods at startup) could overflow them
+and the packets are lost without an error.
+
+The window limits the requests, which wait for a response (requests with the response expected flag).
+The rate limits all packets (token bucket with the burst as size).
+Packets over the limits wait in a queue of the connector and are sent in their order.
+A request, which is never answered (e.g. a lost packet), leaves the window after the timeout.
+A flow control without a window and without a rate is disabled (default).
+*/
+type FlowControl struct {
+	Window  int           // maximal number of requests, which wait for a response (0 for no limit)
+	Rate    float64       // maximal number of packets per second (0 for no limit)
+	Burst   int           // packets, which could be sent at once (less than 1 means 1)
+	Timeout time.Duration // time, after which a request without a response leaves the window (0 for never)
+}
+
+// DefaultFlowControl allows 8 outstanding requests and 100 packets per second per connector.
+var DefaultFlowControl = FlowControl{
+	Window:  8,
+	Rate:    100,
+	Burst:   10,
+	Timeout: time.Second}
+
+// Enabled checks, if the flow control is used.
+func (fc FlowControl) Enabled() bool {
+	return fc.Window > 0 || fc.Rate > 0
+}
+
+// Flow sets the flow control for the outgoing packets of every connector (default no flow control).
+func Flow(fc FlowControl) Option {
+	return func(b *Bricker) {
+		b.throttle.fc = fc
+	}
+}
+
+// FlowStats are the counters of the flow control of a connector.
+type FlowStats struct {
+	Connector string        // name of the connector
+	Queued    int           // packets, which wait in the queue
+	MaxQueued int           // maximal length of the queue
+	InFlight  int           // requests in the window
+	Sent      uint64        // packets sent through the flow control
+	Delayed   uint64        // packets, which waited in the queue
+	Expired   uint64        // requests, which left the window after the timeout
+	Wait      time.Duration // sum of the waiting times of all delayed packets
+	MaxWait   time.Duration // longest waiting time of a packet
+}
+
+// Internal type: waiting is a packet in the queue of the flow control.
+type waiting struct {
+	conn  connector.Connector
+	e     *event.Event
+	since time.Time
+}
+
+// Internal type: gate is the flow control state of a connector.
+// A gate has at most one worker, so the waiting packets are sent in order.
+type gate struct {
+	waiting  []waiting
+	inflight map[request]time.Time // requests in the window with their send time
+	tokens   float64
+	last     time.Time     // time of the last refill of the tokens
+	running  bool          // a worker sends the waiting packets
+	closed   bool          // connector is released
+	wake     chan struct{} // signals a free place in the window
+	idle     chan struct{} // closed, when the worker is done (nil without a waiter)
+	stats    FlowStats
+}
+
+// Internal type: throttle sends the outgoing packets within the limits of the flow control.
+type throttle struct {
+	fc       FlowControl
+	transmit func(c connector.Connector, e *event.Event)
+	lock     sync.Mutex // guards all following fields
+	gates    map[string]*gate
+}
+
+// Internal function: newThrottle creates a throttle, which sends the packets with transmit.
+func newThrottle(transmit func(c connector.Connector, e *event.Event)) *throttle {
+	return &throttle{
+		transmit: transmit,
+		gates:    make(map[string]*gate)}
+}
+
+// Internal method: enabled checks, if the packets pass the flow control.
+func (t *throttle) enabled() bool {
+	return t.fc.Enabled()
+}
+
+// Internal method: gateOf gives the gate of the connector.
+// The caller has to hold the lock.
+func (t *throttle) gateOf(n string) *gate {
+	g, ok := t.gates[n]
+	if !ok {
+		g = &gate{
+			inflight: make(map[request]time.Time),
+			tokens:   float64(t.burst()),
+			last:     time.Now(),
+			wake:     make(chan struct{}, 1),
+			stats:    FlowStats{Connector: n}}
+		t.gates[n] = g
+	}
+	return g
+}
+
+// Internal method: burst gives the size of the token bucket.
+func (t *throttle) burst() int {
+	if t.fc.Burst < 1 {
+		return 1
+	}
+	return t.fc.Burst
+}
+
+// Internal method: enqueue sends the packet, if the limits allow it, otherwise the packet waits in the queue.
+func (t *throttle) enqueue(c connector.Connector, e *event.Event) {
+	t.lock.Lock()
+	g := t.gateOf(e.ConnectorName)
+	now := time.Now()
+	if len(g.waiting) == 0 {
+		if _, ok := t.delay(g, now, e); ok {
+			t.take(g, now, e)
+			t.lock.Unlock()
+			t.transmit(c, e)
+			return
+		}
+	}
+	g.waiting = append(g.waiting, waiting{conn: c, e: e, since: now})
+	if len(g.waiting) > g.stats.MaxQueued {
+		g.stats.MaxQueued = len(g.waiting)
+	}
+	if !g.running {
+		g.running = true
+		go t.work(g)
+	}
+	t.lock.Unlock()
+}
+
+// Internal method: work sends the waiting packets of a gate in order, until the queue is empty.
+func (t *throttle) work(g *gate) {
+	t.lock.Lock()
+	for !g.closed && len(g.waiting) > 0 {
+		w := g.waiting[0]
+		now := time.Now()
+		if d, ok := t.delay(g, now, w.e); !ok {
+			t.lock.Unlock()
+			g.sleep(d)
+			t.lock.Lock()
+			continue
+		}
+		g.waiting[0] = waiting{}
+		g.waiting = g.waiting[1:]
+		t.take(g, now, w.e)
+		wait := now.Sub(w.since)
+		g.stats.Delayed++
+		g.stats.Wait += wait
+		if wait > g.stats.MaxWait {
+			g.stats.MaxWait = wait
+		}
+		t.lock.Unlock()
+		t.transmit(w.conn, w.e)
+		t.lock.Lock()
+	}
+	g.running = false
+	if g.idle != nil {
+		close(g.idle)
+		g.idle = nil
+	}
+	t.lock.Unlock()
+}
+
+// Internal method: sleep waits the duration or until a place in the window is free.
+// Without a duration, it waits only for a free place.
+func (g *gate) sleep(d time.Duration) {
+	if d <= 0 {
+		<-g.wake
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-g.wake:
+	case <-timer.C:
+	}
+}
+
+// Internal method: signal wakes up the waiting worker of the gate.
+func (g *gate) signal() {
+	select {
+	case g.wake <- struct{}{}:
+	default: // a wake up is already pending
+	}
+}
+
+// Internal method: delay checks, if the packet could be sent now.
+// If not, the duration is the time until the limits could allow the packet (0 for an unknown time).
+// The caller has to hold the lock.
+func (t *throttle) delay(g *gate, now time.Time, e *event.Event) (time.Duration, bool) {
+	if _, ok := t.windowed(e); ok && t.fc.Window > 0 {
+		t.expire(g, now)
+		if len(g.inflight) >= t.fc.Window {
+			if t.fc.Timeout <= 0 {
+				return 0, false // waits for a response
+			}
+			oldest := now
+			for _, sent := range g.inflight {
+				if sent.Before(oldest) {
+					oldest = sent
+				}
+			}
+			return atLeast(oldest.Add(t.fc.Timeout).Sub(now)), false
+		}
+	}
+	if t.fc.Rate > 0 {
+		t.refill(g, now)
+		if g.tokens < 1 {
+			return atLeast(time.Duration((1 - g.tokens) / t.fc.Rate * float64(time.Second))), false
+		}
+	}
+	return 0, true
+}
+
+// Internal function: atLeast gives a positive waiting time.
+func atLeast(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return time.Millisecond
+	}
+	return d
+}
+
+// Internal method: take counts the sent packet against the limits.
+// The caller has to hold the lock.
+func (t *throttle) take(g *gate, now time.Time, e *event.Event) {
+	if t.fc.Rate > 0 {
+		g.tokens--
+	}
+	if r, ok := t.windowed(e); ok && t.fc.Window > 0 {
+		g.inflight[r] = now
+	}
+	g.stats.Sent++
+}
+
+// Internal method: windowed checks, if the packet is a request, which takes a place in the window.
+func (t *throttle) windowed(e *event.Event) (request, bool) {
+	r, ok := requestOf(e)
+	if !ok || !e.Packet.Head.OptionResponseExpected() {
+		return request{}, false
+	}
+	return r, true
+}
+
+// Internal method: refill fills the token bucket with the tokens of the time since the last refill.
+// The caller has to hold the lock.
+func (t *throttle) refill(g *gate, now time.Time) {
+	g.tokens += now.Sub(g.last).Seconds() * t.fc.Rate
+	if max := float64(t.burst()); g.tokens > max {
+		g.tokens = max
+	}
+	g.last = now
+}
+
+// Internal method: expire removes the requests from the window, which wait longer than the timeout.
+// The caller has to hold the lock.
+func (t *throttle) expire(g *gate, now time.Time) {
+	if t.fc.Timeout <= 0 {
+		return
+	}
+	for r, sent := range g.inflight {
+		if now.Sub(sent) >= t.fc.Timeout {
+			delete(g.inflight, r)
+			g.stats.Expired++
+		}
+	}
+}
+
+// Internal method: done frees the place of the request in the window (answered or abandoned).
+// A request, which still waits in the queue, is removed from it (nobody waits for its response).
+func (t *throttle) done(r request) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	g, ok := t.gates[r.connector]
+	if !ok {
+		return
+	}
+	if _, ok := g.inflight[r]; ok {
+		delete(g.inflight, r)
+		g.signal()
+		return
+	}
+	for i, w := range g.waiting {
+		if wr, ok := t.windowed(w.e); ok && wr == r {
+			g.waiting = append(g.waiting[:i:i], g.waiting[i+1:]...)
+			g.signal()
+			return
+		}
+	}
+}
+
+// Internal method: drain waits, until all waiting packets are sent or the context is done.
+// The result is the number of the still waiting packets.
+func (t *throttle) drain(ctx context.Context) int {
+	for {
+		t.lock.Lock()
+		var idle chan struct{}
+		for _, g := range t.gates {
+			if g.running {
+				if g.idle == nil {
+					g.idle = make(chan struct{})
+				}
+				idle = g.idle
+				break
+			}
+		}
+		t.lock.Unlock()
+		if idle == nil {
+			return 0
+		}
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			t.lock.Lock()
+			defer t.lock.Unlock()
+			n := 0
+			for _, g := range t.gates {
+				n += len(g.waiting)
+			}
+			return n
+		}
+	}
+}
+
+// Internal method: release drops all waiting packets and the counters of the connector.
+func (t *throttle) release(n string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if g, ok := t.gates[n]; ok {
+		g.closed = true
+		g.waiting = nil
+		g.signal()
+		delete(t.gates, n)
+	}
+}
+
+// Internal method: snapshot returns the counters of all connectors, sorted by the connector name.
+func (t *throttle) snapshot() []FlowStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	result := make([]FlowStats, 0, len(t.gates))
+	now := time.Now()
+	for _, g := range t.gates {
+		t.expire(g, now)
+		s := g.stats
+		s.Queued = len(g.waiting)
+		s.InFlight = len(g.inflight)
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Connector < result[j].Connector })
+	return result
+}
+
+// FlowStats returns the counters of the flow control for all connectors, which have sent packets.
+func (b *Bricker) FlowStats() []FlowStats {
+	return b.throttle.snapshot()
+}
@@ -0,0 +1,146 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Internal function: subscribeRequests subscribes requests for the function ids 1 to 5 of uid 1.
+func subscribeRequests(b *Bricker) []*testSubscriber {
+	subs := make([]*testSubscriber, 0, 5)
+	for fid := uint8(1); fid <= 5; fid++ {
+		s := newTestSubscriber("request", 1, fid, true, false)
+		b.Subscribe(s, "virtual")
+		subs = append(subs, s)
+	}
+	return subs
+}
+
+// Internal function: flowStats gives the flow control counters of the virtual connector.
+func flowStats(b *Bricker) FlowStats {
+	for _, st := range b.FlowStats() {
+		if st.Connector == "virtual" {
+			return st
+		}
+	}
+	return FlowStats{}
+}
+
+func TestFlowWindow(t *testing.T) {
+	sent := new(int32)
+	release := make(chan struct{})
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		atomic.AddInt32(sent, 1)
+		<-release
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, e.Packet.Head.FunctionID, false))
+	}), Flow(FlowControl{Window: 2}))
+	defer b.Done()
+	subs := subscribeRequests(b)
+	deadline := time.Now().Add(time.Second)
+	for flowStats(b).Queued != 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	st := flowStats(b)
+	if n := atomic.LoadInt32(sent); n != 2 || st.Queued != 3 || st.InFlight != 2 {
+		t.Fatalf("Error TestFlowWindow: %d requests sent, stats %+v (expected 2 sent, 3 queued).", n, st)
+	}
+	close(release)
+	for _, s := range subs {
+		select {
+		case <-s.notified:
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestFlowWindow: no response for %s.", s.sub.String())
+		}
+	}
+	if st = flowStats(b); st.Delayed != 3 || st.MaxQueued != 3 || st.Queued != 0 || st.InFlight != 0 {
+		t.Fatalf("Error TestFlowWindow: wrong stats %+v.", st)
+	}
+}
+
+func TestFlowUnsubscribeQueued(t *testing.T) {
+	sent := new(int32)
+	release := make(chan struct{})
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		atomic.AddInt32(sent, 1)
+		<-release
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, e.Packet.Head.FunctionID, false))
+	}), Flow(FlowControl{Window: 1}))
+	defer b.Done()
+	subs := subscribeRequests(b)
+	deadline := time.Now().Add(time.Second)
+	for flowStats(b).Queued != 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := b.Unsubscribe(subs[2]); err != nil {
+		t.Fatalf("Error TestFlowUnsubscribeQueued: unsubscribe failed (%s).", err.Error())
+	}
+	if st := flowStats(b); st.Queued != 3 {
+		t.Fatalf("Error TestFlowUnsubscribeQueued: request still queued, stats %+v.", st)
+	}
+	close(release)
+	for i, s := range subs {
+		if i == 2 {
+			continue
+		}
+		select {
+		case <-s.notified:
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestFlowUnsubscribeQueued: no response for %s.", s.sub.String())
+		}
+	}
+	if st := flowStats(b); atomic.LoadInt32(sent) != 4 || st.Queued != 0 || st.InFlight != 0 {
+		t.Fatalf("Error TestFlowUnsubscribeQueued: %d requests sent, stats %+v.", atomic.LoadInt32(sent), st)
+	}
+}
+
+func TestFlowTimeout(t *testing.T) {
+	sent := new(int32)
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		atomic.AddInt32(sent, 1)
+		return nil // lost request
+	}), Flow(FlowControl{Window: 1, Timeout: 20 * time.Millisecond}))
+	defer b.Done()
+	subscribeRequests(b)
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(sent) != 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if st := flowStats(b); atomic.LoadInt32(sent) != 5 || st.Expired < 4 || st.MaxWait < 20*time.Millisecond {
+		t.Fatalf("Error TestFlowTimeout: %d requests sent, stats %+v.", atomic.LoadInt32(sent), st)
+	}
+}
+
+func TestFlowRate(t *testing.T) {
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, e.Packet.Head.FunctionID, false))
+	}), Flow(FlowControl{Rate: 100, Burst: 1}))
+	defer b.Done()
+	start := time.Now()
+	for _, s := range subscribeRequests(b) {
+		select {
+		case <-s.notified:
+		case <-time.After(time.Second):
+			t.Fatalf("Error TestFlowRate: no response for %s.", s.sub.String())
+		}
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Fatalf("Error TestFlowRate: 5 packets in %s, expected at least 40ms.", d)
+	}
+	if st := flowStats(b); st.Delayed < 4 || st.Wait <= 0 {
+		t.Fatalf("Error TestFlowRate: wrong stats %+v.", st)
+	}
+}
+
+func TestFlowControlEnabled(t *testing.T) {
+	if (FlowControl{}).Enabled() || !(FlowControl{Rate: 1}).Enabled() || !DefaultFlowControl.Enabled() {
+		t.Fatalf("Error TestFlowControlEnabled: wrong enabled flow controls.")
+	}
+}
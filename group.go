@@ -0,0 +1,238 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"sync/atomic"
+)
+
+// GroupPolicy chooses the member of a connector group, which sends a request.
+type GroupPolicy uint8
+
+// All group policies.
+const (
+	Failover GroupPolicy = iota // the first available member in the order of the group
+	Spread                      // the available members in turns (round robin)
+)
+
+// String fullfill the stringer interface.
+func (gp GroupPolicy) String() string {
+	switch gp {
+	case Failover:
+		return "Failover"
+	case Spread:
+		return "Spread"
+	default:
+		return "Unknown"
+	}
+}
+
+// Internal type: group is a named list of redundant connectors (members), which reach the same devices.
+type group struct {
+	name    string
+	policy  GroupPolicy
+	members []string // names of the members in the order of preference
+	turn    uint32   // next turn of the spread (atomic)
+}
+
+/*
+Group defines a group of redundant connectors (e.g. a stack, which is reachable over the brick daemon
+on USB and over a Ethernet Master Extension). The group name could be used like a connector name
+as destination of a subscriber.
+
+A request for the group or for a device, which is reachable over a member, is sent over an
+available member (attached and not disconnected). With the policy Failover this is the first available
+member in the order of the group, with Spread the available members send the requests in turns.
+Members, which know the device (see the registry), are preferred.
+The members do not have to be attached, when the group is defined, and a connector is member of
+at most one group.
+
+When a member is disconnected or released, its session (the configuration requests, see Reconnect)
+is sent over the next available member, so the devices keep their configuration.
+Requests, which wait for a response of the lost member, are notified with an error (ErrorConnectorClosed)
+as before. Callbacks come over every member, a callback is only delivered from the first available
+member, which knows the device.
+*/
+func (b *Bricker) Group(name string, policy GroupPolicy, members ...string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.connection[name]; ok {
+		return NewError(ErrorConnectorNameExists)
+	}
+	if _, ok := b.groups[name]; ok {
+		return NewError(ErrorGroupExists)
+	}
+	if len(members) == 0 {
+		return NewError(ErrorNoGroupMembers)
+	}
+	for _, m := range members {
+		if _, ok := b.grouped[m]; ok || m == name {
+			return NewError(ErrorGroupMember)
+		}
+	}
+	g := &group{name: name, policy: policy, members: append([]string(nil), members...)}
+	b.groups[name] = g
+	for _, m := range g.members {
+		b.grouped[m] = g
+	}
+	return nil
+}
+
+// Ungroup removes the connector group, the members stay attached.
+func (b *Bricker) Ungroup(name string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	g, ok := b.groups[name]
+	if !ok {
+		return NewError(ErrorNoGroupToRelease)
+	}
+	for _, m := range g.members {
+		delete(b.grouped, m)
+	}
+	delete(b.groups, name)
+	return nil
+}
+
+// Internal method: available checks, if the named connector is attached, not disconnected and
+// knows the device with the uid (0 for any device).
+// The caller has to hold the read lock.
+func (b *Bricker) available(n string, uid uint32) bool {
+	if _, ok := b.connection[n]; !ok || b.states[n].State == connector.StateDisconnected {
+		return false
+	}
+	if uid == 0 {
+		return true
+	}
+	_, ok := b.registry[n][uid]
+	return ok
+}
+
+// Internal method: elect chooses the member of the group for the device with the uid (0 for no device).
+// With turn, the policy of the group is used, otherwise the first available member is the result.
+// Without a available member, the result is the first member (a request fails there).
+// The caller has to hold the read lock.
+func (b *Bricker) elect(g *group, uid uint32, turn bool) string {
+	for _, id := range [2]uint32{uid, 0} {
+		count := 0
+		for _, m := range g.members {
+			if b.available(m, id) {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		i := 0
+		if turn && g.policy == Spread {
+			i = int((atomic.AddUint32(&g.turn, 1) - 1) % uint32(count))
+		}
+		for _, m := range g.members {
+			if b.available(m, id) {
+				if i == 0 {
+					return m
+				}
+				i--
+			}
+		}
+	}
+	return g.members[0]
+}
+
+// Internal method: redundant checks, if the event is a callback, which comes over a member of a group,
+// but is delivered from another member (the same callback comes over every member).
+func (b *Bricker) redundant(e *event.Event) bool {
+	if e.Packet == nil || e.Packet.Head == nil || e.Packet.Head.Sequence() != 0 ||
+		e.Packet.Head.FunctionID == callback_enumerate {
+		return false
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	g, ok := b.grouped[e.ConnectorName]
+	return ok && b.elect(g, e.Packet.Head.Uid, false) != e.ConnectorName
+}
+
+// Internal method: takeover moves the session of the named connector to the next available member
+// of its group. The result are the requests of the session, which has to be sent over the member.
+// The member remembers the requests of a disconnected connector only until it reconnects (see giveback),
+// the requests of a released connector stay with the member.
+// The caller has to hold the write lock.
+func (b *Bricker) takeover(n string, released bool) []*event.Event {
+	g, ok := b.grouped[n]
+	if !ok {
+		return nil
+	}
+	b.giveback(n) // a release after a disconnect takes the session over again
+	for _, m := range g.members {
+		if m == n || !b.available(m, 0) {
+			continue
+		}
+		events := make([]*event.Event, 0, len(b.sessions[n]))
+		for _, se := range b.sessions[n] {
+			if se.from == "" && !released {
+				se.from = n
+			}
+			b.record(m, se)
+			p := se.packet.Copy()
+			p.Head.SequenceAndOptions = 0 // new sequence from the connector, no response expected
+			ev := event.NewPacket(p)
+			ev.ConnectorName = m
+			events = append(events, ev)
+		}
+		return events
+	}
+	return nil
+}
+
+// Internal method: giveback removes the requests, which are taken over from the named connector,
+// from the sessions of the other connectors.
+// The caller has to hold the write lock.
+func (b *Bricker) giveback(n string) {
+	for m, session := range b.sessions {
+		kept := session[:0]
+		for _, se := range session {
+			if se.from != n {
+				kept = append(kept, se)
+			}
+		}
+		b.sessions[m] = kept
+	}
+}
+
+// Internal method: orphaned returns the name of the group of the named connector,
+// if no member of the group is available, otherwise the result is empty.
+// The caller has to hold the read lock.
+func (b *Bricker) orphaned(n string) string {
+	g, ok := b.grouped[n]
+	if !ok {
+		return ""
+	}
+	for _, m := range g.members {
+		if m != n && b.available(m, 0) {
+			return ""
+		}
+	}
+	return g.name
+}
+
+// Internal method: electFirst chooses the first connector after the named one is released:
+// the first attached and not disconnected connector in the order of attaching, otherwise the first attached one.
+// The caller has to hold the write lock.
+func (b *Bricker) electFirst(n string) string {
+	first := ""
+	for _, m := range b.attached {
+		if m == n {
+			continue
+		}
+		if b.available(m, 0) {
+			return m
+		}
+		if first == "" {
+			first = m
+		}
+	}
+	return first
+}
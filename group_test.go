@@ -0,0 +1,226 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/subscription"
+	"github.com/dirkjabl/bricker/util/hash"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sentGenerator puts the function ids of the requests (without the enumerate) in the channel.
+func sentGenerator(c chan uint8) virtual.GeneratorFunc {
+	return func(e *event.Event) *event.Event {
+		if e.Packet.Head.FunctionID != function_enumerate {
+			c <- e.Packet.Head.FunctionID
+		}
+		return nil
+	}
+}
+
+// expectSent waits for the function id on the channel.
+func expectSent(t *testing.T, c chan uint8, fid uint8) {
+	select {
+	case f := <-c:
+		if f != fid {
+			t.Fatalf("Error %s: function id %d sent, expected %d.", t.Name(), f, fid)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error %s: function id %d not sent.", t.Name(), fid)
+	}
+}
+
+func TestGroupFailover(t *testing.T) {
+	sent := map[string]chan uint8{"virtual": make(chan uint8, 10), "eth": make(chan uint8, 10)}
+	b, _ := newTestBricker(t, sentGenerator(sent["virtual"]))
+	attachTestConnector(t, b, "eth", sentGenerator(sent["eth"]))
+	defer b.Done()
+	if err := b.Group("stack", Failover, "virtual", "eth"); err != nil {
+		t.Fatalf("Error TestGroupFailover: could not create group (%s).", err.Error())
+	}
+	b.dispatch(enumerateEvent("eth", 200, 100, 'a', 216, 0))
+	b.dispatch(enumerateEvent("virtual", 200, 100, 'a', 216, 0))
+	if n := b.computeConnectorsName("stack"); n != "virtual" {
+		t.Fatalf("Error TestGroupFailover: group routed to %s, expected virtual.", n)
+	}
+	if n := b.ConnectorFor(200); n != "virtual" {
+		t.Fatalf("Error TestGroupFailover: device routed to %s, expected virtual.", n)
+	}
+	s := newTestSubscriber("restore", 200, 10, true, false)
+	s.sub.Restore = true
+	if err := b.Subscribe(s, "stack"); err != nil {
+		t.Fatalf("Error TestGroupFailover: subscribe failed (%s).", err.Error())
+	}
+	expectSent(t, sent["virtual"], 10)
+	b.stateChanged("virtual", connector.StateDisconnected, nil)
+	expectSent(t, sent["eth"], 10) // session is sent over the next member
+	if n := b.computeConnectorsName("stack"); n != "eth" {
+		t.Fatalf("Error TestGroupFailover: group routed to %s, expected eth.", n)
+	}
+	if n := b.ConnectorFor(200); n != "eth" {
+		t.Fatalf("Error TestGroupFailover: device routed to %s, expected eth.", n)
+	}
+	b.stateChanged("virtual", connector.StateReconnected, nil)
+	if n := b.computeConnectorsName("stack"); n != "virtual" {
+		t.Fatalf("Error TestGroupFailover: group routed to %s after reconnect, expected virtual.", n)
+	}
+}
+
+func TestGroupGiveback(t *testing.T) {
+	sent := map[string]chan uint8{"virtual": make(chan uint8, 10), "eth": make(chan uint8, 10)}
+	b, _ := newTestBricker(t, sentGenerator(sent["virtual"]))
+	attachTestConnector(t, b, "eth", sentGenerator(sent["eth"]))
+	defer b.Done()
+	b.Group("stack", Failover, "virtual", "eth")
+	s := newTestSubscriber("restore", 200, 10, true, false)
+	s.sub.Restore = true
+	b.Subscribe(s, "stack")
+	expectSent(t, sent["virtual"], 10)
+	b.stateChanged("virtual", connector.StateDisconnected, nil)
+	expectSent(t, sent["eth"], 10)
+	b.stateChanged("virtual", connector.StateReconnected, nil)
+	expectSent(t, sent["virtual"], 10) // the member restores its own session
+	b.stateChanged("eth", connector.StateReconnected, nil)
+	if len(sent["eth"]) != 0 {
+		t.Fatalf("Error TestGroupGiveback: taken over session is restored after the member is back.")
+	}
+	b.Release("eth")
+	if len(sent["virtual"]) != 0 {
+		t.Fatalf("Error TestGroupGiveback: taken over session is taken over again.")
+	}
+}
+
+func TestGroupReleaseByUid(t *testing.T) {
+	sent := map[string]chan uint8{"virtual": make(chan uint8, 10), "eth": make(chan uint8, 10)}
+	b, _ := newTestBricker(t, sentGenerator(sent["virtual"]))
+	attachTestConnector(t, b, "eth", sentGenerator(sent["eth"]))
+	defer b.Done()
+	b.Group("stack", Failover, "virtual", "eth")
+	b.dispatch(enumerateEvent("eth", 200, 100, 'a', 216, 0))
+	b.dispatch(enumerateEvent("virtual", 200, 100, 'a', 216, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := subscription.New(hash.ChoosenFunctionIDUid, 200, 9, packet.NewSimpleHeaderOnly(200, 9, false), true)
+	c, err := b.Channel(ctx, "uid", s, uint32(200), 1)
+	if err != nil {
+		t.Fatalf("Error TestGroupReleaseByUid: channel failed (%s).", err.Error())
+	}
+	expectSent(t, sent["virtual"], 9)
+	b.Release("virtual")
+	expectSent(t, sent["eth"], 9) // session is sent over the next member
+	b.dispatcher.enqueue(testCallback("eth", 200, 9, 1))
+	select {
+	case e, ok := <-c:
+		if !ok {
+			t.Fatalf("Error TestGroupReleaseByUid: channel is closed with the released member.")
+		}
+		if e.ConnectorName != "eth" {
+			t.Fatalf("Error TestGroupReleaseByUid: callback from %s, expected eth.", e.ConnectorName)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestGroupReleaseByUid: no callback after the release.")
+	}
+}
+
+func TestGroupSpread(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "eth", nil)
+	defer b.Done()
+	b.Group("stack", Spread, "virtual", "eth")
+	for i, expected := range []string{"virtual", "eth", "virtual", "eth"} {
+		if n := b.computeConnectorsName("stack"); n != expected {
+			t.Fatalf("Error TestGroupSpread: request %d routed to %s, expected %s.", i, n, expected)
+		}
+	}
+	b.stateChanged("eth", connector.StateDisconnected, nil)
+	for i := 0; i < 2; i++ {
+		if n := b.computeConnectorsName("stack"); n != "virtual" {
+			t.Fatalf("Error TestGroupSpread: request routed to %s, expected virtual.", n)
+		}
+	}
+}
+
+func TestGroupCallbacks(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "eth", nil)
+	defer b.Done()
+	b.Group("stack", Failover, "virtual", "eth")
+	s := newTestSubscriber("callback", 200, 8, false, true)
+	b.Subscribe(s, "stack")
+	b.dispatch(testCallback("eth", 200, 8, 1)) // comes over virtual too
+	if c := atomic.LoadInt32(&s.count); c != 0 {
+		t.Fatalf("Error TestGroupCallbacks: callback of eth is delivered.")
+	}
+	b.dispatch(testCallback("virtual", 200, 8, 1))
+	if c := atomic.LoadInt32(&s.count); c != 1 {
+		t.Fatalf("Error TestGroupCallbacks: callback of virtual is delivered %d times, expected 1.", c)
+	}
+	b.stateChanged("virtual", connector.StateDisconnected, nil)
+	b.dispatch(testCallback("eth", 200, 8, 1))
+	if c := atomic.LoadInt32(&s.count); c != 2 {
+		t.Fatalf("Error TestGroupCallbacks: callback of eth is not delivered after the failover.")
+	}
+}
+
+func TestGroupErrors(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "eth", nil)
+	defer b.Done()
+	if err := b.Group("virtual", Failover, "eth"); err == nil || err.(Error).Code != ErrorConnectorNameExists {
+		t.Fatalf("Error TestGroupErrors: group with a connector name (%v).", err)
+	}
+	if err := b.Group("stack", Failover); err == nil || err.(Error).Code != ErrorNoGroupMembers {
+		t.Fatalf("Error TestGroupErrors: group without members (%v).", err)
+	}
+	if err := b.Group("stack", Failover, "virtual", "eth"); err != nil {
+		t.Fatalf("Error TestGroupErrors: could not create group (%s).", err.Error())
+	}
+	if err := b.Group("stack", Failover, "other"); err == nil || err.(Error).Code != ErrorGroupExists {
+		t.Fatalf("Error TestGroupErrors: group created twice (%v).", err)
+	}
+	if err := b.Group("second", Failover, "eth"); err == nil || err.(Error).Code != ErrorGroupMember {
+		t.Fatalf("Error TestGroupErrors: member of two groups (%v).", err)
+	}
+	if err := b.Attach(virtual.New(), "stack"); err == nil || err.(Error).Code != ErrorConnectorNameExists {
+		t.Fatalf("Error TestGroupErrors: connector with a group name (%v).", err)
+	}
+	if err := b.Ungroup("stack"); err != nil {
+		t.Fatalf("Error TestGroupErrors: could not remove group (%s).", err.Error())
+	}
+	if err := b.Ungroup("stack"); err == nil || err.(Error).Code != ErrorNoGroupToRelease {
+		t.Fatalf("Error TestGroupErrors: group removed twice (%v).", err)
+	}
+}
+
+func TestReleaseFirst(t *testing.T) {
+	b, _ := newTestBricker(t, nil)
+	attachTestConnector(t, b, "eth", nil)
+	defer b.Done()
+	v := virtual.New()
+	b.Attach(v, "wlan")
+	if n := b.computeConnectorsName(nil); n != "virtual" {
+		t.Fatalf("Error TestReleaseFirst: first connector is %s, expected virtual.", n)
+	}
+	b.stateChanged("eth", connector.StateDisconnected, nil)
+	b.Release("virtual")
+	if n := b.computeConnectorsName(nil); n != "wlan" {
+		t.Fatalf("Error TestReleaseFirst: first connector is %s, expected wlan.", n)
+	}
+	b.Release("wlan")
+	if n := b.computeConnectorsName(nil); n != "eth" {
+		t.Fatalf("Error TestReleaseFirst: first connector is %s, expected eth.", n)
+	}
+	b.Release("eth")
+	if n := b.computeConnectorsName(nil); n != "" {
+		t.Fatalf("Error TestReleaseFirst: first connector is %s, expected none.", n)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/event"
+)
+
+// Direction of an event, which is intercepted.
+type Direction uint8
+
+// All directions.
+const (
+	Outbound Direction = iota // event to a connector, before it is sent
+	Inbound                   // event from a connector, before the subscriber are notified
+)
+
+// String fullfill the stringer interface.
+func (d Direction) String() string {
+	switch d {
+	case Outbound:
+		return "Outbound"
+	case Inbound:
+		return "Inbound"
+	default:
+		return "Unknown"
+	}
+}
+
+/*
+Interceptor is a link of the interceptor chain of a bricker.
+It gets every event of the direction and passes it on with next.
+An interceptor could observe or modify the event, delay it (wait before next is called)
+or drop it (next is not called). It could also pass on another event.
+Next has to be called, before the interceptor returns.
+
+Inbound events are intercepted by the go routine of the device queue (see the event delivery),
+so a delay of an inbound event delays the following events of the device.
+An outbound request or an inbound response, which is dropped, is answered with an error (ErrorEventDropped).
+The uid, function id and sequence number of an outbound request should not be changed,
+they identify the response.
+*/
+type Interceptor func(d Direction, e *event.Event, next func(*event.Event))
+
+// Internal type: interceptor is a named link of the chain.
+type interceptor struct {
+	id string
+	fn Interceptor
+}
+
+// Intercept appends the interceptor with the id to the end of the interceptor chain.
+// The first interceptor of the chain gets the events first.
+func (b *Bricker) Intercept(id string, i Interceptor) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, ic := range b.interceptors {
+		if ic.id == id {
+			return NewError(ErrorInterceptorExists)
+		}
+	}
+	chain := make([]interceptor, len(b.interceptors), len(b.interceptors)+1) // copy on write, a running chain is not changed
+	copy(chain, b.interceptors)
+	b.interceptors = append(chain, interceptor{id: id, fn: i})
+	return nil
+}
+
+// RemoveInterceptor removes the interceptor with the id from the interceptor chain.
+func (b *Bricker) RemoveInterceptor(id string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for i, ic := range b.interceptors {
+		if ic.id == id {
+			chain := make([]interceptor, 0, len(b.interceptors)-1)
+			chain = append(chain, b.interceptors[:i]...)
+			b.interceptors = append(chain, b.interceptors[i+1:]...)
+			return nil
+		}
+	}
+	return NewError(ErrorNoInterceptorToRemove)
+}
+
+// Interceptors returns the ids of all interceptors in the order of the chain.
+func (b *Bricker) Interceptors() []string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	ids := make([]string, 0, len(b.interceptors))
+	for _, ic := range b.interceptors {
+		ids = append(ids, ic.id)
+	}
+	return ids
+}
+
+// Internal method: chain returns the actual interceptor chain.
+func (b *Bricker) chain() []interceptor {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.interceptors
+}
+
+// Internal function: intercept passes the event through the interceptor chain and then to last.
+// The result is false, if the event is dropped by an interceptor (last is not called).
+func intercept(chain []interceptor, d Direction, e *event.Event, last func(*event.Event)) bool {
+	passed := false
+	var next func(i int) func(*event.Event)
+	next = func(i int) func(*event.Event) {
+		return func(e *event.Event) {
+			if e == nil {
+				return // nothing to pass on
+			}
+			if i == len(chain) {
+				passed = true
+				last(e)
+				return
+			}
+			chain[i].fn(d, e, next(i+1))
+		}
+	}
+	next(0)(e)
+	return passed
+}
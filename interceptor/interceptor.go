@@ -0,0 +1,94 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Some interceptors for the interceptor chain of a bricker (see bricker.Intercept).
+
+	brick.Intercept("logger", interceptor.Logger(log.Default()))
+	brick.Intercept("limit", interceptor.RateLimit(bricker.Outbound, 50, 10))
+*/
+package interceptor
+
+import (
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/event"
+	"log"
+	"sync"
+	"time"
+)
+
+// Logger logs every event with its direction and connector.
+func Logger(l *log.Logger) bricker.Interceptor {
+	return func(d bricker.Direction, e *event.Event, next func(*event.Event)) {
+		l.Printf("%s %s: %s", d, e.ConnectorName, e)
+		next(e)
+	}
+}
+
+// Drop drops all events of the direction, for which the predicate is true.
+func Drop(dir bricker.Direction, p func(*event.Event) bool) bricker.Interceptor {
+	return func(d bricker.Direction, e *event.Event, next func(*event.Event)) {
+		if d == dir && p(e) {
+			return
+		}
+		next(e)
+	}
+}
+
+// Internal type: bucket holds the tokens of a connector.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Internal type: limiter is a token bucket per connector.
+type limiter struct {
+	rate    float64 // tokens per second
+	burst   float64 // maximal tokens
+	lock    sync.Mutex
+	buckets map[string]*bucket
+}
+
+/*
+RateLimit limits the events of the direction to rate events per second for every connector.
+Up to burst events could pass without a delay, the following events are delayed.
+The events are not dropped, a delay of an outbound event delays the sender
+and a delay of an inbound event delays the following events of the device.
+A rate smaller or equal 0 means no limit, a burst smaller than 1 is set to 1.
+*/
+func RateLimit(dir bricker.Direction, rate float64, burst int) bricker.Interceptor {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &limiter{rate: rate, burst: float64(burst), buckets: make(map[string]*bucket)}
+	return func(d bricker.Direction, e *event.Event, next func(*event.Event)) {
+		if d == dir && rate > 0 {
+			if w := l.reserve(e.ConnectorName, time.Now()); w > 0 {
+				time.Sleep(w)
+			}
+		}
+		next(e)
+	}
+}
+
+// Internal method: reserve takes a token of the connector and gives the time to wait for it.
+func (l *limiter) reserve(n string, now time.Time) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	b, ok := l.buckets[n]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[n] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / l.rate * float64(time.Second))
+}
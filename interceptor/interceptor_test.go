@@ -0,0 +1,72 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interceptor
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEvent(n string) *event.Event {
+	e := event.NewPacket(packet.NewSimpleHeaderOnly(1, 2, false))
+	e.ConnectorName = n
+	return e
+}
+
+func TestLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	passed := false
+	Logger(log.New(buf, "", 0))(bricker.Inbound, testEvent("local"), func(e *event.Event) {
+		passed = true
+	})
+	if !passed || !strings.HasPrefix(buf.String(), "Inbound local: ") {
+		t.Fatalf("Error TestLogger: wrong log (%s, %t).", buf.String(), passed)
+	}
+}
+
+func TestDrop(t *testing.T) {
+	i := Drop(bricker.Outbound, func(e *event.Event) bool { return e.ConnectorName == "drop" })
+	count := 0
+	next := func(e *event.Event) { count++ }
+	i(bricker.Outbound, testEvent("drop"), next)
+	i(bricker.Outbound, testEvent("pass"), next)
+	i(bricker.Inbound, testEvent("drop"), next)
+	if count != 2 {
+		t.Fatalf("Error TestDrop: %d events passed, expected 2.", count)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	l := &limiter{rate: 10, burst: 2, buckets: make(map[string]*bucket)}
+	now := time.Now()
+	for i, w := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if r := l.reserve("local", now); r != w {
+			t.Fatalf("Error TestReserve: event %d waits %s, expected %s.", i, r, w)
+		}
+	}
+	if r := l.reserve("other", now); r != 0 {
+		t.Fatalf("Error TestReserve: other connector waits %s.", r)
+	}
+	if r := l.reserve("local", now.Add(time.Second)); r != 0 {
+		t.Fatalf("Error TestReserve: event waits %s after refill.", r)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	i := RateLimit(bricker.Outbound, 100, 1)
+	start := time.Now()
+	for n := 0; n < 3; n++ {
+		i(bricker.Outbound, testEvent("local"), func(e *event.Event) {})
+	}
+	if d := time.Since(start); d < 15*time.Millisecond {
+		t.Fatalf("Error TestRateLimit: events are not delayed (%s).", d)
+	}
+}
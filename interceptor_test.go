@@ -0,0 +1,162 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIntercept(t *testing.T) {
+	b := New()
+	noop := func(d Direction, e *event.Event, next func(*event.Event)) { next(e) }
+	if err := b.Intercept("first", noop); err != nil {
+		t.Fatalf("Error TestIntercept: intercept failed (%s).", err.Error())
+	}
+	b.Intercept("second", noop)
+	if err := b.Intercept("first", noop); err == nil {
+		t.Fatalf("Error TestIntercept: interceptor with the same id added.")
+	}
+	if ids := b.Interceptors(); len(ids) != 2 || ids[0] != "first" || ids[1] != "second" {
+		t.Fatalf("Error TestIntercept: wrong chain (%v).", ids)
+	}
+	if err := b.RemoveInterceptor("first"); err != nil {
+		t.Fatalf("Error TestIntercept: remove failed (%s).", err.Error())
+	}
+	if err := b.RemoveInterceptor("first"); err == nil {
+		t.Fatalf("Error TestIntercept: interceptor removed twice.")
+	}
+	if ids := b.Interceptors(); len(ids) != 1 || ids[0] != "second" {
+		t.Fatalf("Error TestIntercept: wrong chain (%v).", ids)
+	}
+}
+
+func TestInterceptChain(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer b.Done()
+	var lock sync.Mutex
+	seen := make([]string, 0)
+	record := func(id string) Interceptor {
+		return func(d Direction, e *event.Event, next func(*event.Event)) {
+			if e.Packet.Head.Uid == 1 { // no enumerate
+				lock.Lock()
+				seen = append(seen, id+" "+d.String())
+				lock.Unlock()
+			}
+			next(e)
+		}
+	}
+	b.Intercept("first", record("first"))
+	b.Intercept("rewrite", func(d Direction, e *event.Event, next func(*event.Event)) {
+		if d == Inbound && e.Packet.Head.FunctionID == 9 {
+			e.Packet.Head.FunctionID = 10 // rewrite the callback
+		}
+		next(e)
+	})
+	b.Intercept("second", record("second"))
+	s := newTestSubscriber("callback", 1, 10, false, true)
+	b.Subscribe(s, "virtual")
+	v.Send(testCallback("", 1, 9, 1)) // the virtual connector answers with the callback
+	select {
+	case <-s.notified:
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestInterceptChain: rewritten event not delivered.")
+	}
+	r := newTestSubscriber("request", 1, 2, true, false)
+	b.Subscribe(r, "virtual")
+	select {
+	case e := <-r.notified:
+		if e.Err != nil {
+			t.Fatalf("Error TestInterceptChain: unexpected error (%s).", e.Err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestInterceptChain: no response.")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	expected := []string{"first Inbound", "second Inbound", "first Outbound", "second Outbound",
+		"first Inbound", "second Inbound"}
+	if len(seen) != len(expected) {
+		t.Fatalf("Error TestInterceptChain: wrong interceptions (%v).", seen)
+	}
+	for i := range seen {
+		if seen[i] != expected[i] {
+			t.Fatalf("Error TestInterceptChain: wrong interceptions (%v).", seen)
+		}
+	}
+}
+
+func TestInterceptDropResponse(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer v.Done()
+	defer b.Done()
+	b.Intercept("drop", func(d Direction, e *event.Event, next func(*event.Event)) {
+		if d == Outbound {
+			next(e)
+		}
+	})
+	r := newTestSubscriber("request", 1, 3, true, false)
+	b.Subscribe(r, "virtual")
+	select {
+	case e := <-r.notified:
+		if err, ok := e.Err.(Error); !ok || err.Code != ErrorEventDropped {
+			t.Fatalf("Error TestInterceptDropResponse: wrong error (%v).", e.Err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestInterceptDropResponse: dropped response not answered.")
+	}
+	deadline := time.Now().Add(time.Second)
+	for b.Metrics().Connectors[0].Pending != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if m := b.Metrics(); m.Connectors[0].Pending != 0 {
+		t.Fatalf("Error TestInterceptDropResponse: request is still outstanding (%v).", m.Connectors[0])
+	}
+}
+
+func TestInterceptDrop(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer b.Done()
+	dropped := make(chan struct{}, 1)
+	drop := func(d Direction, e *event.Event, next func(*event.Event)) {
+		if e.Packet.Head.FunctionID != 2 {
+			next(e)
+		} else if d == Inbound {
+			dropped <- struct{}{}
+		}
+	}
+	b.Intercept("drop", drop)
+	c := newTestSubscriber("callback", 1, 2, false, true)
+	b.Subscribe(c, "virtual")
+	v.Send(testCallback("", 1, 2, 1))
+	r := newTestSubscriber("request", 1, 2, true, false)
+	b.Subscribe(r, "virtual")
+	select {
+	case e := <-r.notified:
+		if err, ok := e.Err.(Error); !ok || err.Code != ErrorEventDropped {
+			t.Fatalf("Error TestInterceptDrop: wrong error (%v).", e.Err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestInterceptDrop: dropped request not answered.")
+	}
+	select {
+	case <-dropped:
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestInterceptDrop: event not dropped.")
+	}
+	b.RemoveInterceptor("drop")
+	v.Send(testCallback("", 1, 2, 2))
+	select {
+	case <-c.notified:
+		if n := atomic.LoadInt32(&c.count); n != 1 {
+			t.Fatalf("Error TestInterceptDrop: dropped event delivered (%d events).", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestInterceptDrop: event not delivered.")
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the default upper bounds (in seconds) of the buckets of the latency histograms.
+var DefaultLatencyBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+// Histogram counts observed values in buckets.
+type Histogram struct {
+	Bounds []float64 // upper bounds of the buckets (inclusive), in ascending order
+	Counts []uint64  // observed values per bucket, the last bucket counts the values above all bounds
+	Count  uint64    // all observed values
+	Sum    float64   // sum of all observed values
+}
+
+// Internal method: observe counts the value.
+func (h *Histogram) observe(v float64) {
+	i := sort.SearchFloat64s(h.Bounds, v)
+	h.Counts[i]++
+	h.Count++
+	h.Sum += v
+}
+
+// Internal method: copy gives a deep copy of the histogram.
+func (h *Histogram) copy() Histogram {
+	return Histogram{
+		Bounds: h.Bounds,
+		Counts: append([]uint64(nil), h.Counts...),
+		Count:  h.Count,
+		Sum:    h.Sum}
+}
+
+// ConnectorMetrics are the counters of a connector.
+type ConnectorMetrics struct {
+	Connector       string
+	PacketsSent     uint64
+	PacketsReceived uint64
+	BytesSent       uint64
+	BytesReceived   uint64
+	DecodeErrors    uint64              // received events with an error of the connector (e.g. a broken packet)
+	ErrorCodes      map[uint8]uint64    // responses with an error code of the brick daemon (see net/errors)
+	Latency         map[uint8]Histogram // response latency in seconds per function id
+	Pending         int                 // requests, which wait for a response
+	Retries         uint64              // requests sent again (see RetryPolicy)
+	Timeouts        uint64              // requests without a response after all attempts
+	Dispatch        DispatchStats       // counters of the event delivery (e.g. dropped events)
+	Flow            FlowStats           // counters of the flow control (e.g. queued packets)
+}
+
+// Metrics are the counters of a bricker.
+type Metrics struct {
+	Connectors       []ConnectorMetrics // sorted by the connector name
+	Subscribers      int                // subscriber of events (without the default fallback subscriber)
+	StateSubscribers int                // subscriber of connector state changes
+	Interceptors     int                // interceptors in the chain
+}
+
+// Internal type: connectorMeter holds the counters of a connector.
+type connectorMeter struct {
+	sent, received       uint64
+	bytesSent, bytesRecv uint64
+	decodeErrors         uint64
+	retries, timeouts    uint64
+	errorCodes           map[uint8]uint64
+	latency              map[uint8]*Histogram
+}
+
+// Internal type: meter counts the packets and measures the response latency of all connectors.
+// The meter has its own lock, it is taken after the lock of the bricker.
+type meter struct {
+	buckets    []float64
+	lock       sync.Mutex // guards all following fields
+	connectors map[string]*connectorMeter
+	started    map[request]time.Time // send time of the outstanding requests
+}
+
+// Internal function: newMeter creates a meter.
+func newMeter() *meter {
+	return &meter{
+		buckets:    DefaultLatencyBuckets,
+		connectors: make(map[string]*connectorMeter),
+		started:    make(map[request]time.Time)}
+}
+
+// Internal method: of gives the counters of the connector.
+// The caller has to hold the lock.
+func (m *meter) of(n string) *connectorMeter {
+	cm, ok := m.connectors[n]
+	if !ok {
+		cm = &connectorMeter{errorCodes: make(map[uint8]uint64), latency: make(map[uint8]*Histogram)}
+		m.connectors[n] = cm
+	}
+	return cm
+}
+
+// Internal method: sent counts a sent event and remembers the send time of a request.
+func (m *meter) sent(e *event.Event) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	cm := m.of(e.ConnectorName)
+	cm.sent++
+	if e.Packet != nil {
+		cm.bytesSent += uint64(e.Packet.ComputeLength())
+	}
+	if r, ok := requestOf(e); ok {
+		m.started[r] = time.Now()
+	}
+}
+
+// Internal method: received counts a received event, its decode error and its error code.
+// The error of a packet with a error code (see net/errors) is no decode error, it is counted as error code.
+func (m *meter) received(e *event.Event) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	cm := m.of(e.ConnectorName)
+	cm.received++
+	if e.Err != nil {
+		if _, ok := e.Err.(*errors.Error); !ok || e.Packet == nil {
+			cm.decodeErrors++
+		}
+	}
+	if e.Packet != nil && e.Packet.Head != nil {
+		cm.bytesRecv += uint64(e.Packet.ComputeLength())
+		if code := e.Packet.Head.ErrorCodeNbr(); code != errors.ErrorOK {
+			cm.errorCodes[code]++
+		}
+	}
+}
+
+// Internal method: responded measures the latency of the request, which is answered by the event.
+func (m *meter) responded(e *event.Event) {
+	r, ok := requestOf(e)
+	if !ok {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	start, ok := m.started[r]
+	if !ok {
+		return
+	}
+	delete(m.started, r)
+	cm := m.of(r.connector)
+	h, ok := cm.latency[r.fid]
+	if !ok {
+		h = &Histogram{Bounds: m.buckets, Counts: make([]uint64, len(m.buckets)+1)}
+		cm.latency[r.fid] = h
+	}
+	h.observe(time.Since(start).Seconds())
+}
+
+// Internal method: retried counts a request, which is sent again.
+func (m *meter) retried(n string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.of(n).retries++
+}
+
+// Internal method: timedOut counts a request without a response after all attempts.
+func (m *meter) timedOut(n string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.of(n).timeouts++
+}
+
+// Internal method: forget removes the send time of a request, which is not answered.
+func (m *meter) forget(r request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.started, r)
+}
+
+// Internal method: release removes all counters of the connector.
+func (m *meter) release(n string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.connectors, n)
+	for r := range m.started {
+		if r.connector == n {
+			delete(m.started, r)
+		}
+	}
+}
+
+// Internal method: snapshot gives a copy of the counters of the connector.
+func (m *meter) snapshot(n string) ConnectorMetrics {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	cm := m.of(n)
+	result := ConnectorMetrics{
+		Connector:       n,
+		PacketsSent:     cm.sent,
+		PacketsReceived: cm.received,
+		BytesSent:       cm.bytesSent,
+		BytesReceived:   cm.bytesRecv,
+		DecodeErrors:    cm.decodeErrors,
+		Retries:         cm.retries,
+		Timeouts:        cm.timeouts,
+		ErrorCodes:      make(map[uint8]uint64, len(cm.errorCodes)),
+		Latency:         make(map[uint8]Histogram, len(cm.latency))}
+	for code, c := range cm.errorCodes {
+		result.ErrorCodes[code] = c
+	}
+	for fid, h := range cm.latency {
+		result.Latency[fid] = h.copy()
+	}
+	return result
+}
+
+// Metrics returns the counters of the bricker and of all attached connectors.
+func (b *Bricker) Metrics() Metrics {
+	dispatch := make(map[string]DispatchStats)
+	for _, st := range b.DispatchStats() {
+		dispatch[st.Connector] = st
+	}
+	flow := make(map[string]FlowStats)
+	for _, st := range b.FlowStats() {
+		flow[st.Connector] = st
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	m := Metrics{
+		Connectors:       make([]ConnectorMetrics, 0, len(b.connection)),
+		StateSubscribers: len(b.statesubscriber),
+		Interceptors:     len(b.interceptors)}
+	for _, subs := range b.subscriber {
+		m.Subscribers += len(subs)
+	}
+	pending := make(map[string]int)
+	for r := range b.outstanding {
+		pending[r.connector]++
+	}
+	names := make([]string, 0, len(b.connection))
+	for n := range b.connection {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		cm := b.meter.snapshot(n)
+		cm.Pending = pending[n]
+		cm.Dispatch = dispatch[n]
+		cm.Dispatch.Connector = n
+		cm.Flow = flow[n]
+		cm.Flow.Connector = n
+		m.Connectors = append(m.Connectors, cm)
+	}
+	return m
+}
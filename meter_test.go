@@ -0,0 +1,89 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"errors"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	h := &Histogram{Bounds: []float64{1, 2}, Counts: make([]uint64, 3)}
+	for _, v := range []float64{0.5, 1, 1.5, 3} {
+		h.observe(v)
+	}
+	if h.Counts[0] != 2 || h.Counts[1] != 1 || h.Counts[2] != 1 || h.Count != 4 || h.Sum != 6 {
+		t.Fatalf("Error TestHistogram: wrong histogram (%v).", h)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	b, v := newTestBricker(t, echoGenerator)
+	defer b.Done()
+	s := newTestSubscriber("request", 1, 2, true, false)
+	b.Subscribe(s, "virtual")
+	select {
+	case <-s.notified:
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestMetrics: no response.")
+	}
+	b.Subscribe(newTestSubscriber("callback", 1, 3, false, true), "virtual")
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 1, 200), func(e *event.Event) *event.Event {
+		p := packet.NewSimpleHeaderOnly(1, 4, false)
+		p.Head.ErrorCodeAndFutureUse = 2 << 6 // function not supported
+		return event.NewSimple(errors.New("broken"), p)
+	})
+	v.Send(event.NewPacket(packet.NewSimpleHeaderOnly(1, 200, false)))
+	var cm ConnectorMetrics
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		m := b.Metrics()
+		if len(m.Connectors) == 1 && m.Connectors[0].DecodeErrors == 1 {
+			cm = m.Connectors[0]
+			if m.Subscribers != 1 {
+				t.Fatalf("Error TestMetrics: wrong subscriber count (%d).", m.Subscribers)
+			}
+			break
+		}
+	}
+	if cm.Connector != "virtual" || cm.ErrorCodes[2] != 1 {
+		t.Fatalf("Error TestMetrics: wrong metrics (%v).", cm)
+	}
+	if cm.PacketsSent < 2 || cm.PacketsReceived < 2 || cm.BytesSent < 16 || cm.BytesReceived < 16 {
+		t.Fatalf("Error TestMetrics: wrong packet counters (%v).", cm)
+	}
+	if h, ok := cm.Latency[2]; !ok || h.Count != 1 || len(h.Counts) != len(DefaultLatencyBuckets)+1 {
+		t.Fatalf("Error TestMetrics: wrong latency (%v).", cm.Latency)
+	}
+	if cm.Pending != 0 {
+		t.Fatalf("Error TestMetrics: pending requests (%d).", cm.Pending)
+	}
+	b.Release("virtual")
+	if m := b.Metrics(); len(m.Connectors) != 0 {
+		t.Fatalf("Error TestMetrics: metrics of a released connector (%v).", m.Connectors)
+	}
+}
+
+func TestMetricsErrorCode(t *testing.T) {
+	m := newMeter()
+	p := packet.NewSimpleHeaderOnly(1, 4, false)
+	p.Head.ErrorCodeAndFutureUse = 1 << 6       // invalid parameter
+	e := event.NewSimple(p.Head.ErrorCode(), p) // like the connectors, which read the packet
+	e.ConnectorName = "virtual"
+	m.received(e)
+	if cm := m.snapshot("virtual"); cm.DecodeErrors != 0 || cm.ErrorCodes[1] != 1 {
+		t.Fatalf("Error TestMetricsErrorCode: wrong metrics (%v).", cm)
+	}
+}
+
+func TestLatencyBuckets(t *testing.T) {
+	b := New(LatencyBuckets(2, 1), LatencyBuckets())
+	if len(b.meter.buckets) != 2 || b.meter.buckets[0] != 1 {
+		t.Fatalf("Error TestLatencyBuckets: wrong buckets (%v).", b.meter.buckets)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Exposition of the metrics of a bricker in the Prometheus text format (version 0.0.4).
+
+The handler could be mounted on every http.ServeMux:
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(brick))
+
+All metric names start with "bricker_", the counters of a connector have the label connector.
+*/
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/net/errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler creates a http handler, which writes the actual metrics of the bricker.
+func Handler(brick *bricker.Bricker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		Write(w, brick.Metrics())
+	})
+}
+
+// Internal type: family is a metric with its help text, type and a function for the value per connector.
+type family struct {
+	name, help, kind string
+	value            func(cm *bricker.ConnectorMetrics) float64
+}
+
+// All simple metrics per connector.
+var families = []family{
+	{"bricker_packets_sent_total", "Packets sent to the connector.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.PacketsSent) }},
+	{"bricker_packets_received_total", "Packets received from the connector.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.PacketsReceived) }},
+	{"bricker_bytes_sent_total", "Bytes sent to the connector.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.BytesSent) }},
+	{"bricker_bytes_received_total", "Bytes received from the connector.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.BytesReceived) }},
+	{"bricker_decode_errors_total", "Received packets, which could not be decoded.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.DecodeErrors) }},
+	{"bricker_retries_total", "Requests sent again, because no response came in time.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Retries) }},
+	{"bricker_request_timeouts_total", "Requests without a response after all attempts.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Timeouts) }},
+	{"bricker_pending_requests", "Requests, which wait for a response.", "gauge",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Pending) }},
+	{"bricker_queued_events", "Events, which wait in the device queues.", "gauge",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Dispatch.Queued) }},
+	{"bricker_delivered_events_total", "Events delivered to the subscriber.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Dispatch.Delivered) }},
+	{"bricker_dropped_events_total", "Events dropped by the overflow policy of the device queues.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Dispatch.Dropped) }},
+	{"bricker_flow_queued_packets", "Packets, which wait in the queue of the flow control.", "gauge",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Flow.Queued) }},
+	{"bricker_flow_in_flight_requests", "Requests in the window of the flow control.", "gauge",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Flow.InFlight) }},
+	{"bricker_flow_delayed_packets_total", "Packets, which waited in the queue of the flow control.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Flow.Delayed) }},
+	{"bricker_flow_wait_seconds_total", "Waiting time of the packets in the queue of the flow control.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return cm.Flow.Wait.Seconds() }},
+	{"bricker_flow_expired_requests_total", "Requests, which left the window without a response.", "counter",
+		func(cm *bricker.ConnectorMetrics) float64 { return float64(cm.Flow.Expired) }},
+}
+
+// Write writes the metrics in the text format.
+func Write(w io.Writer, m bricker.Metrics) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		header(bw, f.name, f.help, f.kind)
+		for i := range m.Connectors {
+			sample(bw, f.name, labels("connector", m.Connectors[i].Connector), f.value(&m.Connectors[i]))
+		}
+	}
+	header(bw, "bricker_brickd_errors_total", "Responses with an error code of the brick daemon.", "counter")
+	for _, cm := range m.Connectors {
+		codes := make([]int, 0, len(cm.ErrorCodes))
+		for code := range cm.ErrorCodes {
+			codes = append(codes, int(code))
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			sample(bw, "bricker_brickd_errors_total",
+				labels("connector", cm.Connector, "code", strconv.Itoa(code), "error", errors.New(uint8(code)).Error()),
+				float64(cm.ErrorCodes[uint8(code)]))
+		}
+	}
+	header(bw, "bricker_response_latency_seconds", "Latency of the responses per function id.", "histogram")
+	for _, cm := range m.Connectors {
+		fids := make([]int, 0, len(cm.Latency))
+		for fid := range cm.Latency {
+			fids = append(fids, int(fid))
+		}
+		sort.Ints(fids)
+		for _, fid := range fids {
+			histogram(bw, "bricker_response_latency_seconds", cm.Latency[uint8(fid)],
+				"connector", cm.Connector, "fid", strconv.Itoa(fid))
+		}
+	}
+	header(bw, "bricker_subscribers", "Subscriber of events.", "gauge")
+	sample(bw, "bricker_subscribers", "", float64(m.Subscribers))
+	header(bw, "bricker_state_subscribers", "Subscriber of connector state changes.", "gauge")
+	sample(bw, "bricker_state_subscribers", "", float64(m.StateSubscribers))
+	header(bw, "bricker_interceptors", "Interceptors in the chain.", "gauge")
+	sample(bw, "bricker_interceptors", "", float64(m.Interceptors))
+	return bw.Flush()
+}
+
+// Internal function: header writes the help and type lines of a metric.
+func header(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// Internal function: sample writes one line with a value.
+func sample(w io.Writer, name, labels string, v float64) {
+	fmt.Fprintf(w, "%s%s %s\n", name, labels, strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+// Internal function: histogram writes the cumulative buckets, the sum and the count of a histogram.
+func histogram(w io.Writer, name string, h bricker.Histogram, kv ...string) {
+	cumulative := uint64(0)
+	for i, c := range h.Counts {
+		cumulative += c
+		le := "+Inf"
+		if i < len(h.Bounds) {
+			le = strconv.FormatFloat(h.Bounds[i], 'g', -1, 64)
+		}
+		sample(w, name+"_bucket", labels(append(kv, "le", le)...), float64(cumulative))
+	}
+	sample(w, name+"_sum", labels(kv...), h.Sum)
+	sample(w, name+"_count", labels(kv...), float64(h.Count))
+}
+
+// Internal variable: escape escapes a label value.
+var escape = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Internal function: labels formats the label pairs (name, value, ...).
+func labels(kv ...string) string {
+	pairs := make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		pairs = append(pairs, kv[i]+`="`+escape.Replace(kv[i+1])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,64 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	m := bricker.Metrics{
+		Connectors: []bricker.ConnectorMetrics{{
+			Connector:   "local \"usb\"",
+			PacketsSent: 3,
+			ErrorCodes:  map[uint8]uint64{1: 2},
+			Latency: map[uint8]bricker.Histogram{
+				7: {Bounds: []float64{0.1, 1}, Counts: []uint64{1, 2, 1}, Count: 4, Sum: 2.5}},
+			Dispatch: bricker.DispatchStats{Dropped: 5}}},
+		Subscribers: 4}
+	buf := new(bytes.Buffer)
+	if err := Write(buf, m); err != nil {
+		t.Fatalf("Error TestWrite: unexpected error (%s).", err.Error())
+	}
+	txt := buf.String()
+	for _, line := range []string{
+		"# TYPE bricker_packets_sent_total counter",
+		`bricker_packets_sent_total{connector="local \"usb\""} 3`,
+		`bricker_dropped_events_total{connector="local \"usb\""} 5`,
+		`bricker_brickd_errors_total{connector="local \"usb\"",code="1",error="Invalid parameter"} 2`,
+		"# TYPE bricker_response_latency_seconds histogram",
+		`bricker_response_latency_seconds_bucket{connector="local \"usb\"",fid="7",le="0.1"} 1`,
+		`bricker_response_latency_seconds_bucket{connector="local \"usb\"",fid="7",le="1"} 3`,
+		`bricker_response_latency_seconds_bucket{connector="local \"usb\"",fid="7",le="+Inf"} 4`,
+		`bricker_response_latency_seconds_sum{connector="local \"usb\"",fid="7"} 2.5`,
+		`bricker_response_latency_seconds_count{connector="local \"usb\"",fid="7"} 4`,
+		"bricker_subscribers 4",
+	} {
+		if !strings.Contains(txt, line+"\n") {
+			t.Fatalf("Error TestWrite: line %s missing in\n%s", line, txt)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	brick := bricker.New()
+	defer brick.Done()
+	v := virtual.New()
+	defer v.Done()
+	brick.Attach(v, "virtual")
+	rec := httptest.NewRecorder()
+	Handler(brick).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("Error TestHandler: wrong content type (%s).", rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), `bricker_packets_sent_total{connector="virtual"}`) {
+		t.Fatalf("Error TestHandler: connector missing in\n%s", rec.Body.String())
+	}
+}
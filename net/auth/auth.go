@@ -0,0 +1,120 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Authentication with the brick daemon (brickd) or a master extension (WIFI, Ethernet).
+
+If the authentication is enabled, the brick daemon drops all requests of a connection,
+until the connection is authenticated. The authentication is a handshake:
+the client get a nonce from the server (function get_authentication_nonce of uid 1),
+computes a HMAC-SHA1 digest with the secret over the server nonce and a own client nonce and
+sends both (function authenticate of uid 1).
+If the digest is wrong, the brick daemon closes the connection.
+
+The authentication is done as option on the connection (net.Option), directly after dial:
+
+	conn, err := buffered.New("localhost:4223", 10, 10, auth.Secret("my secret"))
+
+A reconnecting connector (connector/reconnect) with a dialer with this option
+authenticates after every reconnect again.
+*/
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"github.com/dirkjabl/bricker/net"
+	"github.com/dirkjabl/bricker/net/packet"
+	"time"
+)
+
+// Uid and function ids of the brick daemon for the authentication.
+const (
+	uid                               = uint32(1)
+	function_get_authentication_nonce = uint8(1)
+	function_authenticate             = uint8(2)
+)
+
+// Timeout for the whole handshake.
+var Timeout = 5 * time.Second
+
+// Nonce is the server nonce (result of get_authentication_nonce).
+type Nonce struct {
+	Value [4]uint8
+}
+
+// authenticate is the parameter of the authenticate function.
+type authenticate struct {
+	ClientNonce [4]uint8
+	Digest      [20]uint8
+}
+
+// Secret returns a option, which authenticates the connection with the given secret.
+func Secret(secret string) net.Option {
+	return func(c *net.Net) error {
+		return Authenticate(c, secret)
+	}
+}
+
+// Authenticate does the authentication handshake over the given connection with the secret.
+// It has to be done, before any other packet is written or read over the connection.
+// If the handshake fails, a error (Error) is returned.
+func Authenticate(c *net.Net, secret string) error {
+	for _, r := range secret {
+		if r > 127 {
+			return NewError(ErrorSecretNotASCII, nil)
+		}
+	}
+	c.SetDeadline(time.Now().Add(Timeout)) // only for streams with a deadline support
+	defer c.SetDeadline(time.Time{})
+	p, err := call(c, packet.NewSimpleHeaderOnly(uid, function_get_authentication_nonce, true), 1)
+	if err != nil {
+		return NewError(ErrorNoNonce, err)
+	}
+	server := &Nonce{}
+	if p.Payload == nil {
+		return NewError(ErrorNoNonce, nil)
+	}
+	if err = p.Payload.Decode(server); err != nil {
+		return NewError(ErrorNoNonce, err)
+	}
+	a := &authenticate{}
+	if _, err = rand.Read(a.ClientNonce[:]); err != nil {
+		return NewError(ErrorUnknown, err)
+	}
+	copy(a.Digest[:], Digest(secret, server.Value, a.ClientNonce))
+	_, err = call(c, packet.NewSimpleHeaderPayload(uid, function_authenticate, true, a), 2)
+	if err != nil {
+		return NewError(ErrorAuthenticationFailed, err)
+	}
+	return nil
+}
+
+// Digest computes the HMAC-SHA1 digest with the secret over the server and the client nonce.
+func Digest(secret string, server, client [4]uint8) []byte {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(server[:])
+	mac.Write(client[:])
+	return mac.Sum(nil)
+}
+
+// Internal function: call writes the request with the given sequence and reads the response.
+// Other packets are skipped.
+func call(c *net.Net, p *packet.Packet, seq uint8) (*packet.Packet, error) {
+	p.Head.SetSequence(seq)
+	p.Head.Length = p.ComputeLength()
+	if err := c.WritePacket(p); err != nil {
+		return nil, err
+	}
+	for {
+		r, err := c.ReadPacket()
+		if r == nil {
+			return nil, err
+		}
+		if r.Head.Uid == uid && r.Head.FunctionID == p.Head.FunctionID && r.Head.Sequence() == seq {
+			return r, err // err is a error code of the brick daemon or nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker/net"
+	"github.com/dirkjabl/bricker/net/packet"
+	gonet "net"
+	"testing"
+)
+
+// brickd is a fake brick daemon, which only knows the authentication.
+func brickd(t *testing.T, secret string) string {
+	l, err := gonet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error %s: could not listen (%s).", t.Name(), err.Error())
+	}
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		server := [4]uint8{1, 2, 3, 4}
+		for {
+			p, err := packet.ReadNew(conn)
+			if p == nil || err != nil {
+				return
+			}
+			switch p.Head.FunctionID {
+			case function_get_authentication_nonce:
+				r := packet.NewSimpleHeaderPayload(uid, function_get_authentication_nonce, false, &Nonce{server})
+				r.Head.SetSequence(p.Head.Sequence())
+				r.Write(conn)
+			case function_authenticate:
+				a := &authenticate{}
+				p.Payload.Decode(a)
+				if !bytes.Equal(a.Digest[:], Digest(secret, server, a.ClientNonce)) {
+					return // wrong secret, close the connection
+				}
+				r := packet.NewSimpleHeaderOnly(uid, function_authenticate, false)
+				r.Head.SetSequence(p.Head.Sequence())
+				r.Write(conn)
+			}
+		}
+	}()
+	return l.Addr().String()
+}
+
+func TestAuthenticate(t *testing.T) {
+	addr := brickd(t, "secret")
+	c, err := net.Dial(addr, Secret("secret"))
+	if err != nil {
+		t.Fatalf("Error TestAuthenticate: authentication failed (%s).", err.Error())
+	}
+	c.Close()
+}
+
+func TestAuthenticateWrongSecret(t *testing.T) {
+	addr := brickd(t, "secret")
+	_, err := net.Dial(addr, Secret("wrong"))
+	if e, ok := err.(Error); !ok || e.Code != ErrorAuthenticationFailed {
+		t.Fatalf("Error TestAuthenticateWrongSecret: expected authentication failed, get (%v).", err)
+	}
+}
+
+func TestAuthenticateNotASCII(t *testing.T) {
+	addr := brickd(t, "secret")
+	_, err := net.Dial(addr, Secret("geheimnis äöü"))
+	if e, ok := err.(Error); !ok || e.Code != ErrorSecretNotASCII {
+		t.Fatalf("Error TestAuthenticateNotASCII: expected secret not ASCII, get (%v).", err)
+	}
+}
+
+func TestDigest(t *testing.T) {
+	// HMAC-SHA1 with key "key" over the bytes 0x01 to 0x08
+	expected := []byte{0x05, 0x14, 0xb1, 0x11, 0x95, 0xf0, 0x91, 0xc8, 0x49, 0x7f,
+		0x36, 0xef, 0x99, 0xef, 0x8d, 0x5a, 0xbe, 0xd0, 0x34, 0x83}
+	d := Digest("key", [4]uint8{1, 2, 3, 4}, [4]uint8{5, 6, 7, 8})
+	if !bytes.Equal(d, expected) {
+		t.Fatalf("Error TestDigest: digest %x, expected %x.", d, expected)
+	}
+}
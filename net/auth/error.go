@@ -0,0 +1,45 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+// All known errors for the authentication.
+const (
+	ErrorUnknown = iota
+	ErrorSecretNotASCII
+	ErrorNoNonce
+	ErrorAuthenticationFailed
+)
+
+// Error type for the authentication.
+type Error struct {
+	Code uint8
+	Err  error // cause of the error, could be nil
+}
+
+// NewError create the error object.
+func NewError(code uint8, err error) Error {
+	return Error{Code: code, Err: err}
+}
+
+// Error gives a string representation for the error code.
+func (e Error) Error() string {
+	var txt string
+	switch e.Code {
+	case ErrorSecretNotASCII:
+		txt = "Authentication secret has non ASCII characters."
+	case ErrorNoNonce:
+		txt = "Could not get the authentication nonce."
+	case ErrorAuthenticationFailed:
+		txt = "Authentication failed."
+	case ErrorUnknown:
+		fallthrough
+	default:
+		txt = "Unknown error."
+	}
+	if e.Err != nil {
+		txt += " (" + e.Err.Error() + ")"
+	}
+	return txt
+}
@@ -40,12 +40,12 @@ func (h *Head) Sequence() uint8 {
 }
 
 // SetSequence sets a new sequence to the header.
-// The sequence has to be between 1 and 15.
+// The sequence has to be between 1 and 15, an existing sequence will be replaced.
 func (h *Head) SetSequence(seq uint8) {
 	if seq < 1 || seq > 15 {
 		panic(fmt.Sprintf("Sequence (%d) is out of range (1-15)", seq))
 	}
-	h.SequenceAndOptions |= (seq << 4) & 0xf0
+	h.SequenceAndOptions = (h.SequenceAndOptions & 0x0f) | ((seq << 4) & 0xf0)
 }
 
 // OptionResponseExpected read out, if this header is configured to expect a response after sending.
@@ -61,7 +61,7 @@ func (h *Head) SetOptionResponseExpected(exp bool) {
 	} else {
 		v = 0
 	}
-	h.SequenceAndOptions |= (v << 3) & 8
+	h.SequenceAndOptions = (h.SequenceAndOptions &^ 8) | ((v << 3) & 8)
 }
 
 // OptionOther read out the other options from the header.
@@ -3,39 +3,98 @@
 // license that can be found in the LICENSE file.
 
 /*
-TCP/IP Networking connection.
+Networking connection to the brick daemon.
+
+A connection is a stream of packets over any io.ReadWriteCloser.
+A dialer creates the stream: TCP (the default, see Dial), a Unix socket or any other stream,
+e.g. a SSH forwarded channel, a serial to TCP bridge or a net.Pipe for tests.
+
+	conn, err := net.Open("ssh:brickd", func() (io.ReadWriteCloser, error) {
+		return sshClient.Dial("tcp", "localhost:4223")
+	})
 */
 package net
 
 import (
+	"bytes"
 	"github.com/dirkjabl/bricker/net/packet"
+	"io"
 	"net"
+	"time"
 )
 
 // IPConn holds the connection and the address for that connection and has methods to read and write packets.
 // No locks or anything to make it thread save. This is the raw structure for communication.
 type Net struct {
 	Address string
-	Conn    *net.TCPConn
+	Conn    io.ReadWriteCloser
 }
 
-// Dial is a shortcut to IPConn.Dial.
-func Dial(addr string) (*Net, error) {
-	conn := new(Net)
-	conn.Address = addr
-	err := conn.Dial()
-	return conn, err
+// Option is a step, which is done directly after the connection is established (e.g. authentication).
+// If a option fails, the connection is closed.
+type Option func(c *Net) error
+
+// Dialer creates a new stream to the brick daemon.
+type Dialer func() (io.ReadWriteCloser, error)
+
+// TCP returns a dialer for a TCP connection to the address (host and port).
+func TCP(addr string) Dialer {
+	return func() (io.ReadWriteCloser, error) {
+		taddr, err := net.ResolveTCPAddr("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := net.DialTCP("tcp", nil, taddr)
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
 }
 
-// Dial makes a connection to the given IPConn object.
-func (c *Net) Dial() error {
-	var conn *net.TCPConn
+// Unix returns a dialer for a connection to the Unix domain socket with the path.
+func Unix(path string) Dialer {
+	return func() (io.ReadWriteCloser, error) {
+		return net.Dial("unix", path)
+	}
+}
+
+// Stream returns a dialer, which returns the given established stream (e.g. one end of a net.Pipe).
+// The dialer could be used only once, a reconnect needs a dialer, which creates new streams.
+func Stream(rwc io.ReadWriteCloser) Dialer {
+	return func() (io.ReadWriteCloser, error) {
+		return rwc, nil
+	}
+}
+
+// Dial is a shortcut to Open with a TCP dialer for the address.
+// After the connection is established, all given options are done in order.
+func Dial(addr string, opts ...Option) (*Net, error) {
+	return Open(addr, TCP(addr), opts...)
+}
 
-	taddr, err := net.ResolveTCPAddr("tcp", c.Address)
+// Open creates a connection with the dialer, the address is only a description of the connection.
+// After the connection is established, all given options are done in order.
+func Open(addr string, d Dialer, opts ...Option) (*Net, error) {
+	conn := new(Net)
+	conn.Address = addr
+	rwc, err := d()
 	if err != nil {
-		return err
+		return conn, err
+	}
+	conn.Conn = rwc
+	for _, opt := range opts {
+		if err = opt(conn); err != nil {
+			conn.Close()
+			return conn, err
+		}
 	}
-	conn, err = net.DialTCP("tcp", nil, taddr)
+	return conn, nil
+}
+
+// Dial makes a TCP connection to the address of the given Net object.
+func (c *Net) Dial() error {
+	conn, err := TCP(c.Address)()
 	if err != nil {
 		return err
 	}
@@ -43,9 +102,24 @@ func (c *Net) Dial() error {
 	return nil
 }
 
+// SetDeadline sets the deadline for reading and writing, if the stream supports deadlines
+// (e.g. a net.Conn). Without a deadline support, it does nothing.
+func (c *Net) SetDeadline(t time.Time) error {
+	if d, ok := c.Conn.(interface{ SetDeadline(time.Time) error }); ok {
+		return d.SetDeadline(t)
+	}
+	return nil
+}
+
 // WritePacket sends one packet over the network connection to the brickd.
+// The packet is written at once, so a stream of messages gets the whole packet in one message.
 func (c *Net) WritePacket(p *packet.Packet) error {
-	return p.Write(c.Conn)
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		return err
+	}
+	_, err := c.Conn.Write(buf.Bytes())
+	return err
 }
 
 // ReadPacket receive one packet from the network connection (brickd).
@@ -56,5 +130,7 @@ func (c *Net) ReadPacket() (*packet.Packet, error) {
 
 // Close disconnected the connection.
 func (c *Net) Close() {
-	c.Conn.Close()
+	if c.Conn != nil {
+		c.Conn.Close()
+	}
 }
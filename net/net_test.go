@@ -0,0 +1,86 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"bytes"
+	"errors"
+	"github.com/dirkjabl/bricker/net/packet"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenPipe(t *testing.T) {
+	client, server := net.Pipe()
+	c, err := Open("pipe", Stream(client))
+	if err != nil {
+		t.Fatalf("Error TestOpenPipe: could not open (%s).", err.Error())
+	}
+	defer c.Close()
+	go func() {
+		p, _ := packet.ReadNew(server)
+		r := packet.NewSimpleHeaderPayload(p.Head.Uid, p.Head.FunctionID, false, uint32(0xdeadbeef))
+		r.Head.SetSequence(p.Head.Sequence())
+		r.Head.Length = r.ComputeLength()
+		var buf bytes.Buffer
+		r.Write(&buf)
+		for _, b := range buf.Bytes() { // a stream with single bytes
+			server.Write([]byte{b})
+		}
+	}()
+	p := packet.NewSimpleHeaderOnly(42, 7, true)
+	p.Head.SetSequence(3)
+	p.Head.Length = p.ComputeLength()
+	if err := c.WritePacket(p); err != nil {
+		t.Fatalf("Error TestOpenPipe: could not write (%s).", err.Error())
+	}
+	r, err := c.ReadPacket()
+	var v uint32
+	if err != nil || r.Head.Uid != 42 || r.Head.Sequence() != 3 || r.Payload.Decode(&v) != nil || v != 0xdeadbeef {
+		t.Fatalf("Error TestOpenPipe: wrong response %v (%v).", r, err)
+	}
+}
+
+func TestOpenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brickd.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("TestOpenUnix: no unix sockets (%s).", err.Error())
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			io.Copy(conn, conn) // echo
+			conn.Close()
+		}
+	}()
+	c, err := Open(path, Unix(path))
+	if err != nil {
+		t.Fatalf("Error TestOpenUnix: could not open (%s).", err.Error())
+	}
+	defer c.Close()
+	p := packet.NewSimpleHeaderOnly(42, 7, true)
+	p.Head.Length = p.ComputeLength()
+	c.WritePacket(p)
+	if r, err := c.ReadPacket(); err != nil || r.Head.Uid != 42 || r.Head.FunctionID != 7 {
+		t.Fatalf("Error TestOpenUnix: wrong echo %v (%v).", r, err)
+	}
+}
+
+func TestOpenOption(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	failed := errors.New("option failed")
+	_, err := Open("pipe", Stream(client), func(c *Net) error { return failed })
+	if err != failed {
+		t.Fatalf("Error TestOpenOption: wrong error %v.", err)
+	}
+	if _, err := client.Write([]byte{0}); err == nil {
+		t.Fatalf("Error TestOpenOption: connection is not closed.")
+	}
+}
@@ -35,9 +35,7 @@ func (o *OptionalData) Copy() *OptionalData {
 	if o == nil { // no optionaldata, no copy
 		return nil
 	}
-	n := &OptionalData{}
-	copy(*o, *n)
-	return n
+	return New(*o)
 }
 
 // Write writes the optinal data into a given writer.
@@ -50,18 +48,11 @@ func (o *OptionalData) Write(w io.Writer) error {
 
 // Read reads the optinal data out of a given reader.
 func (o *OptionalData) Read(r io.Reader, l uint8) error {
-	var err error
-	var i int
 	if l < 1 { // nothing to read
 		return nil
 	}
 	*o = make(OptionalData, l)
-	i = int(l)
-	err = nil
-	for i > 0 && err == nil {
-		i, err = r.Read(*o)
-		i = int(l) - i
-	}
+	_, err := io.ReadFull(r, *o) // a stream could return less bytes per read
 	return err
 }
 
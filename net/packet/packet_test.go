@@ -58,3 +58,23 @@ func TestComputeLength(t *testing.T) {
 }
 
 // t.Fatalf
+
+func TestCopy(t *testing.T) {
+	p := NewSimpleHeaderPayload(1, 2, true, uint16(0x0304))
+	p.Head.SetSequence(3)
+	c := p.Copy()
+	c.Head.SetSequence(5)
+	if p.Head.Sequence() != 3 || c.Head.Sequence() != 5 {
+		t.Fatalf("Error TestCopy: sequences are wrong (%d != 3, %d != 5).", p.Head.Sequence(), c.Head.Sequence())
+	}
+	if !c.Head.OptionResponseExpected() {
+		t.Fatalf("Error TestCopy: option response expected lost by setting the sequence.")
+	}
+	if len(*c.Payload) != 2 || (*c.Payload)[0] != 4 || (*c.Payload)[1] != 3 {
+		t.Fatalf("Error TestCopy: payload not copied (%v).", c.Payload)
+	}
+	p = nil
+	if p.Copy() != nil {
+		t.Fatalf("Error TestCopy: copy of nil packet is not nil.")
+	}
+}
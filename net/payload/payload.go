@@ -47,9 +47,7 @@ func (p *Payload) Copy() *Payload {
 	if p == nil { // no payload, no copy
 		return nil
 	}
-	n := &Payload{}
-	copy(*p, *n)
-	return n
+	return New(*p)
 }
 
 // Write writes the payload into a given writer.
@@ -65,8 +63,6 @@ func (p *Payload) Read(r io.Reader, l uint8) error {
 	if p == nil {
 		return errors.New("Error: Payload could not be nil.")
 	}
-	var err error
-	var i int
 	if l > 64 {
 		return errors.New("Warning: Length of bytes for reading of the payload are to long.")
 	}
@@ -74,12 +70,7 @@ func (p *Payload) Read(r io.Reader, l uint8) error {
 		return nil
 	}
 	*p = make(Payload, l)
-	i = int(l)
-	err = nil
-	for i > 0 && err == nil {
-		i, err = r.Read(*p)
-		i = int(l) - i
-	}
+	_, err := io.ReadFull(r, *p) // a stream could return less bytes per read
 	return err
 }
 
@@ -96,3 +96,19 @@ func TestWritePayload(t *testing.T) {
 		t.Fatalf("Error TestWritePayload: Get same byte slices %v != %v. ", c, b)
 	}
 }
+
+func TestCopyPayload(t *testing.T) {
+	p := New([]byte("123"))
+	c := p.Copy()
+	if bytes.Compare(p.Bytes(), c.Bytes()) != 0 {
+		t.Fatalf("Error TestCopyPayload: copy differs from original (%v != %v).", p, c)
+	}
+	(*c)[0] = '9'
+	if (*p)[0] != '1' {
+		t.Fatalf("Error TestCopyPayload: copy shares the memory with the original.")
+	}
+	p = nil
+	if p.Copy() != nil {
+		t.Fatalf("Error TestCopyPayload: copy of nil payload is not nil.")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"sort"
+)
+
+// Option configures a bricker on creation (see New).
+type Option func(b *Bricker)
+
+// QueueDepth sets the number of events, which could wait in the queue of a device
+// (default DefaultQueueDepth). A depth smaller than 1 is ignored.
+func QueueDepth(depth int) Option {
+	return func(b *Bricker) {
+		if depth > 0 {
+			b.dispatcher.depth = depth
+		}
+	}
+}
+
+// OverflowPolicy sets the policy for a full device queue (default OverflowBlock).
+func OverflowPolicy(o Overflow) Option {
+	return func(b *Bricker) {
+		b.dispatcher.overflow = o
+	}
+}
+
+// LatencyBuckets sets the upper bounds (in seconds, ascending) of the buckets of the latency histograms
+// (default DefaultLatencyBuckets). Without bounds, the option is ignored.
+func LatencyBuckets(bounds ...float64) Option {
+	return func(b *Bricker) {
+		if len(bounds) > 0 {
+			sorted := append([]float64(nil), bounds...)
+			sort.Float64s(sorted)
+			b.meter.buckets = sorted
+		}
+	}
+}
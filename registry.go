@@ -0,0 +1,255 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dirkjabl/bricker/device/name"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/base58"
+	"github.com/dirkjabl/bricker/net/packet"
+	"sort"
+)
+
+// Function ids of the enumerate request and callback and the enumeration types.
+const (
+	function_enumerate            = uint8(254)
+	callback_enumerate            = uint8(253)
+	enumeration_type_disconnected = uint8(2)
+)
+
+// Internal type: enumeration is the payload of a enumerate callback (see device/enumerate).
+type enumeration struct {
+	Uid             [8]byte
+	ConnectedUid    [8]byte
+	Position        byte
+	HardwareVersion [3]uint8
+	FirmwareVersion [3]uint8
+	DeviceIdentifer uint16
+	EnumerationType uint8
+}
+
+// DeviceInfo describes a device (brick or bricklet), which is known by a enumeration.
+type DeviceInfo struct {
+	Connector        string // name of the connector, over which the device is reachable
+	Uid              uint32
+	ConnectedUid     uint32 // uid of the brick, the device is connected to (0 for a brick at the bottom of a stack)
+	Position         byte   // position on the brick ('a' to 'd') or in the stack ('0' to '8')
+	HardwareVersion  [3]uint8
+	FirmwareVersion  [3]uint8
+	DeviceIdentifier uint16
+}
+
+// IsBricklet checks, if the device is a bricklet (connected to a brick port).
+func (di DeviceInfo) IsBricklet() bool {
+	return di.Position >= 'a' && di.Position <= 'z'
+}
+
+// Name returns the name of the device type.
+func (di DeviceInfo) Name() string {
+	return name.Name(di.DeviceIdentifier)
+}
+
+// String fullfill the stringer interface.
+func (di DeviceInfo) String() string {
+	txt := "Device ["
+	txt += fmt.Sprintf("Connector: %s, ", di.Connector)
+	txt += fmt.Sprintf("UID: %s (%d), ", uidString(di.Uid), di.Uid)
+	txt += fmt.Sprintf("Connected UID: %s (%d), ", uidString(di.ConnectedUid), di.ConnectedUid)
+	txt += fmt.Sprintf("Position: %c, ", di.Position)
+	txt += fmt.Sprintf("Hardware Version: %d.%d.%d, ", di.HardwareVersion[0],
+		di.HardwareVersion[1], di.HardwareVersion[2])
+	txt += fmt.Sprintf("Firmware Version: %d.%d.%d, ", di.FirmwareVersion[0],
+		di.FirmwareVersion[1], di.FirmwareVersion[2])
+	txt += "Name: " + di.Name() + "]"
+	return txt
+}
+
+// Brick is a brick with all connected bricklets.
+type Brick struct {
+	DeviceInfo
+	Bricklets []DeviceInfo
+}
+
+// Stack is a stack of bricks, reachable over one connector.
+// The first brick is the brick at the bottom of the stack.
+type Stack struct {
+	Connector string
+	Bricks    []*Brick
+}
+
+// Devices returns all known devices, sorted by connector and uid.
+func (b *Bricker) Devices() []DeviceInfo {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	devices := make([]DeviceInfo, 0)
+	for _, n := range sortedKeys(b.registry) {
+		devices = append(devices, sortedDevices(b.registry[n])...)
+	}
+	return devices
+}
+
+// Device returns the known device with the given uid.
+func (b *Bricker) Device(uid uint32) (DeviceInfo, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if n, ok := b.uids[uid]; ok {
+		if di, ok := b.registry[n][uid]; ok {
+			return di, nil
+		}
+	}
+	return DeviceInfo{}, NewError(ErrorUnknownDevice)
+}
+
+// Topology returns all known devices as tree of stacks, bricks and bricklets.
+// A bricklet, which brick is not known, is connected to a brick with only the uid.
+func (b *Bricker) Topology() []*Stack {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	stacks := make([]*Stack, 0)
+	for _, n := range sortedKeys(b.registry) {
+		devices := sortedDevices(b.registry[n])
+		bricks := make(map[uint32]*Brick)
+		order := make([]*Brick, 0)
+		brickOf := func(uid uint32) *Brick {
+			if br, ok := bricks[uid]; ok {
+				return br
+			}
+			br := &Brick{DeviceInfo: DeviceInfo{Connector: n, Uid: uid}, Bricklets: make([]DeviceInfo, 0)}
+			bricks[uid] = br
+			order = append(order, br)
+			return br
+		}
+		for _, di := range devices {
+			if !di.IsBricklet() {
+				brickOf(di.Uid).DeviceInfo = di
+			}
+		}
+		for _, di := range devices {
+			if di.IsBricklet() {
+				br := brickOf(di.ConnectedUid)
+				br.Bricklets = append(br.Bricklets, di)
+			}
+		}
+		roots := make(map[uint32]*Stack)
+		for _, br := range order { // the bottom brick is connected to nothing or to a unknown brick
+			if _, ok := bricks[br.ConnectedUid]; !ok || br.ConnectedUid == br.Uid {
+				s := &Stack{Connector: n, Bricks: []*Brick{br}}
+				roots[br.Uid] = s
+				stacks = append(stacks, s)
+			}
+		}
+		for _, br := range order { // bricks in a stack are connected to the bottom brick
+			if s, ok := roots[br.ConnectedUid]; ok && br.ConnectedUid != br.Uid {
+				s.Bricks = append(s.Bricks, br)
+			}
+		}
+	}
+	return stacks
+}
+
+// Internal method: observe updates the registry with a enumerate callback.
+// The result is the device identifier of the enumerated device (also for a disconnected device).
+// Other events are ignored, for them the result is 0.
+func (b *Bricker) observe(e *event.Event) uint16 {
+	if e == nil || e.Err != nil || e.Packet == nil || e.Packet.Head == nil ||
+		e.Packet.Head.FunctionID != callback_enumerate || e.Packet.Head.Sequence() != 0 ||
+		e.Packet.Payload == nil {
+		return 0
+	}
+	en := &enumeration{}
+	if err := e.Packet.Payload.Decode(en); err != nil {
+		return 0
+	}
+	di := DeviceInfo{
+		Connector:        e.ConnectorName,
+		Uid:              uidOf(en.Uid),
+		ConnectedUid:     uidOf(en.ConnectedUid),
+		Position:         en.Position,
+		HardwareVersion:  en.HardwareVersion,
+		FirmwareVersion:  en.FirmwareVersion,
+		DeviceIdentifier: en.DeviceIdentifer}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.connection[di.Connector]; !ok { // connector is released
+		return di.DeviceIdentifier
+	}
+	if en.EnumerationType == enumeration_type_disconnected {
+		delete(b.registry[di.Connector], di.Uid)
+		b.unroute(di.Uid, di.Connector)
+		return di.DeviceIdentifier
+	}
+	if _, ok := b.registry[di.Connector]; !ok {
+		b.registry[di.Connector] = make(map[uint32]DeviceInfo)
+	}
+	b.registry[di.Connector][di.Uid] = di
+	b.uids[di.Uid] = di.Connector
+	return di.DeviceIdentifier
+}
+
+// Internal method: forget removes all devices of the named connector from the registry.
+// The caller has to hold the write lock.
+func (b *Bricker) forget(n string) {
+	for uid := range b.registry[n] {
+		b.unroute(uid, n)
+	}
+	delete(b.registry, n)
+}
+
+// Internal method: unroute removes the route of the device over the named connector.
+// If another connector knows the device, the device is routed over it.
+// The caller has to hold the write lock.
+func (b *Bricker) unroute(uid uint32, n string) {
+	if b.uids[uid] != n {
+		return
+	}
+	delete(b.uids, uid)
+	for _, m := range sortedKeys(b.registry) {
+		if _, ok := b.registry[m][uid]; ok && m != n {
+			b.uids[uid] = m
+			return
+		}
+	}
+}
+
+// Internal method: enumerate sends a enumerate request over the named connector.
+// All devices answer with a enumerate callback.
+func (b *Bricker) enumerate(n string) {
+	ev := event.NewPacket(packet.NewSimpleHeaderOnly(0, function_enumerate, true))
+	ev.ConnectorName = n
+	b.write(ev)
+}
+
+// Internal function: sortedKeys returns the sorted connector names of the registry.
+func sortedKeys(r map[string]map[uint32]DeviceInfo) []string {
+	names := make([]string, 0, len(r))
+	for n := range r {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Internal function: sortedDevices returns the devices sorted by uid.
+func sortedDevices(m map[uint32]DeviceInfo) []DeviceInfo {
+	devices := make([]DeviceInfo, 0, len(m))
+	for _, di := range m {
+		devices = append(devices, di)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Uid < devices[j].Uid })
+	return devices
+}
+
+// Internal function: uidOf converts a base58 uid (c style string) to a number.
+func uidOf(uid [8]byte) uint32 {
+	return base58.Convert32(base58.Decode(uid))
+}
+
+// Internal function: uidString converts a uid to a base58 string.
+func uidString(uid uint32) string {
+	s := base58.Encode(uint64(uid))
+	return string(bytes.TrimRight(s[:], "\x00"))
+}
@@ -0,0 +1,73 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/base58"
+	"github.com/dirkjabl/bricker/net/packet"
+	"testing"
+)
+
+func enumerateEvent(n string, uid, cuid uint32, position byte, id uint16, t uint8) *event.Event {
+	en := &enumeration{
+		Uid:             base58.Encode(uint64(uid)),
+		Position:        position,
+		DeviceIdentifer: id,
+		EnumerationType: t}
+	if cuid != 0 {
+		en.ConnectedUid = base58.Encode(uint64(cuid))
+	} else {
+		en.ConnectedUid[0] = '0'
+	}
+	ev := event.NewPacket(packet.NewSimpleHeaderPayload(uid, callback_enumerate, false, en))
+	ev.ConnectorName = n
+	return ev
+}
+
+func TestRegistry(t *testing.T) {
+	b := New()
+	defer b.Done()
+	for _, n := range []string{"first", "second"} {
+		v := virtual.New()
+		defer v.Done()
+		b.Attach(v, n)
+	}
+	b.dispatch(enumerateEvent("first", 100, 0, '0', 13, 0))    // master brick, bottom of the stack
+	b.dispatch(enumerateEvent("first", 101, 100, '1', 14, 0))  // servo brick in the stack
+	b.dispatch(enumerateEvent("first", 200, 100, 'a', 216, 1)) // temperature bricklet on master
+	b.dispatch(enumerateEvent("first", 201, 101, 'b', 21, 0))  // ambient light bricklet on servo
+	b.dispatch(enumerateEvent("second", 300, 0, '0', 13, 0))   // master brick of the second stack
+	if n := b.computeConnectorsName(uint32(300)); n != "second" {
+		t.Fatalf("Error TestRegistry: uid routed to connector %s, expected second.", n)
+	}
+	if di, err := b.Device(201); err != nil || di.ConnectedUid != 101 || !di.IsBricklet() {
+		t.Fatalf("Error TestRegistry: wrong device (%v).", di)
+	}
+	if devices := b.Devices(); len(devices) != 5 || devices[0].Uid != 100 || devices[4].Uid != 300 {
+		t.Fatalf("Error TestRegistry: wrong devices (%v).", devices)
+	}
+	stacks := b.Topology()
+	if len(stacks) != 2 || stacks[0].Connector != "first" || stacks[1].Connector != "second" {
+		t.Fatalf("Error TestRegistry: wrong stacks (%d).", len(stacks))
+	}
+	bricks := stacks[0].Bricks
+	if len(bricks) != 2 || bricks[0].Uid != 100 || bricks[1].Uid != 101 {
+		t.Fatalf("Error TestRegistry: wrong bricks in first stack (%d).", len(bricks))
+	}
+	if len(bricks[0].Bricklets) != 1 || bricks[0].Bricklets[0].Uid != 200 ||
+		len(bricks[1].Bricklets) != 1 || bricks[1].Bricklets[0].Uid != 201 {
+		t.Fatalf("Error TestRegistry: wrong bricklets in first stack.")
+	}
+	b.dispatch(enumerateEvent("first", 200, 100, 'a', 216, 2)) // disconnected
+	if _, err := b.Device(200); err == nil {
+		t.Fatalf("Error TestRegistry: disconnected device is still known.")
+	}
+	b.Release("second")
+	if _, err := b.Device(300); err == nil {
+		t.Fatalf("Error TestRegistry: device of released connector is still known.")
+	}
+}
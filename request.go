@@ -0,0 +1,98 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/util/hash"
+)
+
+// Internal type: request identifies an outstanding request.
+// The brick daemon answers a request with the same uid, function id and sequence number.
+type request struct {
+	connector string
+	uid       uint32
+	fid       uint8
+	seq       uint8
+}
+
+// Internal type: pendingKey identifies a subscriber, which waits for a response.
+type pendingKey struct {
+	hash hash.Hash
+	id   string
+}
+
+// Internal method: requestOf computes the request, which would be answered by the event.
+// Only events with a packet and a sequence number could be a response.
+func requestOf(e *event.Event) (request, bool) {
+	if e == nil || e.Packet == nil || e.Packet.Head == nil || e.Packet.Head.Sequence() == 0 {
+		return request{}, false
+	}
+	h := e.Packet.Head
+	return request{connector: e.ConnectorName, uid: h.Uid, fid: h.FunctionID, seq: h.Sequence()}, true
+}
+
+// Internal method: isOutstanding checks, if the request waits for a response.
+func (b *Bricker) isOutstanding(r request) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	_, ok := b.outstanding[r]
+	return ok
+}
+
+// Internal method: newRequest registers the request of the subscriber as outstanding on the named connector.
+// It takes the next free sequence number of the connector.
+// The caller has to hold the write lock.
+func (b *Bricker) newRequest(n string, k pendingKey, s Subscriber) (request, error) {
+	seq, ok := b.sequences[n]
+	if !ok {
+		seq = new(connector.Sequence)
+		b.sequences[n] = seq
+	}
+	h := s.Subscription().Request.Head
+	for i := 0; i < 15; i++ { // there are only 15 sequence numbers
+		r := request{connector: n, uid: h.Uid, fid: h.FunctionID, seq: seq.GetSequence()}
+		if _, ok := b.outstanding[r]; !ok {
+			b.outstanding[r] = s
+			b.pending[k] = r
+			return r, nil
+		}
+	}
+	return request{}, NewError(ErrorNoFreeSequence)
+}
+
+// Internal method: removeRequest removes the outstanding request of a pending subscriber.
+// The caller has to hold the write lock.
+func (b *Bricker) removeRequest(k pendingKey) {
+	if r, ok := b.pending[k]; ok {
+		delete(b.outstanding, r)
+		delete(b.pending, k)
+		b.meter.forget(r)
+		b.throttle.done(r)
+		if len(b.outstanding) == 0 && b.drained != nil {
+			close(b.drained)
+			b.drained = nil
+		}
+	}
+}
+
+// Internal method: abandon notifies all subscriber, which wait for a response of the given connector,
+// that no response will come.
+func (b *Bricker) abandon(n string) {
+	b.lock.RLock()
+	subs := make([]Subscriber, 0)
+	for r, s := range b.outstanding {
+		if r.connector == n {
+			subs = append(subs, s)
+		}
+	}
+	b.lock.RUnlock()
+	for _, s := range subs {
+		ev := event.NewError(NewError(ErrorConnectorClosed))
+		ev.ConnectorName = n
+		go b.process(ev, s)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+)
+
+// Internal type: sessionEntry is a remembered request of a session.
+// The request of a callback subscription is remembered as long as the subscriber is subscribed,
+// a configuration request (without a subscriber) until the connector is released.
+// A request, which is taken over from a disconnected member of a group, is remembered until the member reconnects.
+type sessionEntry struct {
+	key    pendingKey // subscriber of a callback subscription (empty for a configuration request)
+	from   string     // disconnected member, whose request is taken over (empty for a own request)
+	packet *packet.Packet
+}
+
+// Internal method: record remembers the request of a subscription, which configures a device,
+// for the session of the named connector.
+// Only the last request of a subscriber (or the last configuration request) for a uid and function id
+// is remembered, it moves to the end of the session.
+// The caller has to hold the write lock.
+func (b *Bricker) record(n string, se sessionEntry) {
+	session := b.sessions[n]
+	h := se.packet.Head
+	for i, r := range session {
+		if r.key == se.key && r.from == se.from && r.packet.Head.Uid == h.Uid && r.packet.Head.FunctionID == h.FunctionID {
+			session = append(session[:i], session[i+1:]...)
+			break
+		}
+	}
+	se.packet = se.packet.Copy()
+	b.sessions[n] = append(session, se)
+}
+
+// Internal method: unrecord removes the requests of the subscriber from all sessions.
+// The caller has to hold the write lock.
+func (b *Bricker) unrecord(k pendingKey) {
+	for n, session := range b.sessions {
+		kept := session[:0]
+		for _, se := range session {
+			if se.key != k {
+				kept = append(kept, se)
+			}
+		}
+		b.sessions[n] = kept
+	}
+}
+
+// Internal method: stateChanged handles the state changes of a connector.
+// The state subscriber are notified about the change.
+// After a disconnect all pending subscriber of the connector are notified and,
+// if the connector could not reconnect, all closers are closed.
+// The session of a member of a group is sent over the next available member.
+// After a reconnect the session will be restored (and the member gives the taken over session back).
+func (b *Bricker) stateChanged(n string, s connector.State, err error) {
+	b.statelock.Lock()
+	b.lock.Lock()
+	if _, ok := b.connection[n]; !ok { // connector is released
+		b.lock.Unlock()
+		b.statelock.Unlock()
+		b.abandon(n)
+		return
+	}
+	ci, subs := b.setState(n, s, err)
+	var takeover []*event.Event
+	orphaned := ""
+	if s == connector.StateDisconnected { // the devices are unknown, until the next enumeration
+		b.forget(n)
+		takeover = b.takeover(n, false)
+		orphaned = b.orphaned(n)
+	} else if s == connector.StateReconnected { // the connector restores its own session
+		b.giveback(n)
+	}
+	gone := s == connector.StateDisconnected && !b.canReconnect(n)
+	b.lock.Unlock()
+	notifyState(ci, subs)
+	b.statelock.Unlock()
+	switch s {
+	case connector.StateDisconnected:
+		b.abandon(n)
+		if gone {
+			b.closeSubscriber(n)
+			if orphaned != "" {
+				b.closeSubscriber(orphaned)
+			}
+		}
+		for _, ev := range takeover {
+			b.write(ev)
+		}
+	case connector.StateReconnected:
+		b.restore(n)
+	}
+}
+
+// Internal method: restore sends a enumerate and all remembered requests of the session
+// of the named connector again.
+// The requests are sent without expecting a response, they only configure the devices.
+func (b *Bricker) restore(n string) {
+	b.enumerate(n)
+	b.lock.RLock()
+	requests := make([]*packet.Packet, 0, len(b.sessions[n]))
+	for _, se := range b.sessions[n] {
+		p := se.packet.Copy()
+		p.Head.SequenceAndOptions = 0 // new sequence from the connector, no response expected
+		requests = append(requests, p)
+	}
+	b.lock.RUnlock()
+	for _, p := range requests {
+		ev := event.NewPacket(p)
+		ev.ConnectorName = n
+		b.write(ev)
+	}
+}
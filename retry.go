@@ -0,0 +1,99 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"time"
+)
+
+/*
+RetryPolicy describes, how a idempotent request (see subscription.Subscription) is sent again,
+if no response comes in time. Other requests (e.g. setters or a beep) are never sent again,
+a request could be allowed for a retry with the Idempotent flag of its subscription.
+
+Every attempt waits Timeout for the response. Without a response, the next attempt starts after the backoff.
+The first backoff is Backoff, every next backoff is Factor times longer, but not longer than MaxBackoff.
+After the last attempt without a response the subscriber is notified with an error (ErrorNoResponse).
+A policy without a timeout is disabled (default).
+*/
+type RetryPolicy struct {
+	Attempts   int           // maximal number of attempts with the first one (less than 1 means 1)
+	Timeout    time.Duration // waiting time for the response of an attempt
+	Backoff    time.Duration // waiting time before the second attempt
+	MaxBackoff time.Duration // maximal waiting time before an attempt (0 for no maximum)
+	Factor     float64       // factor for the next waiting time (less than 1 means 1)
+}
+
+// DefaultRetryPolicy tries a request three times, every attempt waits a second for the response.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:   3,
+	Timeout:    time.Second,
+	Backoff:    100 * time.Millisecond,
+	MaxBackoff: time.Second,
+	Factor:     2}
+
+// Delay computes the backoff before the given attempt (starting with 2 for the second attempt).
+func (rp RetryPolicy) Delay(attempt int) time.Duration {
+	d := float64(rp.Backoff)
+	for i := 2; i < attempt && rp.Factor > 1 && (rp.MaxBackoff <= 0 || d < float64(rp.MaxBackoff)); i++ {
+		d *= rp.Factor
+	}
+	if rp.MaxBackoff > 0 && d > float64(rp.MaxBackoff) {
+		d = float64(rp.MaxBackoff)
+	}
+	return time.Duration(d)
+}
+
+// Enabled checks, if the policy is used.
+func (rp RetryPolicy) Enabled() bool {
+	return rp.Timeout > 0
+}
+
+// Retry sets the retry policy for the idempotent requests (default no retry, see RetryPolicy).
+func Retry(rp RetryPolicy) Option {
+	return func(b *Bricker) {
+		b.retry = rp
+	}
+}
+
+// Internal method: watch waits for the response of the request of the pending subscriber.
+// Without a response in the timeout, the request is sent again or
+// after the last attempt the subscriber is notified with an error.
+func (b *Bricker) watch(k pendingKey, r request, e *event.Event, attempt int) {
+	time.AfterFunc(b.retry.Timeout, func() {
+		s, ok := b.waiting(k, r)
+		if !ok {
+			return // answered or unsubscribed
+		}
+		if attempt >= b.retry.Attempts {
+			b.meter.timedOut(r.connector)
+			ev := event.NewError(NewError(ErrorNoResponse))
+			ev.ConnectorName = r.connector
+			b.process(ev, s)
+			return
+		}
+		time.Sleep(b.retry.Delay(attempt + 1))
+		if _, ok := b.waiting(k, r); !ok {
+			return
+		}
+		b.meter.retried(r.connector)
+		ev := event.NewPacket(e.Packet.Copy())
+		ev.ConnectorName = e.ConnectorName
+		b.write(ev)
+		b.watch(k, r, e, attempt+1)
+	})
+}
+
+// Internal method: waiting checks, if the pending subscriber waits still for the response of the request.
+func (b *Bricker) waiting(k pendingKey, r request) (Subscriber, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if b.pending[k] != r {
+		return nil, false
+	}
+	s, ok := b.outstanding[r]
+	return s, ok
+}
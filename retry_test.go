@@ -0,0 +1,102 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{Attempts: 3, Timeout: 20 * time.Millisecond, Backoff: time.Millisecond, Factor: 2}
+
+// Internal function: lostGenerator answers the requests for uid 1 only after lost requests.
+// All requests are counted.
+func lostGenerator(requests *int32, lost int32) virtual.GeneratorFunc {
+	return deviceGenerator(1, func(e *event.Event) *event.Event {
+		if atomic.AddInt32(requests, 1) <= lost {
+			return nil // request is lost
+		}
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, 2, false))
+	})
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	rp := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Factor: 2}
+	for attempt, d := range map[int]time.Duration{2: 100 * time.Millisecond, 3: 200 * time.Millisecond,
+		4: 300 * time.Millisecond, 10: 300 * time.Millisecond} {
+		if rp.Delay(attempt) != d {
+			t.Fatalf("Error TestRetryPolicyDelay: attempt %d waits %s, expected %s.", attempt, rp.Delay(attempt), d)
+		}
+	}
+	if (RetryPolicy{}).Enabled() || !DefaultRetryPolicy.Enabled() {
+		t.Fatalf("Error TestRetryPolicyDelay: wrong enabled policies.")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	requests := new(int32)
+	b, _ := newTestBricker(t, lostGenerator(requests, 2), Retry(testRetryPolicy))
+	defer b.Done()
+	s := newTestSubscriber("idempotent", 1, 2, true, false)
+	s.sub.Idempotent = true
+	b.Subscribe(s, "virtual")
+	select {
+	case e := <-s.notified:
+		if e.Err != nil {
+			t.Fatalf("Error TestRetry: unexpected error (%s).", e.Err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestRetry: no response.")
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Fatalf("Error TestRetry: %d attempts, expected 3.", n)
+	}
+	if m := b.Metrics(); m.Connectors[0].Retries != 2 {
+		t.Fatalf("Error TestRetry: wrong retry counter (%d).", m.Connectors[0].Retries)
+	}
+}
+
+func TestRetryNoResponse(t *testing.T) {
+	requests := new(int32)
+	b, _ := newTestBricker(t, lostGenerator(requests, 10), Retry(testRetryPolicy))
+	defer b.Done()
+	s := newTestSubscriber("idempotent", 1, 2, true, false)
+	s.sub.Idempotent = true
+	b.Subscribe(s, "virtual")
+	select {
+	case e := <-s.notified:
+		if err, ok := e.Err.(Error); !ok || err.Code != ErrorNoResponse {
+			t.Fatalf("Error TestRetryNoResponse: wrong error (%v).", e.Err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestRetryNoResponse: no error after all attempts.")
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Fatalf("Error TestRetryNoResponse: %d attempts, expected 3.", n)
+	}
+	if m := b.Metrics(); m.Connectors[0].Timeouts != 1 || m.Connectors[0].Pending != 0 {
+		t.Fatalf("Error TestRetryNoResponse: wrong metrics (%v).", m.Connectors[0])
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	requests := new(int32)
+	b, _ := newTestBricker(t, lostGenerator(requests, 1), Retry(testRetryPolicy))
+	defer b.Done()
+	s := newTestSubscriber("setter", 1, 2, true, false)
+	b.Subscribe(s, "virtual")
+	select {
+	case e := <-s.notified:
+		t.Fatalf("Error TestNoRetry: request is retried (%v).", e)
+	case <-time.After(5 * testRetryPolicy.Timeout):
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Fatalf("Error TestNoRetry: %d attempts, expected 1.", n)
+	}
+}
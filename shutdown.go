@@ -0,0 +1,225 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"fmt"
+	"github.com/dirkjabl/bricker/connector"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/subscription"
+	"github.com/dirkjabl/bricker/util/hash"
+	"sort"
+)
+
+// ShutdownOption configures a shutdown (see Shutdown).
+type ShutdownOption func(sc *shutdownConfig)
+
+// Internal type: shutdownConfig holds the options of a shutdown.
+type shutdownConfig struct {
+	disable bool
+}
+
+// DisableCallbacks disables the callbacks, which are enabled by subscriptions of the bricker,
+// before the connectors are closed. Only requests of subscriptions with the Disable flag
+// (e.g. the callback periods) are sent again with a zero payload.
+// These requests expect a response, the shutdown waits for them like for all outstanding requests.
+func DisableCallbacks() ShutdownOption {
+	return func(sc *shutdownConfig) {
+		sc.disable = true
+	}
+}
+
+// ShutdownReport describes, what a shutdown has done and what it has abandoned.
+type ShutdownReport struct {
+	Disabled   int      // requests sent to disable callbacks
+	Abandoned  []string // ids of the subscriber, which got no response until the deadline ("disable <connector> <uid>/<function id>" for a disabling request)
+	Packets    int      // outgoing packets, which waited in the flow control at the deadline (not sent)
+	Deliveries int      // deliveries of incoming events, which still ran at the deadline
+	Connectors []string // names of the closed connectors
+}
+
+/*
+Shutdown stops the bricker gracefully.
+
+First the bricker takes no new subscriber (Subscribe and Attach return ErrorShutdown).
+With the option DisableCallbacks, the callbacks enabled by the bricker are disabled.
+Then Shutdown waits for the responses of all outstanding requests and for the sending of all packets,
+which wait in the flow control, until the context is done.
+The subscriber, which got no response until then, are notified with an error (ErrorShutdown),
+so a waiting future returns. At last all subscriber are unsubscribed, all connectors are released
+and closed (Done of the connector) and Shutdown waits for the running deliveries of incoming events.
+
+The report lists all abandoned requests, packets and deliveries.
+If something is abandoned, the error is the error of the context.
+A second Shutdown returns ErrorShutdown.
+*/
+func (b *Bricker) Shutdown(ctx context.Context, opts ...ShutdownOption) (ShutdownReport, error) {
+	var sc shutdownConfig
+	for _, opt := range opts {
+		opt(&sc)
+	}
+	report := ShutdownReport{
+		Abandoned:  make([]string, 0),
+		Connectors: make([]string, 0)}
+	b.lock.Lock()
+	if b.closing {
+		b.lock.Unlock()
+		return report, NewError(ErrorShutdown)
+	}
+	b.closing = true
+	b.lock.Unlock()
+	if sc.disable {
+		report.Disabled = b.disableCallbacks()
+	}
+	b.settle(ctx)
+	report.Packets = b.throttle.drain(ctx)
+	report.Abandoned = b.abandonAll()
+	for _, s := range b.subscribers() {
+		b.Unsubscribe(s)
+	}
+	conns := b.connections()
+	for _, n := range sortedNames(conns) {
+		if b.Release(n) == nil {
+			conns[n].Done()
+			report.Connectors = append(report.Connectors, n)
+		}
+	}
+	report.Deliveries = b.dispatcher.wait(ctx)
+	if report.Packets > 0 || len(report.Abandoned) > 0 || report.Deliveries > 0 {
+		return report, ctx.Err()
+	}
+	return report, nil
+}
+
+// Internal type: disableSubscriber waits for the response of a request, which disables callbacks.
+type disableSubscriber struct {
+	id  string
+	sub *subscription.Subscription
+}
+
+// Id returns the id of the subscriber (fullfill the Subscriber interface).
+func (ds *disableSubscriber) Id() string {
+	return ds.id
+}
+
+// Subscription returns the subscription (fullfill the Subscriber interface).
+func (ds *disableSubscriber) Subscription() *subscription.Subscription {
+	return ds.sub
+}
+
+// Notify does nothing, the response is only awaited (fullfill the Subscriber interface).
+func (ds *disableSubscriber) Notify(e *event.Event) {}
+
+// Internal method: recordDisable remembers the request of a subscription, which enables callbacks,
+// as request with a zero payload, which disables them again.
+// Only the last request for a uid and function id is remembered.
+// The caller has to hold the write lock.
+func (b *Bricker) recordDisable(n string, p *packet.Packet) {
+	p = p.Copy()
+	p.Head.SequenceAndOptions = 0 // new sequence from the bricker
+	p.Head.SetOptionResponseExpected(true)
+	if p.Payload != nil {
+		for i := range *p.Payload {
+			(*p.Payload)[i] = 0
+		}
+	}
+	disablers := b.disablers[n]
+	for i, r := range disablers {
+		if r.Head.Uid == p.Head.Uid && r.Head.FunctionID == p.Head.FunctionID {
+			disablers[i] = p
+			return
+		}
+	}
+	b.disablers[n] = append(disablers, p)
+}
+
+// Internal method: disableCallbacks subscribes all remembered requests, which disable callbacks,
+// so the shutdown waits for their responses (a connector could drop a packet, which is not sent at its close).
+// The result is the number of the sent requests.
+func (b *Bricker) disableCallbacks() int {
+	b.lock.RLock()
+	names := make([]string, 0)
+	subs := make([]Subscriber, 0)
+	for n, disablers := range b.disablers {
+		for _, p := range disablers {
+			names = append(names, n)
+			subs = append(subs, &disableSubscriber{
+				id:  fmt.Sprintf("disable %s %s/%d", n, uidString(p.Head.Uid), p.Head.FunctionID),
+				sub: subscription.New(hash.ChoosenFunctionIDUid, p.Head.Uid, p.Head.FunctionID, p.Copy(), false)})
+		}
+	}
+	b.lock.RUnlock()
+	count := 0
+	for i, s := range subs {
+		if b.subscribe(s, names[i], true) == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Internal method: settle waits, until no request waits for a response or the context is done.
+func (b *Bricker) settle(ctx context.Context) {
+	for {
+		b.lock.Lock()
+		if len(b.outstanding) == 0 {
+			b.lock.Unlock()
+			return
+		}
+		if b.drained == nil {
+			b.drained = make(chan struct{})
+		}
+		drained := b.drained
+		b.lock.Unlock()
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Internal method: abandonAll notifies all subscriber, which wait for a response, with an error (ErrorShutdown).
+// The result are the ids of the notified subscriber.
+func (b *Bricker) abandonAll() []string {
+	b.lock.RLock()
+	pending := make(map[request]Subscriber, len(b.outstanding))
+	for r, s := range b.outstanding {
+		pending[r] = s
+	}
+	b.lock.RUnlock()
+	ids := make([]string, 0, len(pending))
+	for r, s := range pending {
+		ev := event.NewError(NewError(ErrorShutdown))
+		ev.ConnectorName = r.connector
+		b.process(ev, s)
+		ids = append(ids, s.Id())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Internal method: connections returns a snapshot of all attached connectors.
+func (b *Bricker) connections() map[string]connector.Connector {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	conns := make(map[string]connector.Connector, len(b.connection))
+	for n, c := range b.connection {
+		conns[n] = c
+	}
+	return conns
+}
+
+// Internal function: sortedNames returns the sorted names of the connectors.
+func sortedNames(conns map[string]connector.Connector) []string {
+	names := make([]string, 0, len(conns))
+	for n := range conns {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
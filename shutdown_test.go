@@ -0,0 +1,189 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"context"
+	"github.com/dirkjabl/bricker/connector/buffered"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		time.Sleep(20 * time.Millisecond) // slow response
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, 2, false))
+	}))
+	s := newTestSubscriber("slow", 1, 2, true, false)
+	b.Subscribe(s, "virtual")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	report, err := b.Shutdown(ctx)
+	if err != nil {
+		t.Fatalf("Error TestShutdown: unexpected error (%s).", err.Error())
+	}
+	select {
+	case e := <-s.notified:
+		if e.Err != nil {
+			t.Fatalf("Error TestShutdown: response with error (%s).", e.Err.Error())
+		}
+	default:
+		t.Fatalf("Error TestShutdown: response is not awaited.")
+	}
+	if len(report.Abandoned) != 0 || len(report.Connectors) != 1 || report.Connectors[0] != "virtual" {
+		t.Fatalf("Error TestShutdown: wrong report %+v.", report)
+	}
+	if len(b.Connectors()) != 0 {
+		t.Fatalf("Error TestShutdown: connectors are not released.")
+	}
+	if err := b.Subscribe(newTestSubscriber("late", 1, 2, true, false), "virtual"); err == nil ||
+		err.(Error).Code != ErrorShutdown {
+		t.Fatalf("Error TestShutdown: subscribe after the shutdown (%v).", err)
+	}
+	if _, err := b.Shutdown(ctx); err == nil || err.(Error).Code != ErrorShutdown {
+		t.Fatalf("Error TestShutdown: second shutdown (%v).", err)
+	}
+}
+
+func TestShutdownAbandon(t *testing.T) {
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		return nil // lost request
+	}))
+	s := newTestSubscriber("lost", 1, 2, true, false)
+	b.Subscribe(s, "virtual")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	report, err := b.Shutdown(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Error TestShutdownAbandon: wrong error (%v).", err)
+	}
+	if len(report.Abandoned) != 1 || report.Abandoned[0] != "lost" {
+		t.Fatalf("Error TestShutdownAbandon: wrong report %+v.", report)
+	}
+	select {
+	case e := <-s.notified:
+		if err, ok := e.Err.(Error); !ok || err.Code != ErrorShutdown {
+			t.Fatalf("Error TestShutdownAbandon: wrong error (%v).", e.Err)
+		}
+	default:
+		t.Fatalf("Error TestShutdownAbandon: abandoned subscriber is not notified.")
+	}
+}
+
+// disabledPacket checks, if the packet is a request, which disables the callback period of uid 1.
+func disabledPacket(p *packet.Packet) bool {
+	var v uint32
+	return p.Head.Uid == 1 && p.Head.FunctionID == 2 && p.Payload != nil && p.Payload.Decode(&v) == nil && v == 0
+}
+
+func TestShutdownDisableCallbacks(t *testing.T) {
+	disabled := make(chan *packet.Packet, 2)
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		if disabledPacket(e.Packet) {
+			disabled <- e.Packet.Copy()
+		}
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, 2, false))
+	}))
+	s := newTestSubscriber("period", 1, 2, true, false)
+	s.sub.Request = packet.NewSimpleHeaderPayload(1, 2, true, uint32(100))
+	s.sub.Disable = true
+	b.Subscribe(s, "virtual")
+	<-s.notified
+	report, err := b.Shutdown(context.Background(), DisableCallbacks())
+	if err != nil || report.Disabled != 1 {
+		t.Fatalf("Error TestShutdownDisableCallbacks: wrong report %+v (%v).", report, err)
+	}
+	select {
+	case p := <-disabled:
+		if !p.Head.OptionResponseExpected() {
+			t.Fatalf("Error TestShutdownDisableCallbacks: request to disable the callbacks expects no response.")
+		}
+	default:
+		t.Fatalf("Error TestShutdownDisableCallbacks: no request to disable the callbacks.")
+	}
+}
+
+func TestShutdownDisableAbandoned(t *testing.T) {
+	b, _ := newTestBricker(t, deviceGenerator(1, func(e *event.Event) *event.Event {
+		var v uint32
+		if e.Packet.Payload != nil && e.Packet.Payload.Decode(&v) == nil && v == 0 {
+			return nil // request to disable the callbacks is lost
+		}
+		return event.NewPacket(packet.NewSimpleHeaderOnly(1, e.Packet.Head.FunctionID, false))
+	}))
+	for _, fid := range []uint8{2, 3} {
+		s := newTestSubscriber("period", 1, fid, true, false)
+		s.sub.Request = packet.NewSimpleHeaderPayload(1, fid, true, uint32(100))
+		s.sub.Disable = true
+		b.Subscribe(s, "virtual")
+		<-s.notified
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	report, err := b.Shutdown(ctx, DisableCallbacks())
+	if err == nil || report.Disabled != 2 {
+		t.Fatalf("Error TestShutdownDisableAbandoned: wrong report %+v (%v).", report, err)
+	}
+	uid := uidString(1)
+	if len(report.Abandoned) != 2 || report.Abandoned[0] != "disable virtual "+uid+"/2" ||
+		report.Abandoned[1] != "disable virtual "+uid+"/3" {
+		t.Fatalf("Error TestShutdownDisableAbandoned: wrong abandoned requests %v.", report.Abandoned)
+	}
+}
+
+func TestShutdownDisableCallbacksStream(t *testing.T) {
+	client, server := net.Pipe()
+	disabled := make(chan *packet.Packet, 1)
+	go func() { // brick daemon, which answers all requests
+		for {
+			p, err := packet.ReadNew(server)
+			if err != nil {
+				return // closed with the connector
+			}
+			if !p.Head.OptionResponseExpected() {
+				continue
+			}
+			if disabledPacket(p) {
+				disabled <- p
+			}
+			r := packet.NewSimpleHeaderOnly(p.Head.Uid, p.Head.FunctionID, false)
+			r.Head.SetSequence(p.Head.Sequence())
+			if r.Write(server) != nil {
+				return
+			}
+		}
+	}()
+	cb, err := buffered.NewStream("pipe", client, 10, 10)
+	if err != nil {
+		t.Fatalf("Error TestShutdownDisableCallbacksStream: could not create connector (%s).", err.Error())
+	}
+	b := New()
+	if err := b.Attach(cb, "stream"); err != nil {
+		t.Fatalf("Error TestShutdownDisableCallbacksStream: could not attach connector (%s).", err.Error())
+	}
+	s := newTestSubscriber("period", 1, 2, true, false)
+	s.sub.Request = packet.NewSimpleHeaderPayload(1, 2, true, uint32(100))
+	s.sub.Disable = true
+	b.Subscribe(s, "stream")
+	select {
+	case <-s.notified:
+	case <-time.After(time.Second):
+		t.Fatalf("Error TestShutdownDisableCallbacksStream: no response.")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	report, err := b.Shutdown(ctx, DisableCallbacks())
+	if err != nil || report.Disabled != 1 {
+		t.Fatalf("Error TestShutdownDisableCallbacksStream: wrong report %+v (%v).", report, err)
+	}
+	select {
+	case <-disabled:
+	default:
+		t.Fatalf("Error TestShutdownDisableCallbacksStream: request to disable the callbacks is not sent before the close.")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bricker
+
+import (
+	"fmt"
+	"github.com/dirkjabl/bricker/connector"
+	"sort"
+)
+
+// ConnectorInfo describes a attached connector and his actual state.
+type ConnectorInfo struct {
+	Name    string          // name of the connector in the bricker
+	Address string          // address of the hardware, if the connector knows it (connector.Addresser)
+	State   connector.State // actual state
+	Err     error           // cause of the last disconnect, if known
+}
+
+// String fullfill the stringer interface.
+func (ci ConnectorInfo) String() string {
+	txt := fmt.Sprintf("Connector [Name: %s, Address: %s, State: %s", ci.Name, ci.Address, ci.State)
+	if ci.Err != nil {
+		txt += ", Error: " + ci.Err.Error()
+	}
+	return txt + "]"
+}
+
+// Interface for all subscriber of connector state changes.
+// A state subscriber is notified on every state change of every connector
+// (connected, disconnected, reconnected and released).
+// The notifies come in order of the state changes, so NotifyState should not block.
+type StateSubscriber interface {
+	Id() string
+	NotifyState(ConnectorInfo)
+}
+
+// SubscribeState register a state subscriber.
+func (b *Bricker) SubscribeState(s StateSubscriber) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.statesubscriber[s.Id()]; ok {
+		return NewError(ErrorSubscriberExists)
+	}
+	b.statesubscriber[s.Id()] = s
+	return nil
+}
+
+// UnsubscribeState release a registered state subscriber.
+func (b *Bricker) UnsubscribeState(s StateSubscriber) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.statesubscriber[s.Id()]; !ok {
+		return NewError(ErrorNoSubscriberToRelease)
+	}
+	delete(b.statesubscriber, s.Id())
+	return nil
+}
+
+// Connectors returns the informations about all attached connectors, sorted by name.
+func (b *Bricker) Connectors() []ConnectorInfo {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	names := make([]string, 0, len(b.connection))
+	for n := range b.connection {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	infos := make([]ConnectorInfo, 0, len(names))
+	for _, n := range names {
+		infos = append(infos, b.info(n))
+	}
+	return infos
+}
+
+// Connector returns the informations about the named connector.
+func (b *Bricker) Connector(n string) (ConnectorInfo, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if _, ok := b.connection[n]; !ok {
+		return ConnectorInfo{}, NewError(ErrorConnectorNameNotExists)
+	}
+	return b.info(n), nil
+}
+
+// Internal method: info creates the information about the named connector.
+// The caller has to hold the read lock.
+func (b *Bricker) info(n string) ConnectorInfo {
+	ci := b.states[n]
+	ci.Name = n
+	if a, ok := b.connection[n].(connector.Addresser); ok {
+		ci.Address = a.Address()
+	}
+	return ci
+}
+
+// Internal method: setState stores the new state of the named connector and
+// returns the information and all state subscriber to notify.
+// The caller has to hold the write lock.
+func (b *Bricker) setState(n string, s connector.State, err error) (ConnectorInfo, []StateSubscriber) {
+	b.states[n] = ConnectorInfo{State: s, Err: err}
+	ci := b.info(n)
+	ci.State = s // a released connector is no more in the connection table
+	subs := make([]StateSubscriber, 0, len(b.statesubscriber))
+	for _, sub := range b.statesubscriber {
+		subs = append(subs, sub)
+	}
+	return ci, subs
+}
+
+// Internal function: notifyState notifies the state subscriber.
+func notifyState(ci ConnectorInfo, subs []StateSubscriber) {
+	for _, s := range subs {
+		s.NotifyState(ci)
+	}
+}
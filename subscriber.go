@@ -6,6 +6,7 @@ package bricker
 
 import (
 	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
 	"github.com/dirkjabl/bricker/subscription"
 )
 
@@ -20,58 +21,139 @@ type Subscriber interface {
 
 // Subscriber register a subscriber. Internaly it use the subscription of the subscriber.
 func (b *Bricker) Subscribe(s Subscriber, dest interface{}) error {
+	return b.subscribe(s, dest, false)
+}
+
+// Internal method: subscribe registers the subscriber, during the shutdown only with shutdown.
+func (b *Bricker) subscribe(s Subscriber, dest interface{}, shutdown bool) error {
 	hash := s.Subscription().Hash()
-	if v, ok := b.subscriber[hash]; ok {
-		if _, ok := v[s.Id()]; ok {
-			return NewError(ErrorSubscriberExists)
+	name := b.computeConnectorsName(dest)
+	b.lock.Lock()
+	if b.closing && !shutdown {
+		b.lock.Unlock()
+		return NewError(ErrorShutdown)
+	}
+	if _, ok := b.subscriber[hash][s.Id()]; ok {
+		b.lock.Unlock()
+		return NewError(ErrorSubscriberExists)
+	}
+	var p *packet.Packet
+	var r request
+	watch := false
+	if s.Subscription().Request != nil { // the request packet of the subscription is not changed
+		p = s.Subscription().Request.Copy()
+		if !s.Subscription().Callback { // waits for a response
+			var err error
+			r, err = b.newRequest(name, pendingKey{hash, s.Id()}, s)
+			if err != nil {
+				b.lock.Unlock()
+				return err
+			}
+			p.Head.SetSequence(r.seq)
+			watch = s.Subscription().Idempotent && b.retry.Enabled()
+		}
+		if s.Subscription().Callback { // remembered as long as the subscriber is subscribed
+			b.record(name, sessionEntry{key: pendingKey{hash, s.Id()}, packet: s.Subscription().Request})
+		} else if s.Subscription().Restore {
+			b.record(name, sessionEntry{packet: s.Subscription().Request})
+		}
+		if s.Subscription().Disable {
+			b.recordDisable(name, s.Subscription().Request)
 		}
+	}
+	if v, ok := b.subscriber[hash]; ok {
 		v[s.Id()] = s
 	} else {
 		b.subscriber[hash] = map[string]Subscriber{s.Id(): s}
 	}
+	if _, ok := s.(Closer); ok {
+		if f := s.Subscription().Filter; f == nil {
+			b.closers[pendingKey{hash, s.Id()}] = b.closerOf(name)
+		} else if f.Connector != "" { // a filter without a connector follows all connectors
+			b.closers[pendingKey{hash, s.Id()}] = b.closerOf(f.Connector)
+		}
+	}
 	b.insertChooser(s.Subscription().Choosen)
-	if s.Subscription().Request != nil { // only send a event, if a packet is given
-		ev := event.NewPacket(s.Subscription().Request)
-		ev.ConnectorName = b.computeConnectorsName(dest)
+	b.lock.Unlock()
+	if p != nil { // only send a event, if a packet is given
+		ev := event.NewPacket(p)
+		ev.ConnectorName = name
+		if watch { // the connector could change the sent packet, the retries use a copy
+			retry := event.NewPacket(p.Copy())
+			retry.ConnectorName = name
+			b.watch(pendingKey{hash, s.Id()}, r, retry, 1)
+		}
 		go b.write(ev)
 	}
 	return nil
 }
 
+// Internal method: closerOf gives the name, with which a subscriber sent over the named connector is closed:
+// the group, if the connector is a member of a group (also for a subscriber addressed by uid or member name),
+// otherwise the connector.
+// The caller has to hold the read lock.
+func (b *Bricker) closerOf(n string) string {
+	if g, ok := b.grouped[n]; ok {
+		return g.name
+	}
+	return n
+}
+
 // Unsubscribe release a registered subscriber identified with the subscription.
 func (b *Bricker) Unsubscribe(s Subscriber) error {
-	hash := s.Subscription().Hash()
-	subs, ok := b.subscriber[hash]
-	if !ok {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.unsubscribe(pendingKey{s.Subscription().Hash(), s.Id()}) {
 		return NewError(ErrorNoSubscriberToRelease)
 	}
-	_, ok = subs[s.Id()]
-	if ok {
-		delete(subs, s.Id())
-	} else {
-		return NewError(ErrorNoSubscriberToRelease)
+	return nil
+}
+
+// Internal method: unsubscribe removes the subscriber with the hash and id from the routing tables.
+// The result is false, if no such subscriber exists.
+// The caller has to hold the write lock.
+func (b *Bricker) unsubscribe(k pendingKey) bool {
+	subs, ok := b.subscriber[k.hash]
+	if !ok {
+		return false
 	}
+	if _, ok := subs[k.id]; !ok {
+		return false
+	}
+	delete(subs, k.id)
+	delete(b.closers, k)
+	b.unrecord(k)
+	b.removeRequest(k)
 	if len(subs) == 0 { // delete empty map
-		delete(b.subscriber, hash)
+		delete(b.subscriber, k.hash)
 	}
-	return nil
+	return true
 }
 
 // SubscribeDefaultFallback register a (only one) default fallback subscriber.
 // If already a default fallback subscriber is set, this subscriber would be relased.
 func (b *Bricker) SubscribeDefaultFallback(s Subscriber) {
-	if b.defaultsubscriber != nil {
-		b.UnsubscribeDefaultFallback()
-	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
 	b.defaultsubscriber = s
 }
 
 // UnsubscribeDefaultFallback relase a registered default fallback subscriber.
 func (b *Bricker) UnsubscribeDefaultFallback() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
 	b.defaultsubscriber = nil
 }
 
-// Internal method: insertChooser add a new chooser to the slice of chooser
+// Internal method: isDefaultFallback checks, if the given subscriber is the default fallback subscriber.
+func (b *Bricker) isDefaultFallback(s Subscriber) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.defaultsubscriber != nil && b.defaultsubscriber == s
+}
+
+// Internal method: insertChooser add a new chooser to the slice of chooser.
+// The caller has to hold the write lock.
 func (b *Bricker) insertChooser(n uint8) {
 	for _, v := range b.choosers {
 		if v == n {
@@ -0,0 +1,105 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package subscription
+
+import (
+	"fmt"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/util/hash"
+)
+
+// Class is the class of the events, which a filter accepts.
+type Class uint8
+
+// All classes of events.
+const (
+	ClassAll      Class = iota // all events
+	ClassCallback              // only callbacks (packets without a sequence number)
+	ClassResponse              // only responses (packets with a sequence number)
+)
+
+// String fullfill the stringer interface.
+func (c Class) String() string {
+	switch c {
+	case ClassAll:
+		return "All"
+	case ClassCallback:
+		return "Callback"
+	case ClassResponse:
+		return "Response"
+	default:
+		return "Unknown"
+	}
+}
+
+/*
+Filter narrows the events, which match the hash of a subscription.
+Only the set conditions are checked, an empty filter accepts every event.
+
+The device identifier (see device/name) is known for every device, which was enumerated by the bricker,
+so a filter with a device identifier matches also devices, which are connected later.
+Events of unknown devices do not match a device identifier.
+The predicate is called for every event, which matches all other conditions.
+It is called without a lock of the bricker, so it could use the bricker (e.g. Device).
+*/
+type Filter struct {
+	DeviceIdentifier uint16                    // only events of devices with this identifier (0 for all)
+	Connector        string                    // only events of the named connector ("" for all)
+	Class            Class                     // only events of this class
+	Predicate        func(e *event.Event) bool // only events, for which the predicate is true (nil for all)
+}
+
+// Match checks the event against the filter.
+// The device identifier is the identifier of the device, which has sent the event (0 if not known).
+func (f *Filter) Match(e *event.Event, deviceIdentifier uint16) bool {
+	if f == nil {
+		return true
+	}
+	if f.DeviceIdentifier != 0 && f.DeviceIdentifier != deviceIdentifier {
+		return false
+	}
+	if f.Connector != "" && f.Connector != e.ConnectorName {
+		return false
+	}
+	if f.Class != ClassAll {
+		if e.Packet == nil || e.Packet.Head == nil {
+			return false
+		}
+		if (e.Packet.Head.Sequence() == 0) != (f.Class == ClassCallback) {
+			return false
+		}
+	}
+	return f.Predicate == nil || f.Predicate(e)
+}
+
+// String fullfill the stringer interface.
+func (f *Filter) String() string {
+	if f == nil {
+		return "Filter [nil]"
+	}
+	return fmt.Sprintf("Filter [Device-Identifier: %d, Connector: %s, Class: %s, Predicate: %t]",
+		f.DeviceIdentifier, f.Connector, f.Class, f.Predicate != nil)
+}
+
+// NewClass creates a callback subscription for the function id of all devices with the device identifier
+// (e.g. all Temperature Bricklets), without a request.
+func NewClass(deviceIdentifier uint16, f uint8) *Subscription {
+	s := NewFid(f, nil, true)
+	s.Filter = &Filter{DeviceIdentifier: deviceIdentifier, Class: ClassCallback}
+	return s
+}
+
+// NewPredicate creates a callback subscription for all events, for which the predicate is true, without a request.
+func NewPredicate(p func(e *event.Event) bool) *Subscription {
+	s := New(hash.ChoosenNothing, 0, 0, nil, true)
+	s.Filter = &Filter{Predicate: p}
+	return s
+}
+
+// Accept checks, if the event matches the filter of the subscription.
+// A subscription without a filter accepts every event (the hash is checked by the bricker).
+func (s *Subscription) Accept(e *event.Event, deviceIdentifier uint16) bool {
+	return s.Filter.Match(e, deviceIdentifier)
+}
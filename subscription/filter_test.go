@@ -0,0 +1,73 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package subscription
+
+import (
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"strings"
+	"testing"
+)
+
+func testEvent(n string, seq uint8) *event.Event {
+	p := packet.NewSimpleHeaderOnly(1, 8, false)
+	if seq != 0 {
+		p.Head.SetSequence(seq)
+	}
+	e := event.NewPacket(p)
+	e.ConnectorName = n
+	return e
+}
+
+func TestFilterMatch(t *testing.T) {
+	for i, tc := range []struct {
+		f      *Filter
+		e      *event.Event
+		id     uint16
+		result bool
+	}{
+		{nil, testEvent("a", 0), 0, true},
+		{&Filter{}, testEvent("a", 3), 0, true},
+		{&Filter{DeviceIdentifier: 216}, testEvent("a", 0), 216, true},
+		{&Filter{DeviceIdentifier: 216}, testEvent("a", 0), 21, false},
+		{&Filter{DeviceIdentifier: 216}, testEvent("a", 0), 0, false},
+		{&Filter{Connector: "a"}, testEvent("a", 0), 0, true},
+		{&Filter{Connector: "a"}, testEvent("b", 0), 0, false},
+		{&Filter{Class: ClassCallback}, testEvent("a", 0), 0, true},
+		{&Filter{Class: ClassCallback}, testEvent("a", 3), 0, false},
+		{&Filter{Class: ClassResponse}, testEvent("a", 3), 0, true},
+		{&Filter{Class: ClassResponse}, event.NewError(nil), 0, false},
+		{&Filter{Predicate: func(e *event.Event) bool { return e.ConnectorName == "b" }}, testEvent("b", 0), 0, true},
+		{&Filter{Predicate: func(e *event.Event) bool { return false }}, testEvent("b", 0), 0, false},
+	} {
+		if r := tc.f.Match(tc.e, tc.id); r != tc.result {
+			t.Fatalf("Error TestFilterMatch: case %d (%s) is %t, expected %t.", i, tc.f, r, tc.result)
+		}
+	}
+}
+
+func TestNewClass(t *testing.T) {
+	s := NewClass(216, 8)
+	if s.Hash() != hash.New(hash.ChoosenFunctionID, 0, 8) || !s.Callback || s.Request != nil {
+		t.Fatalf("Error TestNewClass: wrong subscription (%s).", s)
+	}
+	if !s.Accept(testEvent("a", 0), 216) || s.Accept(testEvent("a", 0), 21) {
+		t.Fatalf("Error TestNewClass: wrong match (%s).", s)
+	}
+	if !strings.Contains(s.String(), "Device-Identifier: 216") {
+		t.Fatalf("Error TestNewClass: filter missing in string (%s).", s)
+	}
+}
+
+func TestNewPredicate(t *testing.T) {
+	s := NewPredicate(func(e *event.Event) bool { return e.ConnectorName == "a" })
+	if s.Hash() != hash.New(hash.ChoosenNothing, 0, 0) || !s.Callback {
+		t.Fatalf("Error TestNewPredicate: wrong subscription (%s).", s)
+	}
+	if !s.Accept(testEvent("a", 0), 0) || s.Accept(testEvent("b", 0), 0) {
+		t.Fatalf("Error TestNewPredicate: wrong match (%s).", s)
+	}
+}
@@ -25,6 +25,10 @@ type Subscription struct {
 	FunctionID uint8          // Value Function-ID
 	Request    *packet.Packet // ip packet
 	Callback   bool           // Is this subscription a callback (get more as one result) or not (one result)
+	Restore    bool           // Should the request be sent again, after the connector is reconnected
+	Idempotent bool           // Could the request be sent again without a side effect (retry without a response)
+	Disable    bool           // Does the request enable callbacks, which the request with a zero payload disables
+	Filter     *Filter        // Further conditions for matching (nil for none, see Filter)
 }
 
 // NewSubscription creates a new subscription with all informations.
@@ -68,6 +72,9 @@ func (s *Subscription) String() string {
 	} else {
 		t += fmt.Sprintf(", Request-Packet: nil")
 	}
+	if s.Filter != nil {
+		t += ", " + s.Filter.String()
+	}
 	t += "]"
 	return t
 }
@@ -0,0 +1,211 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Structured tracing of the packets of a bricker with log/slog.
+
+A tracer is a interceptor (see bricker.Intercept), it logs every packet with the direction,
+the connector, the uid (base58), the device name, the function name and the decoded payload.
+The brick and bricklet packages register their functions and payload types (Register),
+a function of a device without a registration is logged with its function id and the raw payload.
+
+	t := trace.New(brick, slog.Default())
+	brick.Intercept("trace", t.Intercept)
+	t.Enable("local")          // all packets of the connector
+	t.Enable(uint32(uid))      // all packets of the device
+	t.Disable(nil)             // nothing
+
+The tracing could be enabled and disabled at runtime, for all packets, per connector and per device.
+*/
+package trace
+
+import (
+	"bytes"
+	"context"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/base58"
+	"log/slog"
+	"strconv"
+	"sync"
+)
+
+// Function describes a function or callback of a device for the tracing.
+type Function struct {
+	Name     string             // name of the function (e.g. "GetTemperature")
+	Request  func() interface{} // creates the payload of a request (nil for a request without payload)
+	Response func() interface{} // creates the payload of a response or callback (nil for no payload)
+}
+
+// Functions of all devices (by device identifier).
+var (
+	lock      sync.RWMutex
+	functions = map[uint16]map[uint8]Function{
+		0: { // functions of all devices
+			253: {Name: "Enumerate"},
+			254: {Name: "Enumerate"},
+			255: {Name: "GetIdentity"}}}
+)
+
+// Register registers the functions of the devices with the device identifier (by function id).
+// A registration of the same function id again overwrites the older one.
+func Register(identifier uint16, fs map[uint8]Function) {
+	lock.Lock()
+	defer lock.Unlock()
+	m, ok := functions[identifier]
+	if !ok {
+		m = make(map[uint8]Function)
+		functions[identifier] = m
+	}
+	for fid, f := range fs {
+		m[fid] = f
+	}
+}
+
+// Lookup gives the registered function of the devices with the device identifier.
+// Functions of all devices (e.g. the enumerate) are found for every identifier.
+func Lookup(identifier uint16, fid uint8) (Function, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if f, ok := functions[identifier][fid]; ok {
+		return f, true
+	}
+	f, ok := functions[0][fid]
+	return f, ok
+}
+
+// Tracer logs the packets of a bricker.
+// A new tracer logs nothing, until it is enabled.
+type Tracer struct {
+	brick      *bricker.Bricker
+	logger     *slog.Logger
+	Level      slog.Level // level of the log records (default slog.LevelDebug)
+	lock       sync.RWMutex
+	all        bool
+	connectors map[string]bool
+	uids       map[uint32]bool
+}
+
+// New creates a tracer for the bricker, which logs to the logger.
+func New(brick *bricker.Bricker, logger *slog.Logger) *Tracer {
+	return &Tracer{
+		brick:      brick,
+		logger:     logger,
+		Level:      slog.LevelDebug,
+		connectors: make(map[string]bool),
+		uids:       make(map[uint32]bool)}
+}
+
+// Enable enables the tracing for a destination.
+// The destination could be a connector name (string), a uid of a device (uint32) or nil for all packets.
+func (t *Tracer) Enable(dest interface{}) {
+	t.set(dest, true)
+}
+
+// Disable disables the tracing for a destination (see Enable).
+// Disable with nil disables the tracing for all destinations.
+func (t *Tracer) Disable(dest interface{}) {
+	t.set(dest, false)
+}
+
+// Internal method: set enables or disables the destination.
+func (t *Tracer) set(dest interface{}, on bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	switch value := dest.(type) {
+	case string:
+		if on {
+			t.connectors[value] = true
+		} else {
+			delete(t.connectors, value)
+		}
+	case uint32:
+		if on {
+			t.uids[value] = true
+		} else {
+			delete(t.uids, value)
+		}
+	case nil:
+		t.all = on
+		if !on {
+			t.connectors = make(map[string]bool)
+			t.uids = make(map[uint32]bool)
+		}
+	}
+}
+
+// Enabled checks, if the tracing is enabled for the connector or the device.
+func (t *Tracer) Enabled(n string, uid uint32) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.all || t.connectors[n] || t.uids[uid]
+}
+
+// Intercept traces the event and passes it on (fullfill the bricker.Interceptor type).
+func (t *Tracer) Intercept(d bricker.Direction, e *event.Event, next func(*event.Event)) {
+	t.Trace(d, e)
+	next(e)
+}
+
+// Trace logs the event, if the tracing is enabled for its connector or device.
+func (t *Tracer) Trace(d bricker.Direction, e *event.Event) {
+	var uid uint32
+	if e.Packet != nil && e.Packet.Head != nil {
+		uid = e.Packet.Head.Uid
+	}
+	if !t.Enabled(e.ConnectorName, uid) || !t.logger.Enabled(context.Background(), t.Level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("direction", d.String()),
+		slog.String("connector", e.ConnectorName)}
+	if e.Packet != nil && e.Packet.Head != nil {
+		h := e.Packet.Head
+		var identifier uint16
+		device := "unknown device"
+		if di, err := t.brick.Device(uid); err == nil {
+			identifier = di.DeviceIdentifier
+			device = di.Name()
+		}
+		f, ok := Lookup(identifier, h.FunctionID)
+		name := f.Name
+		if !ok {
+			name = strconv.Itoa(int(h.FunctionID))
+		}
+		attrs = append(attrs,
+			slog.String("uid", uidString(uid)),
+			slog.String("device", device),
+			slog.String("function", name),
+			slog.Int("sequence", int(h.Sequence())))
+		if e.Packet.Payload != nil && len(*e.Packet.Payload) > 0 {
+			attrs = append(attrs, slog.Any("payload", decode(d, f, e)))
+		}
+	}
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	t.logger.LogAttrs(context.Background(), t.Level, "packet", attrs...)
+}
+
+// Internal function: decode decodes the payload of the event with the payload type of the function.
+// If this is not possible, the result is the raw payload.
+func decode(d bricker.Direction, f Function, e *event.Event) interface{} {
+	create := f.Response
+	if d == bricker.Outbound {
+		create = f.Request
+	}
+	if create != nil {
+		v := create()
+		if e.Packet.Payload.Decode(v) == nil {
+			return v
+		}
+	}
+	return e.Packet.Payload
+}
+
+// Internal function: uidString converts a uid to a base58 string.
+func uidString(uid uint32) string {
+	s := base58.Encode(uint64(uid))
+	return string(bytes.TrimRight(s[:], "\x00"))
+}
@@ -0,0 +1,142 @@
+// Copyright 2014 Dirk Jablonowski. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"bytes"
+	"github.com/dirkjabl/bricker"
+	"github.com/dirkjabl/bricker/connector/virtual"
+	"github.com/dirkjabl/bricker/event"
+	"github.com/dirkjabl/bricker/net/base58"
+	"github.com/dirkjabl/bricker/net/packet"
+	"github.com/dirkjabl/bricker/util/hash"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testIdentifier = uint16(60001)
+	testUid        = uint32(4711)
+)
+
+// testValue is the payload of the test functions.
+type testValue struct {
+	Value uint16
+}
+
+// testEnumeration is the payload of a enumerate callback.
+type testEnumeration struct {
+	Uid             [8]byte
+	ConnectedUid    [8]byte
+	Position        byte
+	HardwareVersion [3]uint8
+	FirmwareVersion [3]uint8
+	DeviceIdentifer uint16
+	EnumerationType uint8
+}
+
+func init() {
+	Register(testIdentifier, map[uint8]Function{
+		1: {Name: "GetValue", Response: func() interface{} { return new(testValue) }},
+		2: {Name: "SetValue", Request: func() interface{} { return new(testValue) }}})
+}
+
+// Internal function: newTestTracer creates a tracer for a bricker with a virtual connector,
+// which knows the test device.
+func newTestTracer(t *testing.T) (*Tracer, *bytes.Buffer) {
+	brick := bricker.New()
+	v := virtual.New()
+	v.AttachGenerator(hash.New(hash.ChoosenFunctionIDUid, 0, 254), func(e *event.Event) *event.Event {
+		en := &testEnumeration{Uid: base58.Encode(uint64(testUid)), Position: 'a', DeviceIdentifer: testIdentifier}
+		en.ConnectedUid[0] = '0'
+		return event.NewPacket(packet.NewSimpleHeaderPayload(0, 253, false, en))
+	})
+	if err := brick.Attach(v, "virtual"); err != nil {
+		t.Fatalf("Error %s: could not attach virtual connector (%s).", t.Name(), err.Error())
+	}
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		if _, err := brick.Device(testUid); err == nil {
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatalf("Error %s: test device not enumerated.", t.Name())
+		}
+	}
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return New(brick, logger), buf
+}
+
+func testEvent(n string, uid uint32, fid uint8, v interface{}) *event.Event {
+	var e *event.Event
+	if v == nil {
+		e = event.NewPacket(packet.NewSimpleHeaderOnly(uid, fid, true))
+	} else {
+		e = event.NewPacket(packet.NewSimpleHeaderPayload(uid, fid, true, v))
+	}
+	e.ConnectorName = n
+	return e
+}
+
+func TestLookup(t *testing.T) {
+	if f, ok := Lookup(testIdentifier, 1); !ok || f.Name != "GetValue" {
+		t.Fatalf("Error TestLookup: function not found (%v).", f)
+	}
+	if f, ok := Lookup(testIdentifier, 254); !ok || f.Name != "Enumerate" {
+		t.Fatalf("Error TestLookup: function of all devices not found (%v).", f)
+	}
+	if _, ok := Lookup(testIdentifier, 3); ok {
+		t.Fatalf("Error TestLookup: unknown function found.")
+	}
+}
+
+func TestTrace(t *testing.T) {
+	tr, buf := newTestTracer(t)
+	tr.Enable(nil)
+	tr.Trace(bricker.Outbound, testEvent("virtual", testUid, 2, &testValue{Value: 42}))
+	line := buf.String()
+	for _, s := range []string{"direction=Outbound", "connector=virtual", "uid=" + uidString(testUid),
+		"device=\"unknown hardware\"", "function=SetValue", "payload=&{Value:42}"} {
+		if !strings.Contains(line, s) {
+			t.Fatalf("Error TestTrace: %s missing in %s", s, line)
+		}
+	}
+	buf.Reset()
+	tr.Trace(bricker.Inbound, testEvent("virtual", 99, 7, &testValue{Value: 42}))
+	line = buf.String()
+	for _, s := range []string{"direction=Inbound", "device=\"unknown device\"", "function=7", "payload=\"Payload ["} {
+		if !strings.Contains(line, s) {
+			t.Fatalf("Error TestTrace: %s missing in %s", s, line)
+		}
+	}
+}
+
+func TestEnable(t *testing.T) {
+	tr, buf := newTestTracer(t)
+	traced := func(n string, uid uint32) bool {
+		buf.Reset()
+		tr.Trace(bricker.Inbound, testEvent(n, uid, 1, nil))
+		return buf.Len() > 0
+	}
+	if traced("virtual", testUid) {
+		t.Fatalf("Error TestEnable: a new tracer traces.")
+	}
+	tr.Enable("virtual")
+	if !traced("virtual", testUid) || traced("other", testUid) {
+		t.Fatalf("Error TestEnable: connector not traced.")
+	}
+	tr.Disable("virtual")
+	tr.Enable(testUid)
+	if !traced("other", testUid) || traced("virtual", 1) {
+		t.Fatalf("Error TestEnable: device not traced.")
+	}
+	tr.Enable(nil)
+	tr.Disable(nil)
+	if traced("other", testUid) || traced("virtual", 1) {
+		t.Fatalf("Error TestEnable: tracing not disabled.")
+	}
+}
@@ -2,11 +2,17 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Hashs for identify the packets, for which subscriber they are.
+/*
+Hashs for identify the packets, for which subscriber they are.
+
+A hash is a comparable key (usable as map key) of the choosen values.
+The values, which are not choosen, are zero. So a hash of a subscription and
+a hash of a incoming packet with the same chooser are equal, if the choosen values are equal.
+Creating and comparing hashs does not allocate memory.
+*/
 package hash
 
 import (
-	"crypto/md5"
 	"fmt"
 )
 
@@ -18,39 +24,32 @@ const (
 )
 
 // A hash type for subscriptions
-type Hash [md5.Size]byte
+type Hash struct {
+	Choosen    uint8  // choosen values
+	FunctionID uint8  // function id, if choosen
+	Uid        uint32 // uid, if choosen
+}
 
 // New creates a hash with given values based on the choosen ones.
 func New(choosen uint8, uid uint32, functionID uint8) Hash {
-	t := ""
+	h := Hash{Choosen: choosen & ChoosenFunctionIDUid}
 	if (choosen & ChoosenFunctionID) == ChoosenFunctionID {
-		t += fmt.Sprintf("|Function-ID=%d", functionID)
+		h.FunctionID = functionID
 	}
 	if (choosen & ChoosenUid) == ChoosenUid {
-		t += fmt.Sprintf("|Uid=%d", uid)
+		h.Uid = uid
 	}
-	t += "|"
-	return md5.Sum([]byte(t))
+	return h
 }
 
 // Equal compares to hashes, if they are equal.
 func (a Hash) Equal(b Hash) bool {
-	for i, v := range b {
-		if a[i] != v {
-			return false
-		}
-	}
-	return true
+	return a == b
 }
 
 // String fullfill the stringer interface.
 func (h Hash) String() string {
-	t := "Hash ["
-	for _, v := range h {
-		t += fmt.Sprintf(" %d", v)
-	}
-	t += " ]"
-	return t
+	return fmt.Sprintf("Hash [Choosen: %d, Function-ID: %d, Uid: %d]", h.Choosen, h.FunctionID, h.Uid)
 }
 
 // All returns a slice with all choosers.
@@ -5,6 +5,8 @@
 package hash
 
 import (
+	"crypto/md5"
+	"fmt"
 	"testing"
 )
 
@@ -54,10 +56,10 @@ func TestEqual(t *testing.T) {
 func TestString(t *testing.T) {
 	a := New(ChoosenFunctionID|ChoosenUid, 1, 2)
 	b := New(ChoosenFunctionID, 1, 2)
-	if b.String() != "Hash [ 48 166 236 22 46 87 55 77 24 214 250 90 32 100 93 22 ]" {
+	if b.String() != "Hash [Choosen: 1, Function-ID: 2, Uid: 0]" {
 		t.Fatalf("Error TestString: String not correct (%s).", b.String())
 	}
-	if a.String() == "Hash [ 48 166 236 22 46 87 55 77 24 214 250 90 32 100 93 22 ]" {
+	if a.String() == "Hash [Choosen: 1, Function-ID: 2, Uid: 0]" {
 		t.Fatalf("Error TestString: Strings should not be equal (%s).", a.String())
 	}
 }
@@ -75,3 +77,93 @@ func TestAll(t *testing.T) {
 		}
 	}
 }
+
+func TestNewNotChoosen(t *testing.T) {
+	a := New(ChoosenFunctionID, 1, 2)
+	b := New(ChoosenFunctionID, 3, 2)
+	if a != b || a.Uid != 0 {
+		t.Fatalf("Error TestNewNotChoosen: not choosen uid is used (%s != %s).", a.String(), b.String())
+	}
+	c := New(ChoosenUid|4, 1, 2)
+	if c != New(ChoosenUid, 1, 5) {
+		t.Fatalf("Error TestNewNotChoosen: unknown chooser bits are used (%s).", c.String())
+	}
+}
+
+func TestNewAllocs(t *testing.T) {
+	m := map[Hash]bool{New(ChoosenFunctionIDUid, 1, 2): true}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, c := range choosers {
+			_ = m[New(c, 1, 2)]
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("Error TestNewAllocs: lookup allocates (%f).", allocs)
+	}
+}
+
+// legacyNew is the former md5 based hash, only for the benchmarks.
+func legacyNew(choosen uint8, uid uint32, functionID uint8) [md5.Size]byte {
+	t := ""
+	if (choosen & ChoosenFunctionID) == ChoosenFunctionID {
+		t += fmt.Sprintf("|Function-ID=%d", functionID)
+	}
+	if (choosen & ChoosenUid) == ChoosenUid {
+		t += fmt.Sprintf("|Uid=%d", uid)
+	}
+	t += "|"
+	return md5.Sum([]byte(t))
+}
+
+// choosers are all choosers of the benchmarks, like the routing of a incoming packet.
+var choosers = []uint8{ChoosenNothing, ChoosenUid, ChoosenFunctionID, ChoosenFunctionIDUid}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, c := range choosers {
+			New(c, uint32(i), uint8(i))
+		}
+	}
+}
+
+func BenchmarkLegacyNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, c := range choosers {
+			legacyNew(c, uint32(i), uint8(i))
+		}
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	m := make(map[Hash]int)
+	for uid := uint32(0); uid < 64; uid++ {
+		for fid := uint8(0); fid < 16; fid++ {
+			m[New(ChoosenFunctionIDUid, uid, fid)] = int(fid)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range choosers {
+			_ = m[New(c, uint32(i%64), uint8(i%16))]
+		}
+	}
+}
+
+func BenchmarkLegacyLookup(b *testing.B) {
+	m := make(map[[md5.Size]byte]int)
+	for uid := uint32(0); uid < 64; uid++ {
+		for fid := uint8(0); fid < 16; fid++ {
+			m[legacyNew(ChoosenFunctionIDUid, uid, fid)] = int(fid)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range choosers {
+			_ = m[legacyNew(c, uint32(i%64), uint8(i%16))]
+		}
+	}
+}